@@ -0,0 +1,142 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"golang-backend/pkg/agg"
+)
+
+var _ agg.Valuer = (*Order)(nil)
+
+var sinkIndex int
+
+func orderValue(o Order) float64 { return o.Price * float64(o.Qty) }
+
+// orderValuers returns a Valuer for each order. Only *Order implements Valuer, so the
+// slice holds pointers into orders.
+func orderValuers(orders []Order) []agg.Valuer {
+	vs := make([]agg.Valuer, len(orders))
+	for i := range orders {
+		vs[i] = &orders[i]
+	}
+	return vs
+}
+
+// orderValues extracts each order's notional, the input the float64 variants take.
+func orderValues(orders []Order) []float64 {
+	xs := make([]float64, len(orders))
+	for i, o := range orders {
+		xs[i] = orderValue(o)
+	}
+	return xs
+}
+
+func TestAgg_OrdersAgree(t *testing.T) {
+	orders := sampleOrders(1000)
+	// Put the minimum somewhere other than the front.
+	orders[0], orders[500] = orders[500], orders[0]
+	xs, vs := orderValues(orders), orderValuers(orders)
+
+	sum := agg.Sum(xs)
+	if got := agg.SumValues(vs); got != sum {
+		t.Errorf("SumValues = %v, Sum = %v", got, sum)
+	}
+	if got := agg.SumFunc(orders, orderValue); got != sum {
+		t.Errorf("SumFunc = %v, Sum = %v", got, sum)
+	}
+
+	maxV, _ := agg.Max(xs)
+	if got, _ := agg.MaxValues(vs); got != maxV {
+		t.Errorf("MaxValues = %v, Max = %v", got, maxV)
+	}
+	if got, _ := agg.MaxFunc(orders, orderValue); got != maxV {
+		t.Errorf("MaxFunc = %v, Max = %v", got, maxV)
+	}
+
+	if got := agg.MinBy(xs); got != 500 {
+		t.Errorf("MinBy = %d, want 500", got)
+	}
+	if got := agg.MinByValues(vs); got != 500 {
+		t.Errorf("MinByValues = %d, want 500", got)
+	}
+	if got := agg.MinByFunc(orders, orderValue); got != 500 {
+		t.Errorf("MinByFunc = %d, want 500", got)
+	}
+}
+
+// BenchmarkAggregate runs Sum, Max, and MinBy over 1M orders through a []float64, a
+// []agg.Valuer, and the generic key-function variants. The convert cases include building
+// the input slice, which is what callers holding a []Order actually pay.
+func BenchmarkAggregate(b *testing.B) {
+	orders := sampleOrders(1_000_000)
+	xs, vs := orderValues(orders), orderValuers(orders)
+
+	b.Run("sum/float64", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkTotal = agg.Sum(xs)
+		}
+	})
+	b.Run("sum/float64+convert", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkTotal = agg.Sum(orderValues(orders))
+		}
+	})
+	b.Run("sum/valuer", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkTotal = agg.SumValues(vs)
+		}
+	})
+	b.Run("sum/valuer+convert", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkTotal = agg.SumValues(orderValuers(orders))
+		}
+	})
+	b.Run("sum/generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkTotal = agg.SumFunc(orders, orderValue)
+		}
+	})
+
+	b.Run("max/float64", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkTotal, sinkFound = agg.Max(xs)
+		}
+	})
+	b.Run("max/valuer", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkTotal, sinkFound = agg.MaxValues(vs)
+		}
+	})
+	b.Run("max/generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkTotal, sinkFound = agg.MaxFunc(orders, orderValue)
+		}
+	})
+
+	b.Run("minby/float64", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkIndex = agg.MinBy(xs)
+		}
+	})
+	b.Run("minby/valuer", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkIndex = agg.MinByValues(vs)
+		}
+	})
+	b.Run("minby/generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkIndex = agg.MinByFunc(orders, orderValue)
+		}
+	})
+}
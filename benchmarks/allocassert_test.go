@@ -0,0 +1,51 @@
+package benchmarks
+
+import "testing"
+
+// allocRuns is the number of iterations testing.AllocsPerRun averages over.
+const allocRuns = 1000
+
+// assertAllocs fails the test when fn does not allocate exactly want times per call.
+func assertAllocs(t *testing.T, name string, want float64, fn func()) {
+	t.Helper()
+
+	if got := testing.AllocsPerRun(allocRuns, fn); got != want {
+		t.Errorf("%s: got %v allocs/op, want %v", name, got, want)
+	}
+}
+
+// skipIfRace skips tests whose allocation counts are perturbed by the race detector.
+func skipIfRace(t *testing.T) {
+	t.Helper()
+
+	if raceEnabled {
+		t.Skip("allocation counts are not stable under the race detector")
+	}
+}
+
+func TestAllocs_ReturnConcrete(t *testing.T) {
+	assertAllocs(t, "ReturnConcrete", 0, func() {
+		sinkConcrete = ReturnConcrete(1)
+	})
+}
+
+func TestAllocs_ReturnPointer(t *testing.T) {
+	assertAllocs(t, "ReturnPointer", 1, func() {
+		sinkPointer = ReturnPointer(1)
+	})
+}
+
+func TestAllocs_PoolCycle(t *testing.T) {
+	skipIfRace(t)
+
+	// Warm the pool so AllocsPerRun measures the steady state.
+	objPool.Put(objPool.Get())
+
+	assertAllocs(t, "objPool Get/reset/Put", 0, func() {
+		obj := objPool.Get()
+		obj.ID = 1
+		obj.IsActive = true
+		globalSinkPool = obj
+		objPool.Put(obj)
+	})
+}
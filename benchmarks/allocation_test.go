@@ -1,6 +1,9 @@
-package main
+package benchmarks
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 // SmallObject is a compact struct that should stay on the stack when returned by value.
 type SmallObject struct {
@@ -40,3 +43,73 @@ func BenchmarkGetConcrete(b *testing.B) {
 		sinkConcrete = ReturnConcrete(int64(i))
 	}
 }
+
+// sliceSizes are the element counts used by the slice growth benchmarks.
+var sliceSizes = []int{10, 1_000, 100_000}
+
+var sinkOrders []Order
+
+// TestBuildOrders ensures both growth strategies produce the same contents.
+func TestBuildOrders(t *testing.T) {
+	for _, prealloc := range []bool{false, true} {
+		orders := BuildOrders(100, prealloc)
+		if len(orders) != 100 {
+			t.Fatalf("prealloc=%v: len = %d, want 100", prealloc, len(orders))
+		}
+		for i, o := range orders {
+			if o.Price != float64(i) || o.Qty != int64(i) {
+				t.Fatalf("prealloc=%v: orders[%d] = %+v, want {Price:%d Qty:%d}", prealloc, i, o, i, i)
+			}
+		}
+	}
+
+	if got := cap(BuildOrders(100, true)); got != 100 {
+		t.Errorf("prealloc cap = %d, want 100", got)
+	}
+	if got := BuildOrders(0, false); got != nil {
+		t.Errorf("BuildOrders(0, false) = %v, want nil", got)
+	}
+}
+
+// BenchmarkAppendNil measures append growth from a nil slice (repeated reallocation).
+func BenchmarkAppendNil(b *testing.B) {
+	for _, n := range sliceSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkOrders = BuildOrders(n, false)
+			}
+		})
+	}
+}
+
+// BenchmarkAppendPrealloc measures append into a slice created with make([]Order, 0, n).
+func BenchmarkAppendPrealloc(b *testing.B) {
+	for _, n := range sliceSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkOrders = BuildOrders(n, true)
+			}
+		})
+	}
+}
+
+// BenchmarkIndexAssign measures index assignment into a slice created with make([]Order, n).
+func BenchmarkIndexAssign(b *testing.B) {
+	for _, n := range sliceSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				orders := make([]Order, n)
+				for i := range orders {
+					orders[i] = Order{
+						Price: float64(i),
+						Qty:   int64(i),
+					}
+				}
+				sinkOrders = orders
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package benchmarks
+
+import (
+	"sync"
+	"testing"
+
+	"golang-backend/pkg/slab"
+)
+
+// orderBurst is the number of Orders built and discarded per op, the size of a large
+// request-scoped batch.
+const orderBurst = 100_000
+
+var burstOrderPool = sync.Pool{New: func() any { return new(Order) }}
+
+// BenchmarkOrderBurst builds 100k Orders, keeps pointers to all of them for the op as a
+// request handler would, then discards them: one new(Order) each, a sync.Pool Get and Put
+// each, or a slab.Arena handing out pointers into 1024-element chunks and released at once.
+func BenchmarkOrderBurst(b *testing.B) {
+	live := make([]*Order, orderBurst)
+
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+		ReportGC(b)
+		for range b.N {
+			for i := range live {
+				o := new(Order)
+				o.Qty = int64(i)
+				live[i] = o
+			}
+			sinkOrder = live[len(live)-1]
+			clear(live)
+		}
+	})
+
+	b.Run("sync.Pool", func(b *testing.B) {
+		b.ReportAllocs()
+		ReportGC(b)
+		for range b.N {
+			for i := range live {
+				o := burstOrderPool.Get().(*Order)
+				*o = Order{Qty: int64(i)}
+				live[i] = o
+			}
+			sinkOrder = live[len(live)-1]
+			for i, o := range live {
+				burstOrderPool.Put(o)
+				live[i] = nil
+			}
+		}
+	})
+
+	b.Run("arena", func(b *testing.B) {
+		a, err := slab.NewArena[Order](slab.DefaultChunkSize)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		ReportGC(b)
+		for range b.N {
+			for i := range live {
+				o := a.New()
+				o.Qty = int64(i)
+				live[i] = o
+			}
+			sinkOrder = live[len(live)-1]
+			clear(live)
+			a.Release()
+		}
+	})
+	sinkOrder = nil
+}
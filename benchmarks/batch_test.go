@@ -0,0 +1,246 @@
+package benchmarks
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	batchOrders  = 10_000
+	batchWorkers = 8
+)
+
+// processOrder stands in for per-order batch work: it rejects negative quantities and
+// otherwise does a little arithmetic.
+func processOrder(o *Order) error {
+	if o.Qty < 0 {
+		return errInvalidOrder
+	}
+	v := o.Price
+	for range 16 {
+		v = v*1.0001 + 1
+	}
+	o.Price = v
+	return nil
+}
+
+// batchChunks splits orders into one contiguous chunk per worker.
+func batchChunks(orders []Order, workers int) iter.Seq[[]Order] {
+	return slices.Chunk(orders, max((len(orders)+workers-1)/workers, 1))
+}
+
+// processWaitGroup runs one goroutine per chunk and keeps the first error with sync.Once.
+// Nothing tells the other workers about a failure, so they finish their chunks regardless.
+func processWaitGroup(orders []Order, workers int, fn func(*Order) error) error {
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	for chunk := range batchChunks(orders, workers) {
+		wg.Go(func() {
+			for i := range chunk {
+				if err := fn(&chunk[i]); err != nil {
+					once.Do(func() { first = err })
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	return first
+}
+
+// processResultChan has each worker send its outcome on a results channel, which the
+// caller drains. A failing worker also closes done, which the others check before each
+// order. Closing it from the worker rather than from the caller loop matters: the caller
+// may not be scheduled until the other workers have run through their chunks.
+func processResultChan(orders []Order, workers int, fn func(*Order) error) error {
+	done := make(chan struct{})
+	stop := sync.OnceFunc(func() { close(done) })
+	results := make(chan error, workers)
+	started := 0
+	for chunk := range batchChunks(orders, workers) {
+		started++
+		go func() {
+			for i := range chunk {
+				select {
+				case <-done:
+					results <- nil
+					return
+				default:
+				}
+				if err := fn(&chunk[i]); err != nil {
+					stop()
+					results <- err
+					return
+				}
+			}
+			results <- nil
+		}()
+	}
+
+	var first error
+	for range started {
+		if err := <-results; err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// errGroup is a minimal clone of golang.org/x/sync/errgroup: Go runs functions on new
+// goroutines, and the first error cancels the group's context and is returned by Wait.
+type errGroup struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+// withErrGroup returns a group and a context cancelled by its first error or by Wait.
+func withErrGroup(ctx context.Context) (*errGroup, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &errGroup{cancel: cancel}, ctx
+}
+
+func (g *errGroup) Go(fn func() error) {
+	g.wg.Go(func() {
+		if err := fn(); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				g.cancel()
+			})
+		}
+	})
+}
+
+func (g *errGroup) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
+
+// processErrGroup runs one errGroup function per chunk; each checks the group's context
+// before every order.
+func processErrGroup(orders []Order, workers int, fn func(*Order) error) error {
+	g, ctx := withErrGroup(context.Background())
+	for chunk := range batchChunks(orders, workers) {
+		g.Go(func() error {
+			for i := range chunk {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				if err := fn(&chunk[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+var batchProcessors = []struct {
+	name   string
+	cancel bool // whether a failure stops the other workers
+	run    func(orders []Order, workers int, fn func(*Order) error) error
+}{
+	{name: "waitgroup", run: processWaitGroup},
+	{name: "result-chan", cancel: true, run: processResultChan},
+	{name: "errgroup", cancel: true, run: processErrGroup},
+}
+
+// batchInput returns n valid orders, with the first made invalid if fail is set. The first
+// order is at the start of the first worker's chunk, so it fails before other work.
+func batchInput(n int, fail bool) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{Price: float64(i) + 1, Qty: int64(i + 1)}
+	}
+	if fail {
+		orders[0].Qty = -1
+	}
+	return orders
+}
+
+func TestBatchProcessors_NoError(t *testing.T) {
+	for _, p := range batchProcessors {
+		before := runtime.NumGoroutine()
+		orders := batchInput(batchOrders, false)
+		var processed atomic.Int64
+		err := p.run(orders, batchWorkers, func(o *Order) error {
+			processed.Add(1)
+			return processOrder(o)
+		})
+		if err != nil {
+			t.Errorf("%s: %v", p.name, err)
+		}
+		if got := processed.Load(); got != batchOrders {
+			t.Errorf("%s: processed %d orders, want %d", p.name, got, batchOrders)
+		}
+		waitForGoroutines(t, before)
+	}
+}
+
+func TestBatchProcessors_EarlyError(t *testing.T) {
+	const chunk = batchOrders / batchWorkers
+	for _, p := range batchProcessors {
+		before := runtime.NumGoroutine()
+		orders := batchInput(batchOrders, true)
+
+		// Every other order waits until the failing one has been seen, so no worker can
+		// race through its chunk before there is an error to react to.
+		failed := make(chan struct{})
+		var processed atomic.Int64
+		err := p.run(orders, batchWorkers, func(o *Order) error {
+			if o.Qty < 0 {
+				close(failed)
+				return errInvalidOrder
+			}
+			<-failed
+			processed.Add(1)
+			return processOrder(o)
+		})
+		if !errors.Is(err, errInvalidOrder) {
+			t.Errorf("%s: error = %v, want errInvalidOrder", p.name, err)
+		}
+
+		got := processed.Load()
+		if p.cancel && got > batchOrders/2 {
+			t.Errorf("%s: processed %d of %d orders after the first failed, want the rest skipped", p.name, got, batchOrders)
+		}
+		if !p.cancel && got != batchOrders-chunk {
+			t.Errorf("%s: processed %d orders, want the other workers' %d", p.name, got, batchOrders-chunk)
+		}
+		waitForGoroutines(t, before)
+	}
+}
+
+// BenchmarkBatchProcess processes 10k orders with 8 workers, succeeding or failing on the
+// first order, with a WaitGroup and sync.Once, a drained results channel, and an errgroup
+// clone.
+func BenchmarkBatchProcess(b *testing.B) {
+	for _, fail := range []bool{false, true} {
+		name := "no-error"
+		if fail {
+			name = "early-error"
+		}
+		// processOrder only rewrites prices, so the same input serves every op.
+		orders := batchInput(batchOrders, fail)
+		for _, p := range batchProcessors {
+			b.Run(name+"/"+p.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					sinkErr = p.run(orders, batchWorkers, processOrder)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,399 @@
+package benchmarks
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	batcherSize     = 128
+	batcherInterval = 5 * time.Millisecond
+)
+
+// afterFuncTimer is the shape of time.AfterFunc the batcher depends on: it runs f once d
+// has passed and returns a function that cancels it. Tests substitute a timer they fire
+// by hand.
+type afterFuncTimer func(d time.Duration, f func()) (stop func() bool)
+
+func realAfterFunc(d time.Duration, f func()) func() bool {
+	return time.AfterFunc(d, f).Stop
+}
+
+// batcher collects orders into batches and hands each one to flush on a background
+// goroutine once it holds size orders or interval has passed since its first order,
+// whichever comes first.
+//
+// Add only takes a mutex and appends; the channel to the flushing goroutine carries one
+// send per batch rather than one per order. A full batch is sent with the mutex held, so
+// a slow flush pushes back on every Add rather than letting batches pile up.
+type batcher struct {
+	size      int
+	interval  time.Duration
+	afterFunc afterFuncTimer
+
+	mu        sync.Mutex
+	batch     []*Order
+	gen       uint64 // incremented per sent batch, so a stale timer does not flush the next one
+	stopTimer func() bool
+	closed    bool
+
+	batches chan []*Order
+	done    chan struct{}
+}
+
+// newBatcher starts a batcher calling flush with each batch. flush runs on a single
+// goroutine and owns the slice it is given.
+func newBatcher(size int, interval time.Duration, afterFunc afterFuncTimer, flush func([]*Order)) *batcher {
+	b := &batcher{
+		size:      size,
+		interval:  interval,
+		afterFunc: afterFunc,
+		batch:     make([]*Order, 0, size),
+		// One batch can wait while the previous one is flushed.
+		batches: make(chan []*Order, 1),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(b.done)
+		for batch := range b.batches {
+			flush(batch)
+		}
+	}()
+	return b
+}
+
+// Add appends o to the current batch, sending the batch if that fills it. Add must not be
+// called after Close.
+func (b *batcher) Add(o *Order) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		panic("batcher: Add after Close")
+	}
+
+	b.batch = append(b.batch, o)
+	if len(b.batch) == 1 {
+		gen := b.gen
+		b.stopTimer = b.afterFunc(b.interval, func() { b.flushTimer(gen) })
+	}
+	if len(b.batch) >= b.size {
+		b.sendLocked()
+	}
+}
+
+// flushTimer sends the current batch if it is still the one the timer was armed for.
+func (b *batcher) flushTimer(gen uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.gen == gen && !b.closed && len(b.batch) > 0 {
+		b.sendLocked()
+	}
+}
+
+// sendLocked hands the current batch to the flushing goroutine and starts a new one.
+func (b *batcher) sendLocked() {
+	if b.stopTimer != nil {
+		b.stopTimer()
+		b.stopTimer = nil
+	}
+	b.gen++
+	b.batches <- b.batch
+	b.batch = make([]*Order, 0, b.size)
+}
+
+// Close sends any partial batch and waits until every batch has been flushed. Closing
+// twice is safe.
+func (b *batcher) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		if len(b.batch) > 0 {
+			b.sendLocked()
+		}
+		close(b.batches)
+	}
+	b.mu.Unlock()
+	<-b.done
+}
+
+// fakeAfterFunc records the timers a batcher arms; fire runs the most recent one as if it
+// had expired.
+type fakeAfterFunc struct {
+	mu      sync.Mutex
+	d       time.Duration
+	f       func()
+	armed   int
+	stopped int
+}
+
+func (t *fakeAfterFunc) afterFunc(d time.Duration, f func()) func() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.d, t.f = d, f
+	t.armed++
+	return func() bool {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.stopped++
+		return true
+	}
+}
+
+func (t *fakeAfterFunc) fire() {
+	t.mu.Lock()
+	f := t.f
+	t.mu.Unlock()
+	f()
+}
+
+// batchRecorder collects flushed batches and reports each one on a channel.
+type batchRecorder struct {
+	flushed chan []*Order
+}
+
+func newBatchRecorder() *batchRecorder {
+	return &batchRecorder{flushed: make(chan []*Order, 64)}
+}
+
+func (r *batchRecorder) flush(batch []*Order) { r.flushed <- batch }
+
+// next waits for the next flushed batch.
+func (r *batchRecorder) next(t *testing.T) []*Order {
+	t.Helper()
+	select {
+	case batch := <-r.flushed:
+		return batch
+	case <-time.After(time.Second):
+		t.Fatal("no batch flushed within a second")
+		return nil
+	}
+}
+
+// none checks nothing has been flushed. The flushing goroutine may still be about to
+// deliver, so this only catches flushes that have already happened.
+func (r *batchRecorder) none(t *testing.T) {
+	t.Helper()
+	select {
+	case batch := <-r.flushed:
+		t.Fatalf("unexpected flush of %d orders", len(batch))
+	default:
+	}
+}
+
+func newOrderPtrs(n int) []*Order {
+	orders := make([]*Order, n)
+	for i := range orders {
+		orders[i] = &Order{Price: float64(i), Qty: int64(i)}
+	}
+	return orders
+}
+
+func TestBatcher_FlushOnSize(t *testing.T) {
+	var timer fakeAfterFunc
+	rec := newBatchRecorder()
+	b := newBatcher(4, batcherInterval, timer.afterFunc, rec.flush)
+	orders := newOrderPtrs(10)
+
+	for _, o := range orders {
+		b.Add(o)
+	}
+	if got := rec.next(t); !slices.Equal(got, orders[:4]) {
+		t.Errorf("first batch = %v, want orders 0-3", got)
+	}
+	if got := rec.next(t); !slices.Equal(got, orders[4:8]) {
+		t.Errorf("second batch = %v, want orders 4-7", got)
+	}
+	rec.none(t)
+
+	// Each full batch cancelled the timer armed by its first order.
+	timer.mu.Lock()
+	armed, stopped := timer.armed, timer.stopped
+	timer.mu.Unlock()
+	if armed != 3 || stopped != 2 {
+		t.Errorf("timers armed %d, stopped %d; want 3 and 2", armed, stopped)
+	}
+	b.Close()
+}
+
+func TestBatcher_FlushOnTimer(t *testing.T) {
+	var timer fakeAfterFunc
+	rec := newBatchRecorder()
+	b := newBatcher(batcherSize, batcherInterval, timer.afterFunc, rec.flush)
+	defer b.Close()
+	orders := newOrderPtrs(3)
+
+	for _, o := range orders {
+		b.Add(o)
+	}
+	rec.none(t)
+	if timer.d != batcherInterval {
+		t.Errorf("timer armed for %v, want %v", timer.d, batcherInterval)
+	}
+
+	timer.fire()
+	if got := rec.next(t); !slices.Equal(got, orders) {
+		t.Errorf("timer flushed %v, want all 3 orders", got)
+	}
+}
+
+func TestBatcher_StaleTimerDoesNotFlushNextBatch(t *testing.T) {
+	var timer fakeAfterFunc
+	rec := newBatchRecorder()
+	b := newBatcher(2, batcherInterval, timer.afterFunc, rec.flush)
+	defer b.Close()
+	orders := newOrderPtrs(3)
+
+	b.Add(orders[0])
+	stale := timer.f
+	b.Add(orders[1]) // fills the batch
+	rec.next(t)
+	b.Add(orders[2]) // starts a new batch with its own timer
+
+	// The first timer's callback may already be running when it is stopped; it must not
+	// flush the batch that replaced its own.
+	stale()
+	rec.none(t)
+	timer.fire()
+	if got := rec.next(t); !slices.Equal(got, orders[2:]) {
+		t.Errorf("second timer flushed %v, want order 2", got)
+	}
+}
+
+func TestBatcher_CloseDrainsPartialBatch(t *testing.T) {
+	before := runtime.NumGoroutine()
+	var timer fakeAfterFunc
+	rec := newBatchRecorder()
+	b := newBatcher(batcherSize, batcherInterval, timer.afterFunc, rec.flush)
+	orders := newOrderPtrs(5)
+
+	for _, o := range orders {
+		b.Add(o)
+	}
+	b.Close()
+	if got := rec.next(t); !slices.Equal(got, orders) {
+		t.Errorf("Close flushed %v, want the 5 pending orders", got)
+	}
+	b.Close()
+	rec.none(t)
+	waitForGoroutines(t, before)
+
+	// A timer firing after Close has nothing to do.
+	timer.fire()
+	rec.none(t)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Add after Close did not panic")
+		}
+	}()
+	b.Add(orders[0])
+}
+
+func TestBatcher_ConcurrentAdders(t *testing.T) {
+	const (
+		adders   = 8
+		perAdder = 5000
+	)
+	orders := newOrderPtrs(adders * perAdder)
+
+	seen := make(map[*Order]int, len(orders))
+	oversized := 0
+	b := newBatcher(batcherSize, batcherInterval, realAfterFunc, func(batch []*Order) {
+		// flush runs on one goroutine, so seen needs no lock.
+		if len(batch) > batcherSize {
+			oversized++
+		}
+		for _, o := range batch {
+			seen[o]++
+		}
+	})
+
+	var wg sync.WaitGroup
+	for chunk := range slices.Chunk(orders, perAdder) {
+		wg.Go(func() {
+			for i, o := range chunk {
+				b.Add(o)
+				if i%1000 == 0 {
+					time.Sleep(batcherInterval) // let some batches go out on the timer
+				}
+			}
+		})
+	}
+	wg.Wait()
+	b.Close()
+
+	if oversized > 0 {
+		t.Errorf("%d batches exceeded %d orders", oversized, batcherSize)
+	}
+	if len(seen) != len(orders) {
+		t.Errorf("flushed %d distinct orders, want %d", len(seen), len(orders))
+	}
+	for o, n := range seen {
+		if n != 1 {
+			t.Fatalf("order %v flushed %d times", *o, n)
+		}
+	}
+}
+
+// BenchmarkBatchSink measures the cost per order of handing orders to a consumer
+// goroutine, one channel send per order against a batcher flushing 128 at a time, from
+// one producer and from every P. Each op includes its share of draining and closing.
+func BenchmarkBatchSink(b *testing.B) {
+	orders := newOrderPtrs(1024)
+
+	sinks := []struct {
+		name string
+		// start returns add, which must be safe for concurrent use, and a close that
+		// waits for the consumer to finish.
+		start func() (add func(*Order), close func())
+	}{
+		{name: "channel", start: func() (func(*Order), func()) {
+			ch := make(chan *Order, batcherSize)
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				var total float64
+				for o := range ch {
+					total += o.Price
+				}
+				sinkTotal = total
+			}()
+			return func(o *Order) { ch <- o }, func() { close(ch); <-done }
+		}},
+		{name: "batcher", start: func() (func(*Order), func()) {
+			var total float64
+			bt := newBatcher(batcherSize, batcherInterval, realAfterFunc, func(batch []*Order) {
+				for _, o := range batch {
+					total += o.Price
+				}
+			})
+			return bt.Add, func() { bt.Close(); sinkTotal = total }
+		}},
+	}
+	for _, s := range sinks {
+		b.Run(s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			add, closeSink := s.start()
+			for i := range b.N {
+				add(orders[i%len(orders)])
+			}
+			closeSink()
+		})
+		b.Run(s.name+"/parallel", func(b *testing.B) {
+			runWithProcs(b, func(b *testing.B) {
+				add, closeSink := s.start()
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						add(orders[i%len(orders)])
+						i++
+					}
+				})
+				closeSink()
+			})
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package benchmarks
+
+import (
+	"fmt"
+	"testing"
+)
+
+// bigHandoffs is the number of BigObjects handed from sender to receiver per op.
+const bigHandoffs = 100_000
+
+// bigHandoffBuffers are the channel capacities the handoff benchmarks run at.
+var bigHandoffBuffers = []int{1, 1024}
+
+// fillHandoff writes the fields of o that handoff number i carries.
+func fillHandoff(o *BigObject, i int) {
+	o.ID = int64(i)
+	o.Data[i%len(o.Data)] = int64(i)
+	o.IsActive = true
+}
+
+// readHandoff is the receiver's work: it reads back what fillHandoff wrote for i.
+func readHandoff(o *BigObject, i int) int64 {
+	return o.ID + o.Data[i%len(o.Data)]
+}
+
+// handoffByValue sends n BigObjects by value. The sender reuses one local object, and each
+// send and receive copies its 8KB into and out of the channel buffer.
+func handoffByValue(n, capacity int) int64 {
+	ch := make(chan BigObject, capacity)
+	go func() {
+		var o BigObject
+		for i := range n {
+			fillHandoff(&o, i)
+			ch <- o
+		}
+		close(ch)
+	}()
+
+	var total int64
+	i := 0
+	for o := range ch {
+		total += readHandoff(&o, i)
+		i++
+	}
+	return total
+}
+
+// handoffByPointer sends n freshly allocated *BigObjects. No data is copied, but every
+// object is a heap allocation, and the sender must not touch one once sent.
+func handoffByPointer(n, capacity int) int64 {
+	ch := make(chan *BigObject, capacity)
+	go func() {
+		for i := range n {
+			o := new(BigObject)
+			fillHandoff(o, i)
+			ch <- o
+		}
+		close(ch)
+	}()
+
+	var total int64
+	i := 0
+	for o := range ch {
+		total += readHandoff(o, i)
+		i++
+	}
+	return total
+}
+
+// handoffPooled sends *BigObjects taken from objPool, and the receiver puts each back once
+// processed. Ownership passes with the pointer: the sender gives it up at the send and the
+// receiver at the Put, which also zeroes the object for its next user.
+func handoffPooled(n, capacity int) int64 {
+	ch := make(chan *BigObject, capacity)
+	go func() {
+		for i := range n {
+			o := objPool.Get()
+			fillHandoff(o, i)
+			ch <- o
+		}
+		close(ch)
+	}()
+
+	var total int64
+	i := 0
+	for o := range ch {
+		total += readHandoff(o, i)
+		objPool.Put(o)
+		i++
+	}
+	return total
+}
+
+var bigHandoffPatterns = []struct {
+	name    string
+	handoff func(n, capacity int) int64
+}{
+	{name: "value", handoff: handoffByValue},
+	{name: "pointer", handoff: handoffByPointer},
+	{name: "pooled", handoff: handoffPooled},
+}
+
+func TestBigHandoffs_DeliverEveryObject(t *testing.T) {
+	const n = 5000
+	var want int64
+	for i := range n {
+		want += 2 * int64(i)
+	}
+	for _, p := range bigHandoffPatterns {
+		for _, capacity := range bigHandoffBuffers {
+			if got := p.handoff(n, capacity); got != want {
+				t.Errorf("%s, buf=%d: receiver total %d, want %d", p.name, capacity, got, want)
+			}
+		}
+	}
+}
+
+// TestBigHandoff_PointerAliasing shows the hazard the copy protects against: once a pointer
+// is sent, the sender and receiver share one object, so a change the sender makes after
+// the send is what the receiver reads. A value send hands over a snapshot instead. Both
+// ends run on one goroutine here to keep the demonstration free of a data race; across
+// goroutines the same mistake is a race as well.
+func TestBigHandoff_PointerAliasing(t *testing.T) {
+	o := &BigObject{ID: 1}
+
+	byValue := make(chan BigObject, 1)
+	byValue <- *o
+	o.ID = 2 // the sender keeps using its object after the send
+	if got := (<-byValue).ID; got != 1 {
+		t.Errorf("value send: receiver saw ID %d, want the 1 that was sent", got)
+	}
+
+	byPointer := make(chan *BigObject, 1)
+	byPointer <- o
+	o.ID = 3
+	if got := (<-byPointer).ID; got != 3 {
+		t.Errorf("pointer send: receiver saw ID %d, want the sender's later 3", got)
+	}
+}
+
+// BenchmarkBigObjectHandoff hands 100k BigObjects per op from a sender goroutine to a
+// receiver over channels of capacity 1 and 1024: by value, copying 8KB in and out of
+// the channel; as a new pointer, allocating 8KB each; and as a pointer from objPool that
+// the receiver returns, which allocates nothing but zeroes each object on Put. The fresh
+// pointers are the slowest of the three: 100k 8KB allocations per op keep the GC busy,
+// costing more than the copies they avoid. A 1024-slot value channel is itself an 8MB
+// buffer allocated with the channel.
+func BenchmarkBigObjectHandoff(b *testing.B) {
+	for _, capacity := range bigHandoffBuffers {
+		for _, p := range bigHandoffPatterns {
+			b.Run(fmt.Sprintf("buf=%d/%s", capacity, p.name), func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					sinkPipelineQty = p.handoff(bigHandoffs, capacity)
+				}
+				b.ReportMetric(float64(bigHandoffs)*float64(b.N)/b.Elapsed().Seconds(), "handoffs/s")
+			})
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package benchmarks
+
+// BigObject simulates a significant memory footprint (approx 8KB).
+type BigObject struct {
+	ID       int64       `json:"id"`
+	Name     string      `json:"name"`
+	Data     [1024]int64 `json:"data"` // 1024 * 8 bytes = 8KB
+	IsActive bool        `json:"is_active"`
+}
+
+// Reset zeroes every field, including the 8KB Data array, so a pooled object
+// never carries a previous user's data to the next one.
+func (o *BigObject) Reset() {
+	*o = BigObject{}
+}
+
+// Clone returns a copy of o for handing to code that must not see later changes to it.
+// Every field is a value or an immutable string, so copying the struct is a deep copy.
+func (o *BigObject) Clone() *BigObject {
+	c := *o
+	return &c
+}
+
+// ChecksumByValue sums ID and Data using a value receiver, copying the 8KB BigObject per call.
+//
+//go:noinline
+func (o BigObject) ChecksumByValue() int64 {
+	sum := o.ID
+	for _, v := range o.Data {
+		sum += v
+	}
+	return sum
+}
+
+// ChecksumByPointer sums ID and Data using a pointer receiver.
+//
+//go:noinline
+func (o *BigObject) ChecksumByPointer() int64 {
+	sum := o.ID
+	for _, v := range o.Data {
+		sum += v
+	}
+	return sum
+}
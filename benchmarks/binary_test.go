@@ -0,0 +1,169 @@
+package benchmarks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// orderWireSize is the encoded size of an Order: a float64 followed by an int64.
+const orderWireSize = 16
+
+// putOrder packs o into dst by hand, little-endian.
+func putOrder(dst *[orderWireSize]byte, o *Order) {
+	binary.LittleEndian.PutUint64(dst[0:8], math.Float64bits(o.Price))
+	binary.LittleEndian.PutUint64(dst[8:16], uint64(o.Qty))
+}
+
+// readOrder unpacks an Order written by putOrder.
+func readOrder(src *[orderWireSize]byte) Order {
+	return Order{
+		Price: math.Float64frombits(binary.LittleEndian.Uint64(src[0:8])),
+		Qty:   int64(binary.LittleEndian.Uint64(src[8:16])),
+	}
+}
+
+// wireOrders covers the float values whose bits a lossy encoding would change.
+var wireOrders = []Order{
+	{Price: 99.5, Qty: 10},
+	{Price: math.Copysign(0, -1), Qty: -1},
+	{Price: math.Inf(1), Qty: math.MaxInt64},
+	{Price: math.Inf(-1), Qty: math.MinInt64},
+	{Price: math.Float64frombits(0x7ff8_0000_dead_beef), Qty: 0}, // NaN with a payload
+	{Price: math.SmallestNonzeroFloat64, Qty: 1},
+}
+
+// sameOrderBits compares Price bit-for-bit, so NaN and -0 are checked exactly.
+func sameOrderBits(a, b Order) bool {
+	return math.Float64bits(a.Price) == math.Float64bits(b.Price) && a.Qty == b.Qty
+}
+
+func TestOrderWire_RoundTrip(t *testing.T) {
+	for _, o := range wireOrders {
+		var manual [orderWireSize]byte
+		putOrder(&manual, &o)
+		if got := readOrder(&manual); !sameOrderBits(got, o) {
+			t.Errorf("manual: got %#x/%d, want %#x/%d",
+				math.Float64bits(got.Price), got.Qty, math.Float64bits(o.Price), o.Qty)
+		}
+
+		var buf bytes.Buffer
+		if err := binary.Write(&buf, binary.LittleEndian, &o); err != nil {
+			t.Fatalf("binary.Write: %v", err)
+		}
+		if !bytes.Equal(buf.Bytes(), manual[:]) {
+			t.Errorf("binary.Write = %x, manual = %x", buf.Bytes(), manual[:])
+		}
+		var viaRead Order
+		if err := binary.Read(&buf, binary.LittleEndian, &viaRead); err != nil {
+			t.Fatalf("binary.Read: %v", err)
+		}
+		if !sameOrderBits(viaRead, o) {
+			t.Errorf("binary.Read: got %+v, want %+v", viaRead, o)
+		}
+
+		appended, err := binary.Append(nil, binary.LittleEndian, &o)
+		if err != nil {
+			t.Fatalf("binary.Append: %v", err)
+		}
+		if !bytes.Equal(appended, manual[:]) {
+			t.Errorf("binary.Append = %x, manual = %x", appended, manual[:])
+		}
+		var viaDecode Order
+		if _, err := binary.Decode(appended, binary.LittleEndian, &viaDecode); err != nil {
+			t.Fatalf("binary.Decode: %v", err)
+		}
+		if !sameOrderBits(viaDecode, o) {
+			t.Errorf("binary.Decode: got %+v, want %+v", viaDecode, o)
+		}
+	}
+}
+
+func TestAllocs_PutOrder(t *testing.T) {
+	o := jsonSampleOrder
+	var dst [orderWireSize]byte
+
+	assertAllocs(t, "putOrder", 0, func() {
+		putOrder(&dst, &o)
+	})
+}
+
+var (
+	sinkWire      [orderWireSize]byte
+	sinkWireOrder Order
+)
+
+// BenchmarkOrderEncode compares binary.Write, binary.Append, and manual PutUint64 packing.
+func BenchmarkOrderEncode(b *testing.B) {
+	o := jsonSampleOrder
+
+	b.Run("binary.Write", func(b *testing.B) {
+		var buf bytes.Buffer
+		buf.Grow(orderWireSize)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			buf.Reset()
+			if err := binary.Write(&buf, binary.LittleEndian, &o); err != nil {
+				b.Fatal(err)
+			}
+		}
+		sinkLen = buf.Len()
+	})
+
+	b.Run("binary.Append", func(b *testing.B) {
+		dst := make([]byte, 0, orderWireSize)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			var err error
+			dst, err = binary.Append(dst[:0], binary.LittleEndian, &o)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+		sinkLen = len(dst)
+	})
+
+	b.Run("manual", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			putOrder(&sinkWire, &o)
+		}
+	})
+}
+
+// BenchmarkOrderDecode compares binary.Read, binary.Decode, and manual Uint64 unpacking.
+func BenchmarkOrderDecode(b *testing.B) {
+	var wire [orderWireSize]byte
+	putOrder(&wire, &jsonSampleOrder)
+
+	b.Run("binary.Read", func(b *testing.B) {
+		r := bytes.NewReader(wire[:])
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			r.Reset(wire[:])
+			if err := binary.Read(r, binary.LittleEndian, &sinkWireOrder); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("binary.Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := binary.Decode(wire[:], binary.LittleEndian, &sinkWireOrder); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("manual", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkWireOrder = readOrder(&wire)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package benchmarks
+
+import (
+	"encoding/binary"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"golang-backend/pkg/bloom"
+)
+
+// bloomFPRate is the false-positive target the benchmark filters are sized for.
+const bloomFPRate = 0.01
+
+// existenceKey writes order key i into buf: an 8-byte id, the shape of a dedup or
+// "seen this order?" check. Keys at or above the filled count were never added.
+func existenceKey(buf []byte, i int) []byte {
+	return binary.BigEndian.AppendUint64(buf[:0], uint64(i))
+}
+
+// existenceSet is the operations both a Bloom filter and a set map offer.
+type existenceSet interface {
+	add(key []byte)
+	mayContain(key []byte) bool
+}
+
+type bloomSet struct{ f *bloom.Filter }
+
+func (s bloomSet) add(key []byte)             { s.f.Add(key) }
+func (s bloomSet) mayContain(key []byte) bool { return s.f.MayContain(key) }
+
+type mapSet map[string]struct{}
+
+func (s mapSet) add(key []byte) { s[string(key)] = struct{}{} }
+func (s mapSet) mayContain(key []byte) bool {
+	_, ok := s[string(key)]
+	return ok
+}
+
+// existenceKinds create an empty set sized for n keys.
+var existenceKinds = []struct {
+	name string
+	make func(tb testing.TB, n int) existenceSet
+}{
+	{name: "bloom", make: func(tb testing.TB, n int) existenceSet {
+		f, err := bloom.New(n, bloomFPRate)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		return bloomSet{f}
+	}},
+	{name: "map", make: func(_ testing.TB, n int) existenceSet {
+		return make(mapSet, n)
+	}},
+}
+
+// filledSet returns a set holding keys [0, n) and the heap it retains, measured as the
+// MemStats delta across building it.
+func filledSet(tb testing.TB, n int, makeSet func(testing.TB, int) existenceSet) (existenceSet, uint64) {
+	before := liveHeap()
+	s := makeSet(tb, n)
+	var buf [8]byte
+	for i := range n {
+		s.add(existenceKey(buf[:], i))
+	}
+	after := liveHeap()
+	return s, after - min(before, after)
+}
+
+func TestExistenceSets_Agree(t *testing.T) {
+	const n = 100_000
+	for _, k := range existenceKinds {
+		s, _ := filledSet(t, n, k.make)
+		var buf [8]byte
+		falsePositives := 0
+		for i := range 2 * n {
+			got := s.mayContain(existenceKey(buf[:], i))
+			switch {
+			case i < n && !got:
+				t.Fatalf("%s: added key %d reported absent", k.name, i)
+			case i >= n && got:
+				falsePositives++
+			}
+		}
+		limit := 0 // the map is exact
+		if k.name == "bloom" {
+			limit = int(2 * bloomFPRate * n)
+		}
+		if falsePositives > limit {
+			t.Errorf("%s: %d false positives in %d absent keys, want at most %d", k.name, falsePositives, n, limit)
+		}
+	}
+}
+
+// BenchmarkExistenceCheck compares a Bloom filter sized for 1% false positives with a
+// map[string]struct{} holding 1M and 10M 8-byte keys. Add inserts into a fresh set,
+// rebuilt every n adds; MayContain looks up keys that were added (hit) or not (miss).
+// mem-MB is what the set retains: SizeBytes for the filter, the MemStats delta across
+// filling it for the map.
+func BenchmarkExistenceCheck(b *testing.B) {
+	for _, n := range []int{1_000_000, 10_000_000} {
+		for _, k := range existenceKinds {
+			prefix := "n=" + strconv.Itoa(n) + "/" + k.name
+
+			b.Run(prefix+"/Add", func(b *testing.B) {
+				var s existenceSet
+				var buf [8]byte
+				b.ReportAllocs()
+				for i := range b.N {
+					if i%n == 0 {
+						s = k.make(b, n)
+					}
+					s.add(existenceKey(buf[:], i%n))
+				}
+			})
+
+			s, retained := filledSet(b, n, k.make)
+			mem := float64(retained)
+			if bs, ok := s.(bloomSet); ok {
+				mem = float64(bs.f.SizeBytes())
+			}
+			for _, lookup := range []struct {
+				name   string
+				offset int
+			}{{"hit", 0}, {"miss", n}} {
+				b.Run(prefix+"/MayContain/"+lookup.name, func(b *testing.B) {
+					var buf [8]byte
+					found := 0
+					b.ReportAllocs()
+					b.ResetTimer()
+					for i := range b.N {
+						if s.mayContain(existenceKey(buf[:], lookup.offset+i%n)) {
+							found++
+						}
+					}
+					sinkFound = found > 0
+					b.ReportMetric(mem/(1<<20), "mem-MB")
+					if lookup.offset > 0 {
+						b.ReportMetric(100*float64(found)/float64(b.N), "fp-%")
+					}
+				})
+			}
+			runtime.KeepAlive(s)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package benchmarks
+
+import "testing"
+
+// Every keep* sink below retains its argument in a package-level variable, so
+// escape analysis (go test -gcflags=-m) has to decide where the argument lives:
+//
+//   - A SmallObject passed by value is copied into the sink; nothing escapes.
+//   - A pointer to a local makes the local escape ("moved to heap"): 1 alloc.
+//   - Converting a SmallObject to any needs a heap copy for the interface's
+//     data word (runtime.convT, "escapes to heap"): 1 alloc per conversion.
+//   - Converting a pointer to any is free, because a pointer already fits in
+//     the data word.
+
+var (
+	sinkKeptValue   SmallObject
+	sinkKeptPointer *SmallObject
+	sinkKeptAny     any
+	sinkKeptAnys    []any
+)
+
+// keepValue retains a copy of o.
+//
+//go:noinline
+func keepValue(o SmallObject) {
+	sinkKeptValue = o
+}
+
+// keepPointer retains o itself, forcing the pointee to the heap.
+//
+//go:noinline
+func keepPointer(o *SmallObject) {
+	sinkKeptPointer = o
+}
+
+// keepAny retains v as an interface, forcing a boxed copy for non-pointer values.
+//
+//go:noinline
+func keepAny(v any) {
+	sinkKeptAny = v
+}
+
+// BenchmarkBoxingValue passes SmallObject by value: 0 allocs.
+func BenchmarkBoxingValue(b *testing.B) {
+	b.ReportAllocs()
+	for i := range b.N {
+		keepValue(SmallObject{ID: int64(i), Type: 1})
+	}
+}
+
+// BenchmarkBoxingPointer passes a pointer to a local: the local escapes, 1 alloc.
+func BenchmarkBoxingPointer(b *testing.B) {
+	b.ReportAllocs()
+	for i := range b.N {
+		o := SmallObject{ID: int64(i), Type: 1}
+		keepPointer(&o)
+	}
+}
+
+// BenchmarkBoxingAnyValue passes SmallObject through an any parameter: boxing, 1 alloc.
+func BenchmarkBoxingAnyValue(b *testing.B) {
+	b.ReportAllocs()
+	for i := range b.N {
+		keepAny(SmallObject{ID: int64(i), Type: 1})
+	}
+}
+
+// BenchmarkBoxingAnyPointer passes an existing pointer through an any parameter: 0 allocs.
+func BenchmarkBoxingAnyPointer(b *testing.B) {
+	b.ReportAllocs()
+	o := &SmallObject{ID: 1, Type: 1}
+	b.ResetTimer()
+	for i := range b.N {
+		o.ID = int64(i)
+		keepAny(o)
+	}
+}
+
+// BenchmarkBoxingAnySlice stores SmallObject values into a reused []any: 1 alloc per element.
+func BenchmarkBoxingAnySlice(b *testing.B) {
+	b.ReportAllocs()
+	anys := make([]any, 0, 1)
+	b.ResetTimer()
+	for i := range b.N {
+		anys = append(anys[:0], SmallObject{ID: int64(i), Type: 1})
+		sinkKeptAnys = anys
+	}
+}
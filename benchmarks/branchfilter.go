@@ -0,0 +1,43 @@
+package benchmarks
+
+import "math"
+
+// SumNotionalAbove returns the sum of Price*Qty over the orders priced above threshold,
+// skipping the rest with an ordinary if. How fast it runs depends on the data: the CPU
+// guesses the branch before the comparison resolves, so a run of prices on one side of
+// threshold costs almost nothing and a random mix mispredicts about half the time.
+func SumNotionalAbove(orders []Order, threshold float64) float64 {
+	var total float64
+	for i := range orders {
+		if orders[i].Price > threshold {
+			total += orders[i].Price * float64(orders[i].Qty)
+		}
+	}
+	return total
+}
+
+// SumNotionalAboveBranchless returns the same sum as SumNotionalAbove, bit for bit,
+// without a data-dependent branch. Every order's notional is computed, and the comparison
+// becomes an all-ones or all-zeros mask ANDed with its bits, so rejected orders add +0.
+// Masking the bits rather than multiplying by 0 or 1 keeps a rejected infinite notional
+// from turning into NaN, and adding +0 leaves every sum unchanged because the running
+// total starts at +0 and so is never -0.
+func SumNotionalAboveBranchless(orders []Order, threshold float64) float64 {
+	var total float64
+	for i := range orders {
+		v := orders[i].Price * float64(orders[i].Qty)
+		mask := -boolToUint64(orders[i].Price > threshold)
+		total += math.Float64frombits(math.Float64bits(v) & mask)
+	}
+	return total
+}
+
+// boolToUint64 returns 1 for true and 0 for false. The compiler turns it into a flag-to-
+// register instruction rather than a jump.
+func boolToUint64(b bool) uint64 {
+	var u uint64
+	if b {
+		u = 1
+	}
+	return u
+}
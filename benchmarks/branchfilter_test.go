@@ -0,0 +1,122 @@
+package benchmarks
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+const (
+	// filterOrders is the number of orders BenchmarkPriceFilter scans per op.
+	filterOrders = 1_000_000
+	// filterThreshold is the median of filterPrices' range, so half the orders pass.
+	filterThreshold = 500.0
+)
+
+// filterPrices returns n orders with prices uniform in [0, 1000) and small quantities, in
+// random order, and the same orders sorted by price.
+func filterPrices(n int) (random, sorted []Order) {
+	r := rand.New(rand.NewPCG(uint64(n), 7))
+	random = make([]Order, n)
+	for i := range random {
+		random[i] = Order{Price: 1000 * r.Float64(), Qty: r.Int64N(100) + 1}
+	}
+	sorted = slices.Clone(random)
+	slices.SortFunc(sorted, func(a, b Order) int { return cmp.Compare(a.Price, b.Price) })
+	return random, sorted
+}
+
+// filterBoundaryOrders are orders at the edges of float64 comparison and arithmetic:
+// prices equal to or one ulp either side of the thresholds tested, both zeros, both
+// infinities, and the extremes, with quantities that make notionals zero, negative,
+// infinite, or NaN once an infinite price meets a zero quantity.
+func filterBoundaryOrders() []Order {
+	prices := []float64{
+		filterThreshold, math.Nextafter(filterThreshold, math.Inf(1)), math.Nextafter(filterThreshold, math.Inf(-1)),
+		0, math.Copysign(0, -1), math.SmallestNonzeroFloat64, -math.SmallestNonzeroFloat64,
+		math.MaxFloat64, -math.MaxFloat64, math.Inf(1), math.Inf(-1),
+	}
+	qtys := []int64{0, 1, -1, math.MaxInt64, math.MinInt64}
+	var orders []Order
+	for _, p := range prices {
+		for _, q := range qtys {
+			orders = append(orders, Order{Price: p, Qty: q})
+		}
+	}
+	return orders
+}
+
+func TestSumNotionalAbove(t *testing.T) {
+	orders := []Order{{Price: 10, Qty: 2}, {Price: 5, Qty: 100}, {Price: 7.5, Qty: 4}, {Price: 5.5, Qty: 1}}
+	if got, want := SumNotionalAbove(orders, 5.5), 10.0*2+7.5*4; got != want {
+		t.Errorf("SumNotionalAbove = %v, want %v from the two orders priced above 5.5", got, want)
+	}
+}
+
+// TestSumNotionalAboveBranchless_MatchesBranching requires bit-identical sums, so a -0
+// where the branching version gives +0, or a different NaN, would fail.
+func TestSumNotionalAboveBranchless_MatchesBranching(t *testing.T) {
+	random, sorted := filterPrices(10_000)
+	boundary := filterBoundaryOrders()
+	inputs := map[string][]Order{
+		"empty":    nil,
+		"random":   random,
+		"sorted":   sorted,
+		"boundary": boundary,
+	}
+	// Each boundary order alone, so one order's NaN or infinity cannot hide another's
+	// mismatch in the sum.
+	for i, o := range boundary {
+		inputs[fmt.Sprintf("boundary[%d]=%v", i, o)] = []Order{o}
+	}
+
+	thresholds := []float64{
+		filterThreshold, 0, math.Copysign(0, -1), -1, math.MaxFloat64, -math.MaxFloat64,
+		math.Inf(1), math.Inf(-1),
+	}
+	for name, orders := range inputs {
+		for _, threshold := range thresholds {
+			want := SumNotionalAbove(orders, threshold)
+			got := SumNotionalAboveBranchless(orders, threshold)
+			if math.Float64bits(got) != math.Float64bits(want) {
+				t.Errorf("%s, threshold %v: branchless = %v (%#x), branching = %v (%#x)",
+					name, threshold, got, math.Float64bits(got), want, math.Float64bits(want))
+			}
+		}
+	}
+}
+
+// BenchmarkPriceFilter sums the notional of the orders priced above the median of 1M:
+// with an if over random prices, where the branch cannot be predicted; with the same if
+// over the orders sorted by price, where it is taken in one long run and then skipped in
+// another; and with the branchless mask, which does the same work whatever the order. The
+// data and the instructions are identical between random and sorted, so the gap between
+// them, about 4x, is mispredicted branches alone. The branchless version runs at the same
+// speed on either input, close to the sorted branch, because it computes and adds every
+// order's notional; it only wins where the branch is unpredictable. A CPU profile of the
+// random case blames the loop, not the data, which is what makes this easy to miss.
+func BenchmarkPriceFilter(b *testing.B) {
+	random, sorted := filterPrices(filterOrders)
+	cases := []struct {
+		name   string
+		orders []Order
+		sum    func([]Order, float64) float64
+	}{
+		{name: "random/branch", orders: random, sum: SumNotionalAbove},
+		{name: "sorted/branch", orders: sorted, sum: SumNotionalAbove},
+		{name: "random/branchless", orders: random, sum: SumNotionalAboveBranchless},
+		{name: "sorted/branchless", orders: sorted, sum: SumNotionalAboveBranchless},
+	}
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkTotal = tc.sum(tc.orders, filterThreshold)
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/filterOrders, "ns/order")
+		})
+	}
+}
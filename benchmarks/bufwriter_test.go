@@ -0,0 +1,164 @@
+package benchmarks
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// logLineCount is the number of order lines written per op.
+const logLineCount = 100_000
+
+// pooledWriterSize is the buffer size of the writers in bufWriterPool.
+const pooledWriterSize = 4 << 10
+
+// bufWriterPool holds *bufio.Writer values; Reset points one at a new destination without
+// reallocating its buffer.
+var bufWriterPool = sync.Pool{
+	New: func() any { return bufio.NewWriterSize(nil, pooledWriterSize) },
+}
+
+// getBufWriter returns a pooled writer that writes to w.
+func getBufWriter(w io.Writer) *bufio.Writer {
+	bw := bufWriterPool.Get().(*bufio.Writer)
+	bw.Reset(w)
+	return bw
+}
+
+// putBufWriter returns bw to the pool. It must already be flushed; the Reset drops the
+// reference to the old destination so the pool does not keep it alive.
+func putBufWriter(bw *bufio.Writer) {
+	bw.Reset(nil)
+	bufWriterPool.Put(bw)
+}
+
+// writeOrderLines writes each order as one "price=%.2f qty=%d\n" line, one Write per line.
+func writeOrderLines(w io.Writer, orders []Order) error {
+	var scratch [64]byte
+	for i := range orders {
+		line := append(AppendOrder(scratch[:0], &orders[i]), '\n')
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeOrderLinesBuffered writes through a bufio.Writer of the given size and flushes it.
+func writeOrderLinesBuffered(w io.Writer, orders []Order, size int) error {
+	bw := bufio.NewWriterSize(w, size)
+	if err := writeOrderLines(bw, orders); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeOrderLinesPooled writes through a writer taken from bufWriterPool.
+func writeOrderLinesPooled(w io.Writer, orders []Order) error {
+	bw := getBufWriter(w)
+	defer putBufWriter(bw)
+
+	if err := writeOrderLines(bw, orders); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+var logWriters = []struct {
+	name  string
+	write func(io.Writer, []Order) error
+}{
+	{name: "unbuffered", write: writeOrderLines},
+	{name: "bufio-512B", write: func(w io.Writer, o []Order) error { return writeOrderLinesBuffered(w, o, 512) }},
+	{name: "bufio-4KB", write: func(w io.Writer, o []Order) error { return writeOrderLinesBuffered(w, o, 4<<10) }},
+	{name: "bufio-64KB", write: func(w io.Writer, o []Order) error { return writeOrderLinesBuffered(w, o, 64<<10) }},
+	{name: "pooled-4KB", write: writeOrderLinesPooled},
+}
+
+// writeCounter forwards to io.Discard and counts Write calls, each of which would be a
+// syscall on a file or socket.
+type writeCounter struct {
+	writes int
+}
+
+func (w *writeCounter) Write(p []byte) (int, error) {
+	w.writes++
+	return io.Discard.Write(p)
+}
+
+func TestLogWriters_SameBytes(t *testing.T) {
+	orders := sampleOrders(10_000)
+
+	var want bytes.Buffer
+	if err := writeOrderLines(&want, orders); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, lw := range logWriters {
+		// Twice, so the pooled case also runs on a writer that came back from the pool.
+		for run := range 2 {
+			var got bytes.Buffer
+			if err := lw.write(&got, orders); err != nil {
+				t.Fatalf("%s: %v", lw.name, err)
+			}
+			if !bytes.Equal(got.Bytes(), want.Bytes()) {
+				t.Errorf("%s run %d: wrote %d bytes, want the %d unbuffered bytes", lw.name, run, got.Len(), want.Len())
+			}
+		}
+	}
+}
+
+func TestLogWriters_FlushesSmallOutput(t *testing.T) {
+	// A single line fits in every buffer, so only a final Flush gets it out.
+	orders := []Order{{Price: 1.5, Qty: 2}}
+	for _, lw := range logWriters {
+		var got bytes.Buffer
+		if err := lw.write(&got, orders); err != nil {
+			t.Fatalf("%s: %v", lw.name, err)
+		}
+		if got.String() != "price=1.50 qty=2\n" {
+			t.Errorf("%s: wrote %q", lw.name, got.String())
+		}
+	}
+}
+
+// BenchmarkLogWriter writes logLineCount order lines per op unbuffered, through
+// bufio.Writers of 512B, 4KB, and 64KB, and through a pooled 4KB writer, reporting the
+// Write calls that reach the destination. io.Discard makes each Write nearly free, so
+// unbuffered wins on ns/op here; against a file or socket every one of its writes/op is a
+// syscall.
+func BenchmarkLogWriter(b *testing.B) {
+	orders := sampleOrders(logLineCount)
+	for _, lw := range logWriters {
+		b.Run(lw.name, func(b *testing.B) {
+			var dst writeCounter
+			b.ReportAllocs()
+			for range b.N {
+				if err := lw.write(&dst, orders); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(dst.writes)/float64(b.N), "writes/op")
+		})
+	}
+}
+
+// BenchmarkLogWriterSizes sweeps the buffer size to show where larger buffers stop paying off.
+func BenchmarkLogWriterSizes(b *testing.B) {
+	orders := sampleOrders(logLineCount)
+	for _, size := range []int{64, 256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			var dst writeCounter
+			b.ReportAllocs()
+			for range b.N {
+				if err := writeOrderLinesBuffered(&dst, orders, size); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(dst.writes)/float64(b.N), "writes/op")
+		})
+	}
+}
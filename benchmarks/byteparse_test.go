@@ -0,0 +1,133 @@
+package benchmarks
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"testing"
+
+	"golang-backend/pkg/byteconv"
+)
+
+// byteFieldParser parses the three byte fields of an "id,price,qty" line.
+type byteFieldParser func(id, price, qty []byte) (orderLine, bool)
+
+// parseFieldsString converts each field with string(b) before calling strconv, the way
+// code reading from a bufio.Scanner or a network buffer usually does.
+func parseFieldsString(id, price, qty []byte) (orderLine, bool) {
+	i, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		return orderLine{}, false
+	}
+	p, err := strconv.ParseFloat(string(price), 64)
+	if err != nil {
+		return orderLine{}, false
+	}
+	q, err := strconv.ParseInt(string(qty), 10, 64)
+	if err != nil {
+		return orderLine{}, false
+	}
+	return orderLine{ID: i, Order: Order{Price: p, Qty: q}}, true
+}
+
+// parseFieldsByteconv parses the fields in place with pkg/byteconv.
+func parseFieldsByteconv(id, price, qty []byte) (orderLine, bool) {
+	i, err := byteconv.ParseInt64(id)
+	if err != nil {
+		return orderLine{}, false
+	}
+	p, err := byteconv.ParseFloat(price)
+	if err != nil {
+		return orderLine{}, false
+	}
+	q, err := byteconv.ParseInt64(qty)
+	if err != nil {
+		return orderLine{}, false
+	}
+	return orderLine{ID: i, Order: Order{Price: p, Qty: q}}, true
+}
+
+// parseOrderBytesWith splits data into lines and fields like parseOrdersBytes, but hands
+// the fields to parse as byte slices.
+func parseOrderBytesWith(data []byte, dst []orderLine, parse byteFieldParser) ([]orderLine, bool) {
+	for len(data) > 0 {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			data = nil
+		}
+		if len(line) == 0 {
+			continue
+		}
+
+		id, tail, ok1 := bytes.Cut(line, []byte{','})
+		price, qty, ok2 := bytes.Cut(tail, []byte{','})
+		if !ok1 || !ok2 || bytes.IndexByte(qty, ',') >= 0 {
+			return dst, false
+		}
+		ol, ok := parse(id, price, qty)
+		if !ok {
+			return dst, false
+		}
+		dst = append(dst, ol)
+	}
+	return dst, true
+}
+
+var byteFieldParsers = []struct {
+	name  string
+	parse byteFieldParser
+}{
+	{name: "strconv-string", parse: parseFieldsString},
+	{name: "byteconv", parse: parseFieldsByteconv},
+}
+
+func TestByteFieldParsers_RoundTrip(t *testing.T) {
+	data, want := orderLinesInput(1000)
+	for _, p := range byteFieldParsers {
+		got, ok := parseOrderBytesWith(data, nil, p.parse)
+		if !ok || !slices.Equal(got, want) {
+			t.Errorf("%s: round trip of %d lines failed (ok=%v, got %d lines)", p.name, len(want), ok, len(got))
+		}
+	}
+}
+
+func TestByteFieldParsers_Reject(t *testing.T) {
+	for _, in := range []string{"1,2.5,", "1,,3", "x,2.5,3", "1,2.5,3.5", "9223372036854775808,1,1"} {
+		for _, p := range byteFieldParsers {
+			if _, ok := parseOrderBytesWith([]byte(in), nil, p.parse); ok {
+				t.Errorf("%s(%q) ok = true, want false", p.name, in)
+			}
+		}
+	}
+}
+
+// byteParseLines is the number of order lines parsed per op.
+const byteParseLines = 1_000_000
+
+// BenchmarkParseNumberBytes parses 1M "id,price,qty" lines, converting each field with
+// string(b) for strconv or parsing it in place with byteconv. strconv does not let its
+// argument escape, so for fields this short the compiler backs string(b) with a stack
+// buffer and neither path reports allocations; the difference is the copy per field and
+// the hand-rolled integer loop.
+func BenchmarkParseNumberBytes(b *testing.B) {
+	data, _ := orderLinesInput(byteParseLines)
+	for _, p := range byteFieldParsers {
+		b.Run(p.name, func(b *testing.B) {
+			dst := make([]orderLine, 0, byteParseLines)
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				var ok bool
+				dst, ok = parseOrderBytesWith(data, dst[:0], p.parse)
+				if !ok {
+					b.Fatal("parse failed")
+				}
+			}
+			sinkLen = len(dst)
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*byteParseLines), "ns/line")
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package benchmarks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"testing"
+
+	"golang-backend/pkg/bytespool"
+)
+
+// scratchSize is the scratch buffer size used to serialize a BigObject summary.
+const scratchSize = 4 << 10
+
+// scratchDataWords is how many Data elements fit in the scratch buffer alongside the header.
+const scratchDataWords = 500
+
+// appendBigObject appends a compact binary summary of o to dst: ID, IsActive, Name, and a prefix of Data.
+func appendBigObject(dst []byte, o *BigObject) []byte {
+	dst = binary.LittleEndian.AppendUint64(dst, uint64(o.ID))
+	if o.IsActive {
+		dst = append(dst, 1)
+	} else {
+		dst = append(dst, 0)
+	}
+	dst = append(dst, o.Name...)
+	for _, v := range o.Data[:scratchDataWords] {
+		dst = binary.LittleEndian.AppendUint64(dst, uint64(v))
+	}
+	return dst
+}
+
+// naiveBytePool stores []byte values directly, so every Put boxes a slice header.
+var naiveBytePool = sync.Pool{
+	New: func() any {
+		return make([]byte, 0, scratchSize)
+	},
+}
+
+var bufferScratchPool = sync.Pool{
+	New: func() any {
+		return bytes.NewBuffer(make([]byte, 0, scratchSize))
+	},
+}
+
+func newScratchPool(tb testing.TB) *bytespool.Pool {
+	tb.Helper()
+
+	p, err := bytespool.New(scratchSize, 4*scratchSize)
+	if err != nil {
+		tb.Fatalf("bytespool.New: %v", err)
+	}
+	return p
+}
+
+func TestAppendBigObject_PooledMatchesFresh(t *testing.T) {
+	p := newScratchPool(t)
+	want := appendBigObject(nil, &jsonSampleBigObject)
+
+	for range 3 {
+		b := p.Get()
+		*b = appendBigObject(*b, &jsonSampleBigObject)
+		if !bytes.Equal(*b, want) {
+			t.Fatal("pooled serialization differs from fresh serialization")
+		}
+		p.Put(b)
+	}
+}
+
+// BenchmarkScratchFresh allocates a new 4KB scratch buffer per serialization.
+func BenchmarkScratchFresh(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		buf := make([]byte, 0, scratchSize)
+		sinkBytes = appendBigObject(buf, &jsonSampleBigObject)
+	}
+}
+
+// BenchmarkScratchNaiveSlicePool pools []byte directly: Put allocates a slice header every time.
+func BenchmarkScratchNaiveSlicePool(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		buf := naiveBytePool.Get().([]byte)
+		buf = appendBigObject(buf, &jsonSampleBigObject)
+		sinkLen = len(buf)
+		naiveBytePool.Put(buf[:0])
+	}
+}
+
+// BenchmarkScratchBufferPool pools *bytes.Buffer.
+func BenchmarkScratchBufferPool(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		buf := bufferScratchPool.Get().(*bytes.Buffer)
+		buf.Write(appendBigObject(buf.AvailableBuffer(), &jsonSampleBigObject))
+		sinkLen = buf.Len()
+		buf.Reset()
+		bufferScratchPool.Put(buf)
+	}
+}
+
+// BenchmarkScratchBytesPool pools *[]byte through bytespool: no allocation on Get or Put.
+func BenchmarkScratchBytesPool(b *testing.B) {
+	p := newScratchPool(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		buf := p.Get()
+		*buf = appendBigObject(*buf, &jsonSampleBigObject)
+		sinkLen = len(*buf)
+		p.Put(buf)
+	}
+}
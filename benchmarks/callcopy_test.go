@@ -0,0 +1,149 @@
+package benchmarks
+
+import (
+	"fmt"
+	"testing"
+)
+
+// The chains below pass a BigObject (8KB) or a SmallObject (16 bytes) down 1 to 5
+// non-inlined calls, by value or by pointer. Entering at level d makes d calls, so a
+// by-value chain copies the object d times. Nothing escapes: the cost is pure copying.
+
+//go:noinline
+func bigValue1(o BigObject) int64 { return o.ID + o.Data[len(o.Data)-1] }
+
+//go:noinline
+func bigValue2(o BigObject) int64 { return bigValue1(o) + 1 }
+
+//go:noinline
+func bigValue3(o BigObject) int64 { return bigValue2(o) + 1 }
+
+//go:noinline
+func bigValue4(o BigObject) int64 { return bigValue3(o) + 1 }
+
+//go:noinline
+func bigValue5(o BigObject) int64 { return bigValue4(o) + 1 }
+
+//go:noinline
+func bigPointer1(o *BigObject) int64 { return o.ID + o.Data[len(o.Data)-1] }
+
+//go:noinline
+func bigPointer2(o *BigObject) int64 { return bigPointer1(o) + 1 }
+
+//go:noinline
+func bigPointer3(o *BigObject) int64 { return bigPointer2(o) + 1 }
+
+//go:noinline
+func bigPointer4(o *BigObject) int64 { return bigPointer3(o) + 1 }
+
+//go:noinline
+func bigPointer5(o *BigObject) int64 { return bigPointer4(o) + 1 }
+
+//go:noinline
+func smallValue1(o SmallObject) int64 { return o.ID + int64(o.Type) }
+
+//go:noinline
+func smallValue2(o SmallObject) int64 { return smallValue1(o) + 1 }
+
+//go:noinline
+func smallValue3(o SmallObject) int64 { return smallValue2(o) + 1 }
+
+//go:noinline
+func smallValue4(o SmallObject) int64 { return smallValue3(o) + 1 }
+
+//go:noinline
+func smallValue5(o SmallObject) int64 { return smallValue4(o) + 1 }
+
+//go:noinline
+func smallPointer1(o *SmallObject) int64 { return o.ID + int64(o.Type) }
+
+//go:noinline
+func smallPointer2(o *SmallObject) int64 { return smallPointer1(o) + 1 }
+
+//go:noinline
+func smallPointer3(o *SmallObject) int64 { return smallPointer2(o) + 1 }
+
+//go:noinline
+func smallPointer4(o *SmallObject) int64 { return smallPointer3(o) + 1 }
+
+//go:noinline
+func smallPointer5(o *SmallObject) int64 { return smallPointer4(o) + 1 }
+
+// callChains enters each chain at depths 1, 3, and 5. The value entries dereference once
+// to make the first by-value call, so depth d still means d copies.
+var callChains = []struct {
+	mode  string
+	depth int
+	big   func(*BigObject) int64
+	small func(*SmallObject) int64
+}{
+	{mode: "value", depth: 1,
+		big:   func(o *BigObject) int64 { return bigValue1(*o) },
+		small: func(o *SmallObject) int64 { return smallValue1(*o) }},
+	{mode: "value", depth: 3,
+		big:   func(o *BigObject) int64 { return bigValue3(*o) },
+		small: func(o *SmallObject) int64 { return smallValue3(*o) }},
+	{mode: "value", depth: 5,
+		big:   func(o *BigObject) int64 { return bigValue5(*o) },
+		small: func(o *SmallObject) int64 { return smallValue5(*o) }},
+	{mode: "pointer", depth: 1,
+		big:   bigPointer1,
+		small: smallPointer1},
+	{mode: "pointer", depth: 3,
+		big:   bigPointer3,
+		small: smallPointer3},
+	{mode: "pointer", depth: 5,
+		big:   bigPointer5,
+		small: smallPointer5},
+}
+
+func TestCallChains_SameResult(t *testing.T) {
+	big := newBigObjects(1)[0]
+	big.ID = 7
+	big.Data[len(big.Data)-1] = 3
+	small := SmallObject{ID: 7, Type: 3}
+
+	for _, c := range callChains {
+		// The leaf returns ID plus one field, and every other level adds 1.
+		want := int64(10 + c.depth - 1)
+		if got := c.big(&big); got != want {
+			t.Errorf("big/%s/depth=%d = %d, want %d", c.mode, c.depth, got, want)
+		}
+		if got := c.small(&small); got != want {
+			t.Errorf("small/%s/depth=%d = %d, want %d", c.mode, c.depth, got, want)
+		}
+	}
+}
+
+func TestAllocs_CallChains(t *testing.T) {
+	big := new(BigObject)
+	small := &SmallObject{}
+	for _, c := range callChains {
+		assertAllocs(t, fmt.Sprintf("big/%s/depth=%d", c.mode, c.depth), 0, func() { sinkChecksum = c.big(big) })
+		assertAllocs(t, fmt.Sprintf("small/%s/depth=%d", c.mode, c.depth), 0, func() { sinkChecksum = c.small(small) })
+	}
+}
+
+// BenchmarkCallChain passes a BigObject and a SmallObject down 1, 3, and 5 calls by value
+// and by pointer. The 8KB by-value chain pays an 8KB copy per level; at 16 bytes the copy
+// fits in two registers, so the two chains cost about the same and value semantics are free.
+func BenchmarkCallChain(b *testing.B) {
+	big := newBigObjects(1)[0]
+	small := SmallObject{ID: 1, Type: 1}
+	for _, c := range callChains {
+		b.Run(fmt.Sprintf("big/%s/depth=%d", c.mode, c.depth), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkChecksum = c.big(&big)
+			}
+		})
+	}
+	for _, c := range callChains {
+		b.Run(fmt.Sprintf("small/%s/depth=%d", c.mode, c.depth), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkChecksum = c.small(&small)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package benchmarks
+
+import (
+	"fmt"
+	"testing"
+)
+
+// pipelineOrders is the number of orders pushed through the pipeline per op.
+const pipelineOrders = 100_000
+
+// pipelineBatch is the slice length used by the batched pipeline.
+const pipelineBatch = 64
+
+// pipelineBuffers are the channel capacities compared against the unbuffered channel (0).
+var pipelineBuffers = []int{0, 1, 64, 1024}
+
+// sendOrders pushes every order through a channel of the given capacity to a consumer
+// goroutine and returns once the consumer has seen them all.
+func sendOrders(orders []Order, capacity int, consume func(Order)) {
+	ch := make(chan Order, capacity)
+	done := make(chan struct{})
+
+	go func() {
+		for o := range ch {
+			consume(o)
+		}
+		close(done)
+	}()
+
+	for _, o := range orders {
+		ch <- o
+	}
+	close(ch)
+	<-done
+}
+
+// sendOrderBatches pushes orders in freshly allocated slices of up to batch elements, so the
+// consumer owns each slice it receives.
+func sendOrderBatches(orders []Order, batch int, consume func(Order)) {
+	ch := make(chan []Order)
+	done := make(chan struct{})
+
+	go func() {
+		for b := range ch {
+			for _, o := range b {
+				consume(o)
+			}
+		}
+		close(done)
+	}()
+
+	for start := 0; start < len(orders); start += batch {
+		end := min(start+batch, len(orders))
+		b := make([]Order, end-start)
+		copy(b, orders[start:end])
+		ch <- b
+	}
+	close(ch)
+	<-done
+}
+
+// pipelineSamples returns n orders whose Qty is their index, so the consumer can track arrivals.
+func pipelineSamples(n int) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{Price: float64(i), Qty: int64(i)}
+	}
+	return orders
+}
+
+// checkExactlyOnce runs send and fails unless every order arrives exactly once.
+func checkExactlyOnce(t *testing.T, send func(orders []Order, consume func(Order))) {
+	t.Helper()
+
+	const n = 10_007 // not a multiple of the batch size
+	seen := make([]int, n)
+	send(pipelineSamples(n), func(o Order) {
+		seen[o.Qty]++
+	})
+
+	for i, c := range seen {
+		if c != 1 {
+			t.Fatalf("order %d arrived %d times, want 1", i, c)
+		}
+	}
+}
+
+func TestSendOrders_ExactlyOnce(t *testing.T) {
+	for _, capacity := range pipelineBuffers {
+		t.Run(fmt.Sprintf("buf=%d", capacity), func(t *testing.T) {
+			checkExactlyOnce(t, func(orders []Order, consume func(Order)) {
+				sendOrders(orders, capacity, consume)
+			})
+		})
+	}
+}
+
+func TestSendOrderBatches_ExactlyOnce(t *testing.T) {
+	checkExactlyOnce(t, func(orders []Order, consume func(Order)) {
+		sendOrderBatches(orders, pipelineBatch, consume)
+	})
+}
+
+var sinkPipelineQty int64
+
+// BenchmarkOrderPipeline measures end-to-end throughput of pipelineOrders orders per op through
+// unbuffered and buffered channels, and through batches of pipelineBatch orders.
+func BenchmarkOrderPipeline(b *testing.B) {
+	orders := pipelineSamples(pipelineOrders)
+
+	for _, capacity := range pipelineBuffers {
+		b.Run(fmt.Sprintf("buf=%d", capacity), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				var total int64
+				sendOrders(orders, capacity, func(o Order) { total += o.Qty })
+				sinkPipelineQty = total
+			}
+		})
+	}
+
+	b.Run(fmt.Sprintf("batch=%d", pipelineBatch), func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			var total int64
+			sendOrderBatches(orders, pipelineBatch, func(o Order) { total += o.Qty })
+			sinkPipelineQty = total
+		}
+	})
+}
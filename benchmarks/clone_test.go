@@ -0,0 +1,149 @@
+package benchmarks
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// cloneSize is the number of orders in the cloned slice and map.
+const cloneSize = 10_000
+
+var sliceClones = []struct {
+	name  string
+	clone func(src []Order) []Order
+}{
+	{name: "slices.Clone", clone: slices.Clone[[]Order]},
+	{name: "append-nil", clone: func(src []Order) []Order { return append([]Order(nil), src...) }},
+	{name: "make-copy", clone: func(src []Order) []Order {
+		dst := make([]Order, len(src))
+		copy(dst, src)
+		return dst
+	}},
+	{name: "make-loop", clone: func(src []Order) []Order {
+		dst := make([]Order, len(src))
+		for i := range src {
+			dst[i] = src[i]
+		}
+		return dst
+	}},
+}
+
+// mapClones copy a map[int64]*Order. The shallow ones share the Orders with the source;
+// the deep ones copy every Order, one allocation each or all in a single slab.
+var mapClones = []struct {
+	name  string
+	deep  bool
+	clone func(src map[int64]*Order) map[int64]*Order
+}{
+	{name: "maps.Clone", clone: maps.Clone[map[int64]*Order]},
+	{name: "range-copy", clone: func(src map[int64]*Order) map[int64]*Order {
+		dst := make(map[int64]*Order, len(src))
+		for k, v := range src {
+			dst[k] = v
+		}
+		return dst
+	}},
+	{name: "deep/per-order", deep: true, clone: func(src map[int64]*Order) map[int64]*Order {
+		dst := make(map[int64]*Order, len(src))
+		for k, v := range src {
+			o := *v
+			dst[k] = &o
+		}
+		return dst
+	}},
+	{name: "deep/slab", deep: true, clone: func(src map[int64]*Order) map[int64]*Order {
+		dst := make(map[int64]*Order, len(src))
+		slab := make([]Order, 0, len(src))
+		for k, v := range src {
+			slab = append(slab, *v)
+			dst[k] = &slab[len(slab)-1]
+		}
+		return dst
+	}},
+}
+
+// cloneSourceMap returns a map of n orders keyed by index, each separately allocated.
+func cloneSourceMap(n int) map[int64]*Order {
+	m := make(map[int64]*Order, n)
+	for i, o := range sampleOrders(n) {
+		m[int64(i)] = &o
+	}
+	return m
+}
+
+func TestSliceClones_Independent(t *testing.T) {
+	for _, c := range sliceClones {
+		src := sampleOrders(100)
+		dst := c.clone(src)
+		if !slices.Equal(dst, src) {
+			t.Fatalf("%s: clone differs from source", c.name)
+		}
+
+		dst[0].Price = -1
+		if src[0].Price == -1 {
+			t.Errorf("%s: writing the clone changed the source", c.name)
+		}
+		src[1].Qty = -1
+		if dst[1].Qty == -1 {
+			t.Errorf("%s: writing the source changed the clone", c.name)
+		}
+	}
+}
+
+func TestMapClones(t *testing.T) {
+	for _, c := range mapClones {
+		src := cloneSourceMap(100)
+		dst := c.clone(src)
+		if !maps.EqualFunc(dst, src, func(a, b *Order) bool { return *a == *b }) {
+			t.Fatalf("%s: clone differs from source", c.name)
+		}
+
+		// The maps themselves are always independent.
+		delete(dst, 0)
+		src[1000] = &Order{}
+		if _, ok := src[0]; !ok {
+			t.Errorf("%s: deleting from the clone deleted from the source", c.name)
+		}
+		if _, ok := dst[1000]; ok {
+			t.Errorf("%s: adding to the source added to the clone", c.name)
+		}
+
+		// The Orders are shared by a shallow clone and copied by a deep one.
+		dst[1].Price = -1
+		src[2].Qty = -1
+		shared := src[1].Price == -1 && dst[2].Qty == -1
+		cloned := src[1].Price != -1 && dst[2].Qty != -1
+		if c.deep && !cloned {
+			t.Errorf("%s: writing one map's Orders changed the other's", c.name)
+		}
+		if !c.deep && !shared {
+			t.Errorf("%s: shallow clone does not share Orders with its source", c.name)
+		}
+	}
+}
+
+// BenchmarkClone copies a 10k-element []Order and map[int64]*Order with the standard
+// library helpers, append, and hand-written loops, and deep-copies the map's Orders.
+func BenchmarkClone(b *testing.B) {
+	orders := sampleOrders(cloneSize)
+	for _, c := range sliceClones {
+		b.Run(fmt.Sprintf("slice/%s", c.name), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkOrders = c.clone(orders)
+			}
+		})
+	}
+
+	m := cloneSourceMap(cloneSize)
+	for _, c := range mapClones {
+		b.Run(fmt.Sprintf("map/%s", c.name), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkOrderMap = c.clone(m)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package benchmarks
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang-backend/pkg/coalesce"
+)
+
+// stormGoroutines is the number of concurrent requests that miss the cache for one key.
+const stormGoroutines = 1000
+
+// backendLatency stands in for the round trip of the load a cache miss triggers.
+const backendLatency = 100 * time.Microsecond
+
+// loadBigObject simulates fetching the object from a backend, counting every execution.
+func loadBigObject(calls *atomic.Int64) (*BigObject, error) {
+	calls.Add(1)
+	time.Sleep(backendLatency)
+	return newLazyBigObject(), nil
+}
+
+// runMissStorm releases stormGoroutines goroutines at once, each calling fetch.
+func runMissStorm(b *testing.B, fetch func() (*BigObject, error)) {
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for range stormGoroutines {
+		wg.Go(func() {
+			<-start
+			o, err := fetch()
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			globalSinkParallel.Store(o)
+		})
+	}
+	close(start)
+	wg.Wait()
+}
+
+// BenchmarkCacheMissStorm measures one storm per op: 1000 goroutines miss the cache for the
+// same key at once, each hitting the backend directly or through a coalesce.Group.
+// fn-calls is the number of backend loads per storm.
+func BenchmarkCacheMissStorm(b *testing.B) {
+	b.Run("direct", func(b *testing.B) {
+		var calls atomic.Int64
+		b.ReportAllocs()
+		for range b.N {
+			runMissStorm(b, func() (*BigObject, error) { return loadBigObject(&calls) })
+		}
+		b.ReportMetric(float64(calls.Load())/float64(b.N), "fn-calls/op")
+	})
+
+	b.Run("coalesced", func(b *testing.B) {
+		var calls atomic.Int64
+		var g coalesce.Group[BigObject]
+		b.ReportAllocs()
+		for range b.N {
+			runMissStorm(b, func() (*BigObject, error) {
+				o, _, err := g.Do("order-42", func() (*BigObject, error) { return loadBigObject(&calls) })
+				return o, err
+			})
+		}
+		b.ReportMetric(float64(calls.Load())/float64(b.N), "fn-calls/op")
+	})
+}
@@ -0,0 +1,87 @@
+package benchmarks
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config is a hot-reloadable service configuration: read on every request, replaced rarely.
+type Config struct {
+	Version  int64
+	Endpoint string
+	Timeout  time.Duration
+	MaxConns int
+}
+
+// RWMutexConfig guards a Config held by value. Load copies it out under the read lock.
+type RWMutexConfig struct {
+	mu  sync.RWMutex
+	cfg Config
+}
+
+// NewRWMutexConfig returns an RWMutexConfig holding a copy of c.
+func NewRWMutexConfig(c *Config) *RWMutexConfig {
+	return &RWMutexConfig{cfg: *c}
+}
+
+// Load returns a copy of the current config.
+func (s *RWMutexConfig) Load() Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg
+}
+
+// Store replaces the current config with a copy of c.
+func (s *RWMutexConfig) Store(c *Config) {
+	s.mu.Lock()
+	s.cfg = *c
+	s.mu.Unlock()
+}
+
+// AtomicPointerConfig publishes immutable Config snapshots through an atomic.Pointer.
+// Callers must treat both the Config passed to Store and the one returned by Load as
+// read-only: a change is made by storing a new Config, never by editing the current one.
+type AtomicPointerConfig struct {
+	p atomic.Pointer[Config]
+}
+
+// NewAtomicPointerConfig returns an AtomicPointerConfig publishing c.
+func NewAtomicPointerConfig(c *Config) *AtomicPointerConfig {
+	s := &AtomicPointerConfig{}
+	s.p.Store(c)
+	return s
+}
+
+// Load returns the current snapshot.
+func (s *AtomicPointerConfig) Load() *Config {
+	return s.p.Load()
+}
+
+// Store publishes c as the current snapshot.
+func (s *AtomicPointerConfig) Store(c *Config) {
+	s.p.Store(c)
+}
+
+// AtomicValueConfig is AtomicPointerConfig built on atomic.Value, which stores an interface
+// and needs a type assertion on every Load. The same read-only rules apply.
+type AtomicValueConfig struct {
+	v atomic.Value
+}
+
+// NewAtomicValueConfig returns an AtomicValueConfig publishing c.
+func NewAtomicValueConfig(c *Config) *AtomicValueConfig {
+	s := &AtomicValueConfig{}
+	s.v.Store(c)
+	return s
+}
+
+// Load returns the current snapshot.
+func (s *AtomicValueConfig) Load() *Config {
+	return s.v.Load().(*Config)
+}
+
+// Store publishes c as the current snapshot. c must not be nil.
+func (s *AtomicValueConfig) Store(c *Config) {
+	s.v.Store(c)
+}
@@ -0,0 +1,152 @@
+package benchmarks
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// configAt builds a Config whose every field is derived from version, so a reader can tell
+// a consistent snapshot from one mixing two versions.
+func configAt(version int64) *Config {
+	return &Config{
+		Version:  version,
+		Endpoint: "https://orders.internal/v" + strconv.FormatInt(version, 10),
+		Timeout:  time.Duration(version) * time.Millisecond,
+		MaxConns: int(version),
+	}
+}
+
+func consistentConfig(c Config) bool {
+	return c.Endpoint == "https://orders.internal/v"+strconv.FormatInt(c.Version, 10) &&
+		c.Timeout == time.Duration(c.Version)*time.Millisecond &&
+		c.MaxConns == int(c.Version)
+}
+
+// configStores adapts each implementation to a common load/store pair. The atomic loads are
+// dereferenced so every variant hands the reader the same Config value.
+var configStores = []struct {
+	name string
+	new  func(c *Config) (load func() Config, store func(*Config))
+}{
+	{
+		name: "rwmutex",
+		new: func(c *Config) (func() Config, func(*Config)) {
+			s := NewRWMutexConfig(c)
+			return s.Load, s.Store
+		},
+	},
+	{
+		name: "atomicPointer",
+		new: func(c *Config) (func() Config, func(*Config)) {
+			s := NewAtomicPointerConfig(c)
+			return func() Config { return *s.Load() }, s.Store
+		},
+	},
+	{
+		name: "atomicValue",
+		new: func(c *Config) (func() Config, func(*Config)) {
+			s := NewAtomicValueConfig(c)
+			return func() Config { return *s.Load() }, s.Store
+		},
+	},
+}
+
+func TestConfigStores_NoTornReads(t *testing.T) {
+	const (
+		readers = 4
+		writes  = 10_000
+	)
+
+	for _, cs := range configStores {
+		t.Run(cs.name, func(t *testing.T) {
+			load, store := cs.new(configAt(0))
+
+			var (
+				done   atomic.Bool
+				failed atomic.Bool
+				wg     sync.WaitGroup
+			)
+			for range readers {
+				wg.Go(func() {
+					var last int64
+					for !done.Load() {
+						c := load()
+						if !consistentConfig(c) || c.Version < last {
+							t.Errorf("reader saw %+v after version %d", c, last)
+							failed.Store(true)
+							return
+						}
+						last = c.Version
+					}
+				})
+			}
+
+			for v := int64(1); v <= writes && !failed.Load(); v++ {
+				store(configAt(v))
+			}
+			done.Store(true)
+			wg.Wait()
+		})
+	}
+}
+
+func TestConfigStores_StoreVisible(t *testing.T) {
+	for _, cs := range configStores {
+		t.Run(cs.name, func(t *testing.T) {
+			load, store := cs.new(configAt(1))
+			if got := load(); got != *configAt(1) {
+				t.Fatalf("initial Load = %+v, want version 1", got)
+			}
+
+			store(configAt(2))
+			if got := load(); got != *configAt(2) {
+				t.Fatalf("Load after Store = %+v, want version 2", got)
+			}
+
+			stored := make(chan struct{})
+			go func() {
+				store(configAt(3))
+				close(stored)
+			}()
+			<-stored
+			if got := load(); got != *configAt(3) {
+				t.Errorf("Load after another goroutine's Store = %+v, want version 3", got)
+			}
+		})
+	}
+}
+
+// sinkConfigVersion collects the versions read by the parallel benchmarks.
+var sinkConfigVersion atomic.Int64
+
+// BenchmarkConfigReload has every goroutine read the config and, once every readsPerWrite
+// ops, store one of two prebuilt snapshots, so the writes themselves do not allocate.
+func BenchmarkConfigReload(b *testing.B) {
+	snapshots := [2]*Config{configAt(1), configAt(2)}
+
+	for _, readsPerWrite := range []int{1_000_000, 100} {
+		for _, cs := range configStores {
+			b.Run(fmt.Sprintf("%s/reads=%d", cs.name, readsPerWrite), func(b *testing.B) {
+				load, store := cs.new(snapshots[0])
+
+				runWithProcs(b, func(b *testing.B) {
+					b.RunParallel(func(pb *testing.PB) {
+						var sum int64
+						for n := 1; pb.Next(); n++ {
+							if n%readsPerWrite == 0 {
+								store(snapshots[n/readsPerWrite%2])
+								continue
+							}
+							sum += load().Version
+						}
+						sinkConfigVersion.Add(sum)
+					})
+				})
+			})
+		}
+	}
+}
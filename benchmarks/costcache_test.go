@@ -0,0 +1,222 @@
+package benchmarks
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"golang-backend/pkg/costcache"
+	"golang-backend/pkg/lru"
+)
+
+// unitCostOrderCache is a costcache.Cache charging 1 per entry, so a budget of lruCapacity
+// holds as many orders as the LRU.
+type unitCostOrderCache struct {
+	c *costcache.Cache[int64, Order]
+}
+
+func (u unitCostOrderCache) Get(key int64) (*Order, bool) { return u.c.Get(key) }
+func (u unitCostOrderCache) Put(key int64, o *Order)      { u.c.Set(key, o, 1) }
+
+// equalCountCaches are the LRU and the cost cache at the same entry count.
+var equalCountCaches = []struct {
+	name string
+	new  func(tb testing.TB) orderCache
+}{
+	{name: "lru", new: orderCacheImpls[0].new},
+	{name: "costcache", new: func(tb testing.TB) orderCache {
+		c, err := costcache.New[int64, Order](lruCapacity)
+		if err != nil {
+			tb.Fatalf("costcache.New: %v", err)
+		}
+		return unitCostOrderCache{c}
+	}},
+}
+
+const (
+	// blobCacheEntries is the LRU's capacity in the variable-size workload; the cost cache
+	// gets the same number of average-sized blobs as its byte budget.
+	blobCacheEntries = 1000
+	// blobKeys is the key space the workload reads from, four times what either cache holds.
+	blobKeys = 4 * blobCacheEntries
+	// blobMinSize is the smallest blob; the largest is 100 times that.
+	blobMinSize = 100
+)
+
+// cachedBlob is a cached payload, such as a serialized response, whose cost is its size.
+type cachedBlob struct {
+	data []byte
+}
+
+// blobSize spreads sizes over [blobMinSize, 100*blobMinSize] by key, uniformly, so the
+// average is about 50x the smallest.
+func blobSize(key int64) int {
+	return blobMinSize * (1 + int(uint64(key)*0x9e3779b97f4a7c15>>32)%100)
+}
+
+// blobAverageSize is the mean size of blobs over the key space.
+func blobAverageSize() int64 {
+	var total int64
+	for k := range int64(blobKeys) {
+		total += int64(blobSize(k))
+	}
+	return total / blobKeys
+}
+
+// blobs holds the payload for every key, built once so the benchmark measures the caches
+// and not payload allocation.
+var blobs = func() []*cachedBlob {
+	b := make([]*cachedBlob, blobKeys)
+	for k := range b {
+		b[k] = &cachedBlob{data: make([]byte, blobSize(int64(k)))}
+	}
+	return b
+}()
+
+// blobCache is a read-through cache of blobs that tracks the bytes it holds.
+type blobCache interface {
+	get(key int64) bool
+	put(key int64, b *cachedBlob)
+	// held returns the bytes of blobs currently cached.
+	held() int64
+}
+
+// lruBlobCache bounds blobs by count. Its byte total is whatever the entries add up to.
+type lruBlobCache struct {
+	c     *lru.Cache[int64, cachedBlob]
+	bytes int64
+}
+
+func newLRUBlobCache(tb testing.TB) blobCache {
+	bc := &lruBlobCache{}
+	c, err := lru.New(blobCacheEntries, lru.WithOnEvict(func(_ int64, b *cachedBlob) {
+		bc.bytes -= int64(len(b.data))
+	}))
+	if err != nil {
+		tb.Fatalf("lru.New: %v", err)
+	}
+	bc.c = c
+	return bc
+}
+
+func (bc *lruBlobCache) get(key int64) bool { _, ok := bc.c.Get(key); return ok }
+func (bc *lruBlobCache) put(key int64, b *cachedBlob) {
+	bc.c.Put(key, b)
+	bc.bytes += int64(len(b.data))
+}
+func (bc *lruBlobCache) held() int64 { return bc.bytes }
+
+// costBlobCache bounds blobs by their total size.
+type costBlobCache struct {
+	c *costcache.Cache[int64, cachedBlob]
+}
+
+func newCostBlobCache(tb testing.TB) blobCache {
+	c, err := costcache.New[int64, cachedBlob](blobCacheEntries * blobAverageSize())
+	if err != nil {
+		tb.Fatalf("costcache.New: %v", err)
+	}
+	return costBlobCache{c}
+}
+
+func (bc costBlobCache) get(key int64) bool { _, ok := bc.c.Get(key); return ok }
+func (bc costBlobCache) put(key int64, b *cachedBlob) {
+	bc.c.Set(key, b, int64(len(b.data)))
+}
+func (bc costBlobCache) held() int64 { return bc.c.Cost() }
+
+var blobCaches = []struct {
+	name string
+	new  func(tb testing.TB) blobCache
+}{
+	{name: "lru", new: newLRUBlobCache},
+	{name: "costcache", new: newCostBlobCache},
+}
+
+// readThroughBlob looks key up and, on a miss, stores its blob. It reports whether the
+// lookup hit.
+func readThroughBlob(c blobCache, key int64) bool {
+	if c.get(key) {
+		return true
+	}
+	c.put(key, blobs[key])
+	return false
+}
+
+func TestBlobCaches_StayWithinBounds(t *testing.T) {
+	budget := blobCacheEntries * blobAverageSize()
+	for _, bc := range blobCaches {
+		c := bc.new(t)
+		r := rand.New(rand.NewPCG(5, 5))
+		var peak int64
+		for range 50_000 {
+			readThroughBlob(c, r.Int64N(blobKeys))
+			peak = max(peak, c.held())
+		}
+		switch bc.name {
+		case "costcache":
+			if peak > budget {
+				t.Errorf("costcache held up to %d bytes, over its %d budget", peak, budget)
+			}
+		case "lru":
+			if lc := c.(*lruBlobCache); lc.c.Len() != blobCacheEntries {
+				t.Errorf("lru holds %d blobs, want %d", lc.c.Len(), blobCacheEntries)
+			}
+		}
+	}
+}
+
+func TestBlobSize_Spread(t *testing.T) {
+	lo, hi := blobSize(0), blobSize(0)
+	for k := range int64(blobKeys) {
+		lo, hi = min(lo, blobSize(k)), max(hi, blobSize(k))
+	}
+	if lo != blobMinSize || hi != 100*blobMinSize {
+		t.Errorf("blob sizes span [%d, %d], want [%d, %d]", lo, hi, blobMinSize, 100*blobMinSize)
+	}
+}
+
+// BenchmarkCostCache compares costcache with the plain LRU. equal-count runs the
+// BenchmarkOrderCache workloads with every entry costing 1, so the caches hold the same
+// orders and differ only in bookkeeping. variable-size reads through random keys whose
+// blobs range from 100B to 10KB, with the LRU holding 1000 of them and the cost cache a
+// budget of 1000 average blobs: hit-% is the share of lookups served, and peak-held-MB the
+// most blob data either cache held at once. The LRU's byte usage drifts with whichever
+// sizes it happens to hold; the cost cache's never exceeds its budget.
+func BenchmarkCostCache(b *testing.B) {
+	for _, wl := range cacheWorkloads {
+		for _, impl := range equalCountCaches {
+			b.Run("equal-count/"+wl.name+"/"+impl.name, func(b *testing.B) {
+				c := newFilledOrderCache(b, impl.new)
+				w := &cacheWorker{r: rand.New(rand.NewPCG(1, 1))}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					wl.op(c, w)
+				}
+				sinkCacheHits.Add(int64(w.hits))
+			})
+		}
+	}
+
+	for _, bc := range blobCaches {
+		b.Run("variable-size/"+bc.name, func(b *testing.B) {
+			c := bc.new(b)
+			r := rand.New(rand.NewPCG(3, 3))
+			for range 10 * blobKeys { // warm up to a steady state
+				readThroughBlob(c, r.Int64N(blobKeys))
+			}
+			var hits int
+			var peak int64
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				if readThroughBlob(c, r.Int64N(blobKeys)) {
+					hits++
+				}
+				peak = max(peak, c.held())
+			}
+			b.ReportMetric(100*float64(hits)/float64(b.N), "hit-%")
+			b.ReportMetric(float64(peak)/(1<<20), "peak-held-MB")
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"golang-backend/pkg/ctxkey"
+)
+
+// requestOrderKey carries the request's order through a context.
+var requestOrderKey = ctxkey.New[Order]("order")
+
+// ctxChainDepths are the numbers of WithValue layers in the benchmark chains.
+var ctxChainDepths = []int{1, 5, 20}
+
+// ctxFillerKeys stand in for the values other middleware layers add on top of the order.
+var ctxFillerKeys = func() []*ctxkey.Key[Order] {
+	keys := make([]*ctxkey.Key[Order], ctxChainDepths[len(ctxChainDepths)-1])
+	for i := range keys {
+		keys[i] = ctxkey.New[Order](fmt.Sprintf("filler-%d", i))
+	}
+	return keys
+}()
+
+// buildOrderContext stores o first, then depth-1 filler values above it, so looking up the
+// order walks the whole chain.
+func buildOrderContext(o *Order, depth int) context.Context {
+	ctx := requestOrderKey.Set(context.Background(), o)
+	for _, k := range ctxFillerKeys[:depth-1] {
+		ctx = k.Set(ctx, o)
+	}
+	return ctx
+}
+
+// requestScope is the explicit alternative: request-scoped values as plain fields.
+type requestScope struct {
+	Order   *Order
+	TraceID string
+	UserID  int64
+}
+
+//go:noinline
+func orderFromContext(ctx context.Context) *Order {
+	o, _ := requestOrderKey.Get(ctx)
+	return o
+}
+
+//go:noinline
+func orderFromScope(s *requestScope) *Order {
+	return s.Order
+}
+
+var sinkCtx context.Context
+
+func TestBuildOrderContext_Depth(t *testing.T) {
+	o := &Order{Qty: 1}
+	for _, depth := range ctxChainDepths {
+		ctx := buildOrderContext(o, depth)
+		if got := orderFromContext(ctx); got != o {
+			t.Errorf("depth %d: order = %p, want %p", depth, got, o)
+		}
+		for _, k := range ctxFillerKeys[:depth-1] {
+			if _, ok := k.Get(ctx); !ok {
+				t.Fatalf("depth %d: filler %v missing", depth, k)
+			}
+		}
+		if _, ok := ctxFillerKeys[depth-1].Get(ctx); ok {
+			t.Errorf("depth %d: chain is deeper than requested", depth)
+		}
+	}
+}
+
+// BenchmarkContextValue compares building a WithValue chain and looking up its deepest key
+// against passing a request-scoped struct. Each WithValue layer is one heap allocation.
+func BenchmarkContextValue(b *testing.B) {
+	o := &Order{Price: 10, Qty: 1}
+
+	for _, depth := range ctxChainDepths {
+		b.Run(fmt.Sprintf("build/depth=%d", depth), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkCtx = buildOrderContext(o, depth)
+			}
+		})
+
+		b.Run(fmt.Sprintf("lookup/depth=%d", depth), func(b *testing.B) {
+			ctx := buildOrderContext(o, depth)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				sinkOrder = orderFromContext(ctx)
+			}
+		})
+	}
+
+	b.Run("explicit/build", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := range b.N {
+			s := requestScope{Order: o, TraceID: "trace", UserID: int64(i)}
+			sinkOrder = orderFromScope(&s)
+		}
+	})
+
+	b.Run("explicit/lookup", func(b *testing.B) {
+		s := &requestScope{Order: o, TraceID: "trace", UserID: 1}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			sinkOrder = orderFromScope(s)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package benchmarks
+
+import "reflect"
+
+// DeepCopy returns a copy of v sharing no mutable memory with it, found by walking v with
+// reflection: pointers, slices, maps, arrays, structs, and interfaces are copied all the
+// way down, with nil values left nil. Pointers that alias in v alias the same copy in the
+// result, so cycles are safe. Strings, funcs, and channels are shared, and unexported
+// struct fields are copied shallowly, since reflection cannot set them.
+//
+// It is a general fallback; a hand-written Clone for a known type is much faster.
+func DeepCopy[T any](v T) T {
+	src := reflect.ValueOf(&v).Elem()
+	dst := reflect.New(src.Type()).Elem()
+	c := deepCopier{}
+	c.copy(dst, src)
+	return dst.Interface().(T)
+}
+
+// deepCopier remembers the copy made for each pointer. The key includes the type because
+// a pointer to a struct and a pointer to its first field share an address.
+type deepCopier struct {
+	seen map[pointerKey]reflect.Value
+}
+
+type pointerKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// copy deep-copies src into dst, which must be settable and of the same type. dst may
+// hold an earlier value, as the map case reuses its key and value holders.
+func (c *deepCopier) copy(dst, src reflect.Value) {
+	if !hasReferences(src.Type()) {
+		dst.Set(src)
+		return
+	}
+
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		key := pointerKey{src.Pointer(), src.Type()}
+		if p, ok := c.seen[key]; ok {
+			dst.Set(p)
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		if c.seen == nil {
+			c.seen = make(map[pointerKey]reflect.Value)
+		}
+		c.seen[key] = p
+		c.copy(p.Elem(), src.Elem())
+		dst.Set(p)
+
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		if !hasReferences(src.Type().Elem()) {
+			reflect.Copy(s, src)
+		} else {
+			for i := range src.Len() {
+				c.copy(s.Index(i), src.Index(i))
+			}
+		}
+		dst.Set(s)
+
+	case reflect.Map:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		k := reflect.New(src.Type().Key()).Elem()
+		v := reflect.New(src.Type().Elem()).Elem()
+		for it := src.MapRange(); it.Next(); {
+			c.copy(k, it.Key())
+			c.copy(v, it.Value())
+			m.SetMapIndex(k, v)
+		}
+		dst.Set(m)
+
+	case reflect.Array:
+		for i := range src.Len() {
+			c.copy(dst.Index(i), src.Index(i))
+		}
+
+	case reflect.Struct:
+		dst.Set(src) // copies unexported fields, shallowly
+		for i := range src.NumField() {
+			if f := dst.Field(i); f.CanSet() {
+				c.copy(f, src.Field(i))
+			}
+		}
+
+	case reflect.Interface:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		e := reflect.New(src.Elem().Type()).Elem()
+		c.copy(e, src.Elem())
+		dst.Set(e)
+
+	default: // funcs and channels
+		dst.Set(src)
+	}
+}
+
+// hasReferences reports whether values of t can reach memory a plain assignment would
+// share, so copying them takes more than dst.Set.
+func hasReferences(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return true
+	case reflect.Array:
+		return t.Len() > 0 && hasReferences(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if hasReferences(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package benchmarks
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// sampleOrderBatch builds the same fully populated batch on every call, so a fresh one
+// serves as the expected value after a copy has been mutated.
+func sampleOrderBatch(n int) *OrderBatch {
+	b := &OrderBatch{
+		ID:    7,
+		Owner: &Trader{Name: "ana", Desks: []string{"rates", "fx"}},
+		Tags:  map[string][]string{"region": {"emea"}, "flags": {"urgent", "manual"}},
+	}
+	for i, o := range sampleOrders(n) {
+		b.Orders = append(b.Orders, &o)
+		b.Tags["order-"+strconv.Itoa(i%4)] = []string{strconv.Itoa(i)}
+	}
+	return b
+}
+
+// sampleBigObject builds the same BigObject on every call.
+func sampleBigObject() *BigObject {
+	o := &BigObject{ID: 42, Name: "big", IsActive: true}
+	for i := range o.Data {
+		o.Data[i] = int64(i)
+	}
+	return o
+}
+
+// mutateAll changes every value reachable from v in place: numbers are incremented,
+// strings extended, bools flipped, and pointers, slices, arrays, maps, and struct fields
+// followed. It returns how many values it changed.
+func mutateAll(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int64:
+		v.SetInt(v.Int() + 1)
+	case reflect.Float64:
+		v.SetFloat(v.Float() + 1)
+	case reflect.String:
+		v.SetString(v.String() + "!")
+	case reflect.Bool:
+		v.SetBool(!v.Bool())
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return mutateAll(v.Elem())
+	case reflect.Slice, reflect.Array:
+		n := 0
+		for i := range v.Len() {
+			n += mutateAll(v.Index(i))
+		}
+		return n
+	case reflect.Struct:
+		n := 0
+		for i := range v.NumField() {
+			n += mutateAll(v.Field(i))
+		}
+		return n
+	case reflect.Map:
+		n := 0
+		for _, k := range v.MapKeys() {
+			// Map values are not addressable: change a copy and store it back. A slice
+			// copy still points at the original's backing array, as the map's value does.
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(v.MapIndex(k))
+			n += mutateAll(e)
+			v.SetMapIndex(k, e)
+		}
+		return n
+	default:
+		panic("mutateAll: unhandled kind " + v.Kind().String())
+	}
+	return 1
+}
+
+// checkIndependent copies a fresh value from build, mutates everything reachable from the
+// copy, and checks the original still equals a fresh value from build.
+func checkIndependent[T any](t *testing.T, name string, build func() T, clone func(T) T) {
+	t.Helper()
+	orig := build()
+	c := clone(orig)
+	if !reflect.DeepEqual(c, build()) {
+		t.Errorf("%s: copy differs from the original", name)
+		return
+	}
+	if n := mutateAll(reflect.ValueOf(&c).Elem()); n == 0 {
+		t.Fatalf("%s: nothing to mutate", name)
+	}
+	if !reflect.DeepEqual(orig, build()) {
+		t.Errorf("%s: mutating the copy changed the original", name)
+	}
+	if reflect.DeepEqual(c, build()) {
+		t.Errorf("%s: copy unchanged after mutation", name)
+	}
+}
+
+func TestClone_SharesNoMemory(t *testing.T) {
+	checkIndependent(t, "Order.Clone", func() *Order { o := sampleOrders(1)[0]; return &o }, (*Order).Clone)
+	checkIndependent(t, "BigObject.Clone", sampleBigObject, (*BigObject).Clone)
+	checkIndependent(t, "OrderBatch.Clone", func() *OrderBatch { return sampleOrderBatch(10) }, (*OrderBatch).Clone)
+}
+
+func TestDeepCopy_SharesNoMemory(t *testing.T) {
+	checkIndependent(t, "DeepCopy(*Order)", func() *Order { o := sampleOrders(1)[0]; return &o }, DeepCopy[*Order])
+	checkIndependent(t, "DeepCopy(*BigObject)", sampleBigObject, DeepCopy[*BigObject])
+	checkIndependent(t, "DeepCopy(*OrderBatch)", func() *OrderBatch { return sampleOrderBatch(10) }, DeepCopy[*OrderBatch])
+	checkIndependent(t, "DeepCopy(OrderBatch)", func() OrderBatch { return *sampleOrderBatch(10) }, DeepCopy[OrderBatch])
+	checkIndependent(t, "DeepCopy([]any)", func() []any {
+		o := sampleOrders(1)[0]
+		return []any{&o, []string{"a"}, map[string]int{"k": 1}, [2]*Order{&o, nil}}
+	}, DeepCopy[[]any])
+}
+
+func TestClone_NilFields(t *testing.T) {
+	sparse := func() *OrderBatch {
+		return &OrderBatch{ID: 1, Orders: []*Order{nil, {Price: 1, Qty: 1}, nil}}
+	}
+	for name, clone := range map[string]func(*OrderBatch) *OrderBatch{
+		"Clone":    (*OrderBatch).Clone,
+		"DeepCopy": DeepCopy[*OrderBatch],
+	} {
+		c := clone(sparse())
+		if c.Owner != nil || c.Tags != nil {
+			t.Errorf("%s: nil Owner and Tags became %v and %v", name, c.Owner, c.Tags)
+		}
+		if len(c.Orders) != 3 || c.Orders[0] != nil || c.Orders[2] != nil || *c.Orders[1] != (Order{Price: 1, Qty: 1}) {
+			t.Errorf("%s: Orders = %v, want nil, {1 1}, nil", name, c.Orders)
+		}
+		checkIndependent(t, name+" with nil fields", sparse, clone)
+
+		empty := clone(&OrderBatch{})
+		if !reflect.DeepEqual(empty, &OrderBatch{}) {
+			t.Errorf("%s of an empty batch = %+v", name, empty)
+		}
+	}
+	if DeepCopy[*OrderBatch](nil) != nil {
+		t.Error("DeepCopy of a nil pointer is not nil")
+	}
+	if got := DeepCopy(map[string][]string{"k": nil}); got["k"] != nil || len(got) != 1 {
+		t.Errorf("DeepCopy kept a nil map value as %v", got)
+	}
+}
+
+func TestDeepCopy_PreservesAliasingAndCycles(t *testing.T) {
+	shared := &Order{Price: 1, Qty: 2}
+	b := &OrderBatch{Orders: []*Order{shared, shared}}
+	c := DeepCopy(b)
+	if c.Orders[0] != c.Orders[1] || c.Orders[0] == shared {
+		t.Error("DeepCopy did not map two pointers to one Order onto one new Order")
+	}
+
+	type node struct {
+		Name string
+		Next *node
+	}
+	ring := &node{Name: "a"}
+	ring.Next = &node{Name: "b", Next: ring}
+	cp := DeepCopy(ring)
+	if cp == ring || cp.Next == ring.Next || cp.Next.Next != cp {
+		t.Error("DeepCopy of a two-node cycle did not produce a separate two-node cycle")
+	}
+}
+
+func TestDeepCopy_UnexportedFieldsShallow(t *testing.T) {
+	type wrapper struct {
+		Public  []int
+		private []int
+	}
+	w := wrapper{Public: []int{1}, private: []int{2}}
+	c := DeepCopy(w)
+	c.Public[0], c.private[0] = 10, 20
+	if w.Public[0] != 1 || w.private[0] != 20 {
+		t.Errorf("after mutating copy: Public %v, private %v; want exported copied, unexported shared", w.Public, w.private)
+	}
+}
+
+// deepCopyBatchOrders is the number of orders in the benchmarked OrderBatch.
+const deepCopyBatchOrders = 100
+
+var sinkBatch *OrderBatch
+
+// BenchmarkDeepCopy copies a BigObject and a 100-order OrderBatch with their hand-written
+// Clone methods and with reflection via DeepCopy. BigObject holds no references, so both
+// reduce to one 8KB copy and DeepCopy pays only for its type walk; the batch makes
+// DeepCopy visit every pointer, slice, and map entry through reflect.
+func BenchmarkDeepCopy(b *testing.B) {
+	big := sampleBigObject()
+	batch := sampleOrderBatch(deepCopyBatchOrders)
+
+	b.Run("BigObject/Clone", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			globalSinkPool = big.Clone()
+		}
+	})
+	b.Run("BigObject/DeepCopy", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			globalSinkPool = DeepCopy(big)
+		}
+	})
+	b.Run("OrderBatch/Clone", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkBatch = batch.Clone()
+		}
+	})
+	b.Run("OrderBatch/DeepCopy", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkBatch = DeepCopy(batch)
+		}
+	})
+}
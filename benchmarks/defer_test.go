@@ -0,0 +1,112 @@
+package benchmarks
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// deferLoopSizes are the locked updates performed per benchmark op.
+var deferLoopSizes = []int{1, 100, 10_000}
+
+// updateWithDefer applies one update under mu, unlocking via defer.
+//
+//go:noinline
+func updateWithDefer(mu *sync.Mutex, o *Order, price float64) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	o.Price = price
+	o.Qty++
+}
+
+// updateExplicitUnlock applies one update under mu, unlocking explicitly.
+//
+//go:noinline
+func updateExplicitUnlock(mu *sync.Mutex, o *Order, price float64) {
+	mu.Lock()
+	o.Price = price
+	o.Qty++
+	mu.Unlock()
+}
+
+// updateBatchWithDefer applies n updates under a single lock, with the defer hoisted out of the loop.
+//
+//go:noinline
+func updateBatchWithDefer(mu *sync.Mutex, o *Order, n int) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i := range n {
+		o.Price = float64(i)
+		o.Qty++
+	}
+}
+
+func TestDeferVariants_SameResult(t *testing.T) {
+	const n = 100
+
+	var mu sync.Mutex
+	var deferred, explicit, batched Order
+	for i := range n {
+		updateWithDefer(&mu, &deferred, float64(i))
+		updateExplicitUnlock(&mu, &explicit, float64(i))
+	}
+	updateBatchWithDefer(&mu, &batched, n)
+
+	want := Order{Price: n - 1, Qty: n}
+	for name, got := range map[string]Order{"defer": deferred, "explicit": explicit, "batched": batched} {
+		if got != want {
+			t.Errorf("%s: got %+v, want %+v", name, got, want)
+		}
+	}
+	if !mu.TryLock() {
+		t.Fatal("mutex left locked")
+	}
+}
+
+// BenchmarkDeferUnlock measures n calls that each lock and defer the unlock.
+func BenchmarkDeferUnlock(b *testing.B) {
+	for _, n := range deferLoopSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			var mu sync.Mutex
+			var o Order
+			b.ReportAllocs()
+			for range b.N {
+				for j := range n {
+					updateWithDefer(&mu, &o, float64(j))
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkExplicitUnlock measures n calls that each lock and unlock explicitly.
+func BenchmarkExplicitUnlock(b *testing.B) {
+	for _, n := range deferLoopSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			var mu sync.Mutex
+			var o Order
+			b.ReportAllocs()
+			for range b.N {
+				for j := range n {
+					updateExplicitUnlock(&mu, &o, float64(j))
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkHoistedDeferUnlock measures one lock and deferred unlock around n updates.
+func BenchmarkHoistedDeferUnlock(b *testing.B) {
+	for _, n := range deferLoopSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			var mu sync.Mutex
+			var o Order
+			b.ReportAllocs()
+			for range b.N {
+				updateBatchWithDefer(&mu, &o, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package benchmarks
+
+import "testing"
+
+// dispatchOps are the operation names routed by every dispatcher, in handler order.
+var dispatchOps = []string{
+	"create", "cancel", "amend", "fill", "expire",
+	"reject", "suspend", "resume", "settle", "audit",
+}
+
+// handleOrder is the shared handler body. Each route passes its own index, so tests can
+// tell which route ran while every route does identical work.
+//
+//go:noinline
+func handleOrder(o *Order, route int64) {
+	o.Qty = route
+}
+
+// dispatchSwitch routes op with a switch statement and reports whether op was known.
+func dispatchSwitch(op string, o *Order) bool {
+	switch op {
+	case "create":
+		handleOrder(o, 0)
+	case "cancel":
+		handleOrder(o, 1)
+	case "amend":
+		handleOrder(o, 2)
+	case "fill":
+		handleOrder(o, 3)
+	case "expire":
+		handleOrder(o, 4)
+	case "reject":
+		handleOrder(o, 5)
+	case "suspend":
+		handleOrder(o, 6)
+	case "resume":
+		handleOrder(o, 7)
+	case "settle":
+		handleOrder(o, 8)
+	case "audit":
+		handleOrder(o, 9)
+	default:
+		return false
+	}
+	return true
+}
+
+// dispatchMap routes by name through a map of handler funcs.
+var dispatchMap = map[string]func(*Order){
+	"create":  func(o *Order) { handleOrder(o, 0) },
+	"cancel":  func(o *Order) { handleOrder(o, 1) },
+	"amend":   func(o *Order) { handleOrder(o, 2) },
+	"fill":    func(o *Order) { handleOrder(o, 3) },
+	"expire":  func(o *Order) { handleOrder(o, 4) },
+	"reject":  func(o *Order) { handleOrder(o, 5) },
+	"suspend": func(o *Order) { handleOrder(o, 6) },
+	"resume":  func(o *Order) { handleOrder(o, 7) },
+	"settle":  func(o *Order) { handleOrder(o, 8) },
+	"audit":   func(o *Order) { handleOrder(o, 9) },
+}
+
+// orderHandler is the interface dispatcher: the name is resolved to a handler once, up front,
+// and only the method call is paid per operation.
+type orderHandler interface {
+	Handle(o *Order)
+}
+
+type (
+	createHandler  struct{}
+	cancelHandler  struct{}
+	amendHandler   struct{}
+	fillHandler    struct{}
+	expireHandler  struct{}
+	rejectHandler  struct{}
+	suspendHandler struct{}
+	resumeHandler  struct{}
+	settleHandler  struct{}
+	auditHandler   struct{}
+)
+
+func (createHandler) Handle(o *Order)  { handleOrder(o, 0) }
+func (cancelHandler) Handle(o *Order)  { handleOrder(o, 1) }
+func (amendHandler) Handle(o *Order)   { handleOrder(o, 2) }
+func (fillHandler) Handle(o *Order)    { handleOrder(o, 3) }
+func (expireHandler) Handle(o *Order)  { handleOrder(o, 4) }
+func (rejectHandler) Handle(o *Order)  { handleOrder(o, 5) }
+func (suspendHandler) Handle(o *Order) { handleOrder(o, 6) }
+func (resumeHandler) Handle(o *Order)  { handleOrder(o, 7) }
+func (settleHandler) Handle(o *Order)  { handleOrder(o, 8) }
+func (auditHandler) Handle(o *Order)   { handleOrder(o, 9) }
+
+// orderHandlers maps each name to its interface handler for the up-front selection.
+var orderHandlers = map[string]orderHandler{
+	"create":  createHandler{},
+	"cancel":  cancelHandler{},
+	"amend":   amendHandler{},
+	"fill":    fillHandler{},
+	"expire":  expireHandler{},
+	"reject":  rejectHandler{},
+	"suspend": suspendHandler{},
+	"resume":  resumeHandler{},
+	"settle":  settleHandler{},
+	"audit":   auditHandler{},
+}
+
+func TestDispatchers_SameHandler(t *testing.T) {
+	if len(dispatchMap) != len(dispatchOps) || len(orderHandlers) != len(dispatchOps) {
+		t.Fatalf("dispatchMap has %d routes, orderHandlers %d, want %d",
+			len(dispatchMap), len(orderHandlers), len(dispatchOps))
+	}
+
+	for want, op := range dispatchOps {
+		var viaSwitch, viaMap, viaIface Order
+		viaSwitch.Qty, viaMap.Qty, viaIface.Qty = -1, -1, -1
+
+		if !dispatchSwitch(op, &viaSwitch) {
+			t.Fatalf("dispatchSwitch(%q) reported unknown op", op)
+		}
+		dispatchMap[op](&viaMap)
+		orderHandlers[op].Handle(&viaIface)
+
+		if viaSwitch.Qty != int64(want) || viaMap.Qty != int64(want) || viaIface.Qty != int64(want) {
+			t.Errorf("%q: switch ran %d, map ran %d, interface ran %d, want %d",
+				op, viaSwitch.Qty, viaMap.Qty, viaIface.Qty, want)
+		}
+	}
+}
+
+func TestDispatchers_Miss(t *testing.T) {
+	o := Order{Qty: -1}
+	if dispatchSwitch("unknown", &o) {
+		t.Error("dispatchSwitch(unknown) reported a known op")
+	}
+	if _, ok := dispatchMap["unknown"]; ok {
+		t.Error("dispatchMap has a route for unknown")
+	}
+	if o.Qty != -1 {
+		t.Errorf("miss ran a handler: Qty = %d", o.Qty)
+	}
+}
+
+// BenchmarkDispatch compares routing an operation name via a switch, a map of funcs, and an
+// interface handler selected up front. The miss cases look up a name with no route.
+func BenchmarkDispatch(b *testing.B) {
+	o := &Order{}
+	handlers := make([]orderHandler, len(dispatchOps))
+	for i, op := range dispatchOps {
+		handlers[i] = orderHandlers[op]
+	}
+
+	b.Run("switch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := range b.N {
+			dispatchSwitch(dispatchOps[i%len(dispatchOps)], o)
+		}
+	})
+
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := range b.N {
+			if h, ok := dispatchMap[dispatchOps[i%len(dispatchOps)]]; ok {
+				h(o)
+			}
+		}
+	})
+
+	b.Run("interface", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := range b.N {
+			handlers[i%len(handlers)].Handle(o)
+		}
+	})
+
+	b.Run("switchMiss", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkFound = dispatchSwitch("unknown", o)
+		}
+	})
+
+	b.Run("mapMiss", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			_, sinkFound = dispatchMap["unknown"]
+		}
+	})
+
+	sink = o
+}
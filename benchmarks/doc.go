@@ -0,0 +1,4 @@
+// Package benchmarks holds the comparative benchmarks referenced from
+// references/PERFORMANCE_OPTIMIZATION.md, together with the small helpers
+// they exercise.
+package benchmarks
@@ -0,0 +1,17 @@
+package benchmarks
+
+import (
+	"encoding/base64"
+	"slices"
+)
+
+// AppendBase64 appends the standard, padded base64 encoding of src to dst and returns the
+// extended slice. It does not allocate when dst has EncodedLen(len(src)) bytes of spare
+// capacity, so a dst reused across calls settles at zero allocations.
+func AppendBase64(dst, src []byte) []byte {
+	enc := base64.StdEncoding
+	n := enc.EncodedLen(len(src))
+	dst = slices.Grow(dst, n)
+	enc.Encode(dst[len(dst):len(dst)+n], src)
+	return dst[:len(dst)+n]
+}
@@ -0,0 +1,147 @@
+package benchmarks
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+// encodingPayloadSize is the size of the raw input encoded per op.
+const encodingPayloadSize = 1 << 10
+
+// encodingPayload returns n bytes covering every byte value.
+func encodingPayload(n int) []byte {
+	src := make([]byte, n)
+	for i := range src {
+		src[i] = byte(i * 7)
+	}
+	return src
+}
+
+func TestAppendBase64_RoundTrip(t *testing.T) {
+	// 0 to 5 covers empty input and every remainder mod 3, with and without padding.
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 255, encodingPayloadSize} {
+		src := encodingPayload(n)
+		prefix := []byte("prefix:")
+
+		got := AppendBase64(bytes.Clone(prefix), src)
+		if !bytes.HasPrefix(got, prefix) {
+			t.Fatalf("n=%d: AppendBase64 clobbered dst prefix: %q", n, got)
+		}
+		encoded := got[len(prefix):]
+		if want := base64.StdEncoding.EncodeToString(src); string(encoded) != want {
+			t.Errorf("n=%d: AppendBase64 = %q, want %q", n, encoded, want)
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(string(encoded))
+		if err != nil {
+			t.Fatalf("n=%d: decode: %v", n, err)
+		}
+		if !bytes.Equal(decoded, src) {
+			t.Errorf("n=%d: round trip = %v, want %v", n, decoded, src)
+		}
+	}
+}
+
+func TestAppendBase64_NilDst(t *testing.T) {
+	if got := AppendBase64(nil, []byte("ab")); string(got) != "YWI=" {
+		t.Errorf("AppendBase64(nil, ab) = %q, want YWI=", got)
+	}
+	if got := AppendBase64(nil, nil); len(got) != 0 {
+		t.Errorf("AppendBase64(nil, nil) = %q, want empty", got)
+	}
+}
+
+func TestAllocs_Encoding(t *testing.T) {
+	skipIfRace(t)
+
+	src := encodingPayload(encodingPayloadSize)
+	b64 := make([]byte, 0, base64.StdEncoding.EncodedLen(len(src)))
+	assertAllocs(t, "AppendBase64 into reused dst", 0, func() {
+		sinkBytes = AppendBase64(b64[:0], src)
+	})
+
+	hexDst := make([]byte, hex.EncodedLen(len(src)))
+	assertAllocs(t, "hex.Encode into reused dst", 0, func() {
+		sinkLen = hex.Encode(hexDst, src)
+	})
+
+	encoded := []byte(base64.StdEncoding.EncodeToString(src))
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+	assertAllocs(t, "base64 Decode into reused dst", 0, func() {
+		sinkLen, sinkErr = base64.StdEncoding.Decode(decoded, encoded)
+	})
+}
+
+// BenchmarkEncode compares allocating EncodeToString with encoding into a destination
+// sized by EncodedLen and reused across iterations, for base64 and hex.
+func BenchmarkEncode(b *testing.B) {
+	src := encodingPayload(encodingPayloadSize)
+	b64Dst := make([]byte, base64.StdEncoding.EncodedLen(len(src)))
+	hexDst := make([]byte, hex.EncodedLen(len(src)))
+
+	cases := []struct {
+		name string
+		fn   func()
+	}{
+		{name: "base64/EncodeToString", fn: func() { sinkString = base64.StdEncoding.EncodeToString(src) }},
+		{name: "base64/Encode", fn: func() { base64.StdEncoding.Encode(b64Dst, src); sinkBytes = b64Dst }},
+		{name: "base64/AppendBase64", fn: func() { sinkBytes = AppendBase64(b64Dst[:0], src) }},
+		{name: "hex/EncodeToString", fn: func() { sinkString = hex.EncodeToString(src) }},
+		{name: "hex/Encode", fn: func() { sinkLen = hex.Encode(hexDst, src) }},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			b.SetBytes(int64(len(src)))
+			b.ReportAllocs()
+			for range b.N {
+				c.fn()
+			}
+		})
+	}
+}
+
+// BenchmarkDecode compares allocating DecodeString with decoding into a destination sized
+// by DecodedLen and reused across iterations. The encoded input is held as []byte for the
+// Decode cases and as a string for DecodeString, as each would arrive.
+func BenchmarkDecode(b *testing.B) {
+	src := encodingPayload(encodingPayloadSize)
+	b64Str := base64.StdEncoding.EncodeToString(src)
+	hexStr := hex.EncodeToString(src)
+	b64Bytes, hexBytes := []byte(b64Str), []byte(hexStr)
+	dst := make([]byte, max(base64.StdEncoding.DecodedLen(len(b64Bytes)), hex.DecodedLen(len(hexBytes))))
+
+	cases := []struct {
+		name string
+		fn   func() error
+	}{
+		{name: "base64/DecodeString", fn: func() (err error) {
+			sinkBytes, err = base64.StdEncoding.DecodeString(b64Str)
+			return err
+		}},
+		{name: "base64/Decode", fn: func() (err error) {
+			sinkLen, err = base64.StdEncoding.Decode(dst, b64Bytes)
+			return err
+		}},
+		{name: "hex/DecodeString", fn: func() (err error) {
+			sinkBytes, err = hex.DecodeString(hexStr)
+			return err
+		}},
+		{name: "hex/Decode", fn: func() (err error) {
+			sinkLen, err = hex.Decode(dst, hexBytes)
+			return err
+		}},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			b.SetBytes(int64(len(src)))
+			b.ReportAllocs()
+			for range b.N {
+				if err := c.fn(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package benchmarks
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// equalLoop compares a and b one byte at a time.
+func equalLoop(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalStrings is a string comparison behind a call the compiler cannot see through, so
+// arguments converted from []byte must really be allocated when they are too long for the
+// runtime's small on-stack buffer.
+//
+//go:noinline
+func equalStrings(a, b string) bool {
+	return a == b
+}
+
+// equalPair is one comparison input: a key and the candidate it is matched against, as
+// both bytes and strings.
+type equalPair struct {
+	a, b   []byte
+	as, bs string
+}
+
+// equalPairs returns inputs of size n that are equal, differ in the first byte, and differ
+// in the last byte. The comparison can stop at the first differing byte, so the last two
+// bracket its cost.
+func equalPairs(n int) []struct {
+	name string
+	pair equalPair
+} {
+	key := bytes.Repeat([]byte("k"), n)
+	first := bytes.Clone(key)
+	first[0] = 'x'
+	last := bytes.Clone(key)
+	last[n-1] = 'x'
+
+	mk := func(b []byte) equalPair {
+		// The candidate is a separate copy so no comparison can short-circuit on identity.
+		a := bytes.Clone(key)
+		return equalPair{a: a, b: b, as: string(a), bs: string(b)}
+	}
+	return []struct {
+		name string
+		pair equalPair
+	}{
+		{name: "equal", pair: mk(bytes.Clone(key))},
+		{name: "first-differs", pair: mk(first)},
+		{name: "last-differs", pair: mk(last)},
+	}
+}
+
+var equalMethods = []struct {
+	name  string
+	equal func(p *equalPair) bool
+}{
+	{name: "bytes.Equal", equal: func(p *equalPair) bool { return bytes.Equal(p.a, p.b) }},
+	{name: "string==", equal: func(p *equalPair) bool { return p.as == p.bs }},
+	{name: "loop", equal: func(p *equalPair) bool { return equalLoop(p.a, p.b) }},
+	// The compiler compares string(a) == string(b) in place without converting.
+	{name: "convert-inline", equal: func(p *equalPair) bool { return string(p.a) == string(p.b) }},
+	{name: "convert-call", equal: func(p *equalPair) bool { return equalStrings(string(p.a), p.bs) }},
+}
+
+// equalSizes are the payload sizes compared: a short ID, a hash-sized key, and a page.
+var equalSizes = []int{8, 64, 4096}
+
+func TestEqualMethods_Agree(t *testing.T) {
+	for _, n := range append([]int{1}, equalSizes...) {
+		for _, in := range equalPairs(n) {
+			want := in.name == "equal"
+			for _, m := range equalMethods {
+				if got := m.equal(&in.pair); got != want {
+					t.Errorf("n=%d/%s: %s = %v, want %v", n, in.name, m.name, got, want)
+				}
+			}
+		}
+	}
+	if equalLoop([]byte("ab"), []byte("abc")) {
+		t.Error("equalLoop reported different lengths equal")
+	}
+}
+
+// headerPairs are case-insensitive header-name matches.
+var headerPairs = []struct {
+	name string
+	a, b string
+	want bool
+}{
+	{name: "same-case", a: "Content-Type", b: "Content-Type", want: true},
+	{name: "mixed-case", a: "content-type", b: "Content-Type", want: true},
+	{name: "first-differs", a: "Xontent-Type", b: "Content-Type", want: false},
+	{name: "last-differs", a: "Content-Typx", b: "Content-Type", want: false},
+}
+
+func TestHeaderMatch(t *testing.T) {
+	for _, h := range headerPairs {
+		if got := strings.EqualFold(h.a, h.b); got != h.want {
+			t.Errorf("%s: EqualFold = %v, want %v", h.name, got, h.want)
+		}
+		if got := strings.ToLower(h.a) == strings.ToLower(h.b); got != h.want {
+			t.Errorf("%s: ToLower == %v, want %v", h.name, got, h.want)
+		}
+	}
+}
+
+// BenchmarkEqual compares 8-byte, 64-byte, and 4KB payloads with bytes.Equal, string ==,
+// a byte loop, and by converting []byte to string to use ==.
+func BenchmarkEqual(b *testing.B) {
+	for _, n := range equalSizes {
+		for _, in := range equalPairs(n) {
+			for _, m := range equalMethods {
+				b.Run(fmt.Sprintf("size=%d/%s/%s", n, in.name, m.name), func(b *testing.B) {
+					b.ReportAllocs()
+					b.SetBytes(int64(n))
+					for range b.N {
+						sinkFound = m.equal(&in.pair)
+					}
+				})
+			}
+		}
+	}
+}
+
+// BenchmarkHeaderMatch compares strings.EqualFold with lowercasing both sides and using ==.
+func BenchmarkHeaderMatch(b *testing.B) {
+	for _, h := range headerPairs {
+		b.Run(h.name+"/EqualFold", func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkFound = strings.EqualFold(h.a, h.b)
+			}
+		})
+		b.Run(h.name+"/ToLower", func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkFound = strings.ToLower(h.a) == strings.ToLower(h.b)
+			}
+		})
+	}
+}
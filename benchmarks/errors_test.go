@@ -0,0 +1,175 @@
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// errInvalidOrder is the pre-declared sentinel returned by validateSentinel.
+var errInvalidOrder = errors.New("invalid order")
+
+// OrderError carries the ID of the order that failed validation.
+type OrderError struct {
+	ID  int64
+	Qty int64
+}
+
+func (e *OrderError) Error() string {
+	return fmt.Sprintf("order %d: invalid qty %d", e.ID, e.Qty)
+}
+
+// Unwrap lets errors.Is(err, errInvalidOrder) match an *OrderError.
+func (e *OrderError) Unwrap() error {
+	return errInvalidOrder
+}
+
+// validateSentinel returns the shared sentinel: no allocation, but no context either.
+//
+//go:noinline
+func validateSentinel(_ int64, o *Order) error {
+	if o.Qty <= 0 {
+		return errInvalidOrder
+	}
+	return nil
+}
+
+// validateErrorsNew allocates a fresh error per failure.
+//
+//go:noinline
+func validateErrorsNew(_ int64, o *Order) error {
+	if o.Qty <= 0 {
+		return errors.New("invalid order")
+	}
+	return nil
+}
+
+// validateErrorf formats the order ID into the message.
+//
+//go:noinline
+func validateErrorf(id int64, o *Order) error {
+	if o.Qty <= 0 {
+		return fmt.Errorf("order %d invalid", id)
+	}
+	return nil
+}
+
+// validateWrapped formats the order ID and wraps the sentinel with %w.
+//
+//go:noinline
+func validateWrapped(id int64, o *Order) error {
+	if o.Qty <= 0 {
+		return fmt.Errorf("order %d: %w", id, errInvalidOrder)
+	}
+	return nil
+}
+
+// validateTyped returns an *OrderError, keeping the ID as a field instead of formatting it.
+//
+//go:noinline
+func validateTyped(id int64, o *Order) error {
+	if o.Qty <= 0 {
+		return &OrderError{ID: id, Qty: o.Qty}
+	}
+	return nil
+}
+
+var sinkErr error
+
+// invalidOrder fails every validator, so the benchmarks measure the error path.
+var invalidOrder = Order{Price: 10, Qty: 0}
+
+var validators = []struct {
+	name     string
+	validate func(id int64, o *Order) error
+}{
+	{name: "sentinel", validate: validateSentinel},
+	{name: "errors.New", validate: validateErrorsNew},
+	{name: "Errorf", validate: validateErrorf},
+	{name: "ErrorfWrap", validate: validateWrapped},
+	{name: "typed", validate: validateTyped},
+}
+
+func TestValidators(t *testing.T) {
+	valid := Order{Price: 10, Qty: 1}
+
+	for _, v := range validators {
+		t.Run(v.name, func(t *testing.T) {
+			if err := v.validate(7, &valid); err != nil {
+				t.Errorf("valid order: got %v, want nil", err)
+			}
+			if err := v.validate(7, &invalidOrder); err == nil {
+				t.Error("invalid order: got nil error")
+			}
+		})
+	}
+}
+
+func TestValidateWrapped_Is(t *testing.T) {
+	err := validateWrapped(7, &invalidOrder)
+	if !errors.Is(err, errInvalidOrder) {
+		t.Errorf("errors.Is(%v, errInvalidOrder) = false, want true", err)
+	}
+	if got, want := err.Error(), "order 7: invalid order"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestOrderError_As(t *testing.T) {
+	err := fmt.Errorf("checkout: %w", validateTyped(42, &Order{Price: 1, Qty: -3}))
+
+	var oe *OrderError
+	if !errors.As(err, &oe) {
+		t.Fatalf("errors.As(%v, *OrderError) = false, want true", err)
+	}
+	if oe.ID != 42 || oe.Qty != -3 {
+		t.Errorf("got {ID:%d Qty:%d}, want {ID:42 Qty:-3}", oe.ID, oe.Qty)
+	}
+	if !errors.Is(err, errInvalidOrder) {
+		t.Error("errors.Is(err, errInvalidOrder) = false, want true via Unwrap")
+	}
+}
+
+// BenchmarkErrorCreate measures the cost of producing each kind of validation error.
+func BenchmarkErrorCreate(b *testing.B) {
+	for _, v := range validators {
+		b.Run(v.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				sinkErr = v.validate(int64(i), &invalidOrder)
+			}
+		})
+	}
+}
+
+var sinkMatched bool
+
+// BenchmarkErrorCheck measures the caller side: == against a sentinel, errors.Is through a
+// wrap chain, and errors.As into the typed error.
+func BenchmarkErrorCheck(b *testing.B) {
+	sentinel := validateSentinel(1, &invalidOrder)
+	wrapped := validateWrapped(1, &invalidOrder)
+	typed := fmt.Errorf("checkout: %w", validateTyped(1, &invalidOrder))
+
+	b.Run("Equal", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkMatched = sentinel == errInvalidOrder
+		}
+	})
+
+	b.Run("Is", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkMatched = errors.Is(wrapped, errInvalidOrder)
+		}
+	})
+
+	b.Run("As", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			var oe *OrderError
+			sinkMatched = errors.As(typed, &oe)
+		}
+	})
+}
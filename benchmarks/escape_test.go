@@ -0,0 +1,77 @@
+package benchmarks
+
+import "testing"
+
+// A closure stored in a package-level variable outlives the call that created
+// it, so everything it captures must live on the heap. With
+// go test -gcflags=-m ./benchmarks the compiler reports
+// "func literal escapes to heap" at the closure inside captureInClosure.
+//
+// passExplicitly stores the same data in plain variables and refers to a
+// top-level function, which needs no closure context and reports nothing.
+
+var (
+	pendingCallback func() int64
+	pendingHandler  func(SmallObject) int64
+	pendingArg      SmallObject
+)
+
+// captureInClosure defers work on o by capturing it in a closure.
+//
+//go:noinline
+func captureInClosure(id int64) {
+	o := SmallObject{ID: id, Type: 1}
+	pendingCallback = func() int64 {
+		return o.ID * int64(o.Type)
+	}
+}
+
+// handleSmallObject is the top-level equivalent of the captureInClosure closure.
+func handleSmallObject(o SmallObject) int64 {
+	return o.ID * int64(o.Type)
+}
+
+// passExplicitly defers the same work by storing the argument and a plain function.
+//
+//go:noinline
+func passExplicitly(id int64) {
+	pendingArg = SmallObject{ID: id, Type: 1}
+	pendingHandler = handleSmallObject
+}
+
+func TestEscape_SameResult(t *testing.T) {
+	captureInClosure(7)
+	passExplicitly(7)
+
+	if got, want := pendingCallback(), pendingHandler(pendingArg); got != want {
+		t.Errorf("closure result = %d, explicit result = %d", got, want)
+	}
+}
+
+func TestAllocs_PassExplicitly(t *testing.T) {
+	assertAllocs(t, "passExplicitly", 0, func() {
+		passExplicitly(1)
+	})
+}
+
+func TestAllocs_CaptureInClosure(t *testing.T) {
+	if got := testing.AllocsPerRun(allocRuns, func() { captureInClosure(1) }); got == 0 {
+		t.Errorf("captureInClosure: got 0 allocs/op, want the captured closure to escape")
+	}
+}
+
+// BenchmarkEscapeClosureCapture measures deferring work through a capturing closure.
+func BenchmarkEscapeClosureCapture(b *testing.B) {
+	b.ReportAllocs()
+	for i := range b.N {
+		captureInClosure(int64(i))
+	}
+}
+
+// BenchmarkEscapeExplicitPass measures deferring the same work through explicit arguments.
+func BenchmarkEscapeExplicitPass(b *testing.B) {
+	b.ReportAllocs()
+	for i := range b.N {
+		passExplicitly(int64(i))
+	}
+}
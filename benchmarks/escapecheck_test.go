@@ -0,0 +1,85 @@
+package benchmarks
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"golang-backend/pkg/escape"
+)
+
+// compileDiagnostics compiles this package's tests with -gcflags=-m and returns the
+// escape-analysis diagnostics. It skips in -short mode because it runs the compiler.
+func compileDiagnostics(t *testing.T) []escape.Diagnostic {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("runs the compiler; skipped in -short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not on PATH")
+	}
+
+	// The test files are needed too, since ReturnPointer and friends live in them.
+	cmd := exec.Command(goTool, "test", "-c", "-o", os.DevNull, "-gcflags=-m", ".")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go test -c -gcflags=-m: %v\n%s", err, out)
+	}
+	diags, err := escape.Parse(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return diags
+}
+
+// funcLines returns the first and last line of the named top-level function in file.
+func funcLines(t *testing.T, file, name string) (int, int) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			return fset.Position(fn.Pos()).Line, fset.Position(fn.End()).Line
+		}
+	}
+	t.Fatalf("%s: no func %s", file, name)
+	return 0, 0
+}
+
+// heapIn returns the Heap diagnostics reported inside the named function.
+func heapIn(t *testing.T, diags []escape.Diagnostic, file, name string) []escape.Diagnostic {
+	t.Helper()
+
+	first, last := funcLines(t, file, name)
+	var heap []escape.Diagnostic
+	for _, d := range diags {
+		if filepath.Base(d.File) == file && d.Line >= first && d.Line <= last && d.Kind == escape.Heap {
+			heap = append(heap, d)
+		}
+	}
+	return heap
+}
+
+// TestEscapeDecisions checks that the compiler still makes the escape decisions the
+// allocation benchmarks are built around.
+func TestEscapeDecisions(t *testing.T) {
+	diags := compileDiagnostics(t)
+
+	if heap := heapIn(t, diags, "allocation_test.go", "ReturnPointer"); len(heap) == 0 {
+		t.Error("ReturnPointer: &SmallObject{...} no longer escapes to heap")
+	}
+	if heap := heapIn(t, diags, "allocation_test.go", "ReturnConcrete"); len(heap) != 0 {
+		t.Errorf("ReturnConcrete: unexpected heap allocation: %+v", heap)
+	}
+}
@@ -0,0 +1,239 @@
+package benchmarks
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+const (
+	// eventChunkSize is the number of Orders per chunk in chunkedEventBuf: 64KB each.
+	eventChunkSize = 4096
+	// bufferedEvents is how many events the benchmark appends per op.
+	bufferedEvents = 1_000_000
+)
+
+// eventBuffer collects Orders until they are flushed.
+type eventBuffer interface {
+	Append(o Order)
+	// All yields the events in the order they were appended.
+	All() iter.Seq[Order]
+	Len() int
+	// Reset empties the buffer, keeping its memory for reuse.
+	Reset()
+}
+
+// appendEventBuf is a plain slice grown by append, which copies everything collected so
+// far each time it runs out of capacity.
+type appendEventBuf struct {
+	events []Order
+}
+
+func (b *appendEventBuf) Append(o Order)       { b.events = append(b.events, o) }
+func (b *appendEventBuf) All() iter.Seq[Order] { return slices.Values(b.events) }
+func (b *appendEventBuf) Len() int             { return len(b.events) }
+func (b *appendEventBuf) Reset()               { b.events = b.events[:0] }
+
+// growEventBuf is a slice sized up front with slices.Grow for the expected number of
+// events. Past the prediction it falls back to append's doubling.
+type growEventBuf struct {
+	events []Order
+}
+
+func newGrowEventBuf(expected int) *growEventBuf {
+	return &growEventBuf{events: slices.Grow([]Order(nil), expected)}
+}
+
+func (b *growEventBuf) Append(o Order)       { b.events = append(b.events, o) }
+func (b *growEventBuf) All() iter.Seq[Order] { return slices.Values(b.events) }
+func (b *growEventBuf) Len() int             { return len(b.events) }
+func (b *growEventBuf) Reset()               { b.events = b.events[:0] }
+
+// eventChunk is one fixed-capacity link of a chunkedEventBuf.
+type eventChunk struct {
+	events []Order
+	next   *eventChunk
+}
+
+// chunkedEventBuf is a linked list of fixed-size chunks. Appending past the last chunk
+// links a new one, so events already collected are never copied. Reset rewinds to the
+// first chunk and refills the existing chain before allocating more.
+type chunkedEventBuf struct {
+	head, tail *eventChunk
+	n          int
+}
+
+func newChunkedEventBuf() *chunkedEventBuf {
+	c := &eventChunk{events: make([]Order, 0, eventChunkSize)}
+	return &chunkedEventBuf{head: c, tail: c}
+}
+
+func (b *chunkedEventBuf) Append(o Order) {
+	if len(b.tail.events) == cap(b.tail.events) {
+		if b.tail.next == nil {
+			b.tail.next = &eventChunk{events: make([]Order, 0, eventChunkSize)}
+		}
+		b.tail = b.tail.next
+		b.tail.events = b.tail.events[:0] // may hold events from before a Reset
+	}
+	b.tail.events = append(b.tail.events, o)
+	b.n++
+}
+
+// All stops at the tail: chunks after it are spares left over from before a Reset.
+func (b *chunkedEventBuf) All() iter.Seq[Order] {
+	return func(yield func(Order) bool) {
+		for c := b.head; c != nil; c = c.next {
+			for _, o := range c.events {
+				if !yield(o) {
+					return
+				}
+			}
+			if c == b.tail {
+				return
+			}
+		}
+	}
+}
+
+func (b *chunkedEventBuf) Len() int { return b.n }
+
+func (b *chunkedEventBuf) Reset() {
+	b.head.events = b.head.events[:0]
+	b.tail = b.head
+	b.n = 0
+}
+
+var eventBuffers = []struct {
+	name string
+	make func(expected int) eventBuffer
+}{
+	{name: "append", make: func(int) eventBuffer { return &appendEventBuf{} }},
+	{name: "grow", make: func(expected int) eventBuffer { return newGrowEventBuf(expected) }},
+	{name: "chunked", make: func(int) eventBuffer { return newChunkedEventBuf() }},
+}
+
+// fillEvents appends orders n to n+count to buf, with Qty recording the sequence number.
+func fillEvents(buf eventBuffer, n, count int) {
+	for i := n; i < n+count; i++ {
+		buf.Append(Order{Price: float64(i) / 4, Qty: int64(i)})
+	}
+}
+
+// checkEvents verifies buf holds exactly orders n to n+count, in order.
+func checkEvents(t *testing.T, name string, buf eventBuffer, n, count int) {
+	t.Helper()
+	if buf.Len() != count {
+		t.Errorf("%s: Len = %d, want %d", name, buf.Len(), count)
+	}
+	got := slices.Collect(buf.All())
+	if len(got) != count {
+		t.Fatalf("%s: iterated %d events, want %d", name, len(got), count)
+	}
+	for i, o := range got {
+		if want := n + i; o.Qty != int64(want) || o.Price != float64(want)/4 {
+			t.Fatalf("%s: event %d = %+v, want sequence number %d", name, i, o, want)
+		}
+	}
+}
+
+func TestEventBuffers_InsertionOrder(t *testing.T) {
+	sizes := []int{0, 1, eventChunkSize - 1, eventChunkSize, eventChunkSize + 1, 3*eventChunkSize + 17}
+	for _, eb := range eventBuffers {
+		for _, n := range sizes {
+			buf := eb.make(eventChunkSize)
+			fillEvents(buf, 0, n)
+			checkEvents(t, eb.name, buf, 0, n)
+		}
+	}
+}
+
+func TestEventBuffers_ResetAndRefill(t *testing.T) {
+	for _, eb := range eventBuffers {
+		buf := eb.make(eventChunkSize)
+		fillEvents(buf, 0, 2*eventChunkSize+5)
+
+		// Refill with fewer events than before, then with more than before.
+		for _, count := range []int{eventChunkSize + 3, 0, 4*eventChunkSize + 1} {
+			buf.Reset()
+			fillEvents(buf, 1000, count)
+			checkEvents(t, eb.name+" after Reset", buf, 1000, count)
+		}
+	}
+}
+
+func TestEventBuffers_EarlyBreak(t *testing.T) {
+	for _, eb := range eventBuffers {
+		buf := eb.make(0)
+		fillEvents(buf, 0, 2*eventChunkSize)
+		seen := 0
+		for range buf.All() {
+			seen++
+			if seen == eventChunkSize+1 {
+				break
+			}
+		}
+		if seen != eventChunkSize+1 {
+			t.Errorf("%s: saw %d events before breaking", eb.name, seen)
+		}
+	}
+}
+
+func TestChunkedEventBuf_ResetReusesChunks(t *testing.T) {
+	const n = 3*eventChunkSize + 1
+	buf := newChunkedEventBuf()
+	fillEvents(buf, 0, n)
+	allocs := testing.AllocsPerRun(10, func() {
+		buf.Reset()
+		fillEvents(buf, 0, n)
+	})
+	if allocs != 0 {
+		t.Errorf("Reset and refill to the previous size allocated %v times, want 0", allocs)
+	}
+	checkEvents(t, "chunked", buf, 0, n)
+}
+
+func TestGrowEventBuf_NoAllocsWithinPrediction(t *testing.T) {
+	buf := newGrowEventBuf(eventChunkSize)
+	if allocs := testing.AllocsPerRun(10, func() {
+		buf.Reset()
+		fillEvents(buf, 0, eventChunkSize)
+	}); allocs != 0 {
+		t.Errorf("filling to the predicted size allocated %v times, want 0", allocs)
+	}
+}
+
+// BenchmarkEventBuffer appends 1M Orders and then iterates them all, into a new buffer
+// each op and into one reused across ops with Reset. New buffers show the growth cost:
+// append reallocates and copies about 40 times on the way to 1M, allocating 88MB in all,
+// grow allocates its 16MB once, and chunked links a 64KB chunk per 4096 events without
+// copying any. Once reused, each design allocates only the iterator's closures.
+func BenchmarkEventBuffer(b *testing.B) {
+	for _, mode := range []string{"new", "reused"} {
+		for _, eb := range eventBuffers {
+			b.Run(mode+"/"+eb.name, func(b *testing.B) {
+				buf := eb.make(bufferedEvents)
+				if mode == "reused" {
+					fillEvents(buf, 0, bufferedEvents) // grow to size before timing
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					if mode == "new" {
+						buf = eb.make(bufferedEvents)
+					} else {
+						buf.Reset()
+					}
+					for i := range bufferedEvents {
+						buf.Append(Order{Price: float64(i), Qty: 1})
+					}
+					var total float64
+					for o := range buf.All() {
+						total += orderValue(o)
+					}
+					sinkTotal = total
+				}
+			})
+		}
+	}
+}
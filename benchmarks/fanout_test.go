@@ -0,0 +1,60 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"golang-backend/pkg/pipeline"
+)
+
+// fanOutOrders is the number of orders pushed through the pipeline per op.
+const fanOutOrders = 10_000
+
+// heavyValue stands in for a CPU-bound transform, such as pricing or validation, costing
+// a few microseconds per order.
+func heavyValue(o Order) float64 {
+	v := orderValue(o)
+	for i := range 2000 {
+		v = v*0.999 + float64(i&7)
+	}
+	return v
+}
+
+var fanOutTransforms = []struct {
+	name string
+	fn   func(Order) float64
+}{
+	{name: "light", fn: orderValue},
+	{name: "heavy", fn: heavyValue},
+}
+
+// BenchmarkFanOutPipeline pushes 10k orders from a generator through 1, 4, or 16 workers
+// to a collector, with a cheap and a CPU-heavy transform, in both collection modes.
+func BenchmarkFanOutPipeline(b *testing.B) {
+	orders := sampleOrders(fanOutOrders)
+	ctx := context.Background()
+
+	for _, tr := range fanOutTransforms {
+		for _, mode := range []struct {
+			name string
+			mode pipeline.Mode
+		}{{name: "unordered", mode: pipeline.Unordered}, {name: "ordered", mode: pipeline.Ordered}} {
+			for _, workers := range []int{1, 4, 16} {
+				b.Run(fmt.Sprintf("%s/%s/workers=%d", tr.name, mode.name, workers), func(b *testing.B) {
+					b.ReportAllocs()
+					for range b.N {
+						var total float64
+						err := pipeline.Run(ctx, slices.Values(orders), workers, tr.fn, mode.mode, func(v float64) { total += v })
+						if err != nil {
+							b.Fatal(err)
+						}
+						sinkTotal = total
+					}
+					b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*fanOutOrders), "ns/order")
+				})
+			}
+		}
+	}
+}
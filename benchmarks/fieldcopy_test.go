@@ -0,0 +1,249 @@
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// OrderRecord and SmallRecord are the mapper targets: they share field names and types with
+// Order and SmallObject, plus a field the source does not have and which must be left alone.
+type OrderRecord struct {
+	Price  float64
+	Qty    int64
+	Source string
+}
+
+type SmallRecord struct {
+	ID    int64
+	Type  int
+	Label string
+}
+
+func (r *OrderRecord) SetPrice(v float64) { r.Price = v }
+func (r *OrderRecord) SetQty(v int64)     { r.Qty = v }
+func (r *SmallRecord) SetID(v int64)      { r.ID = v }
+func (r *SmallRecord) SetType(v int)      { r.Type = v }
+
+var errUnsupportedCopy = errors.New("unsupported field copy")
+
+// copyFieldsReflect copies every exported field of src into the field of dst with the same
+// name and type. dst must be a pointer to a struct; src may be a struct or a pointer to one.
+func copyFieldsReflect(dst, src any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: dst is %T, want pointer to struct", errUnsupportedCopy, dst)
+	}
+	dv = dv.Elem()
+
+	sv := reflect.Indirect(reflect.ValueOf(src))
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: src is %T, want struct", errUnsupportedCopy, src)
+	}
+
+	st := sv.Type()
+	for i := range st.NumField() {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		df := dv.FieldByName(sf.Name)
+		if !df.IsValid() || !df.CanSet() || df.Type() != sf.Type {
+			continue
+		}
+		df.Set(sv.Field(i))
+	}
+	return nil
+}
+
+// copyFieldsSwitch is the hand-written mapper for the known source/destination pairs.
+func copyFieldsSwitch(dst, src any) error {
+	switch s := src.(type) {
+	case *Order:
+		if d, ok := dst.(*OrderRecord); ok {
+			d.Price = s.Price
+			d.Qty = s.Qty
+			return nil
+		}
+	case *SmallObject:
+		if d, ok := dst.(*SmallRecord); ok {
+			d.ID = s.ID
+			d.Type = s.Type
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %T to %T", errUnsupportedCopy, src, dst)
+}
+
+// orderSetter is satisfied by any destination that can receive an Order's fields.
+type orderSetter interface {
+	SetPrice(float64)
+	SetQty(int64)
+}
+
+// smallObjectSetter is satisfied by any destination that can receive a SmallObject's fields.
+type smallObjectSetter interface {
+	SetID(int64)
+	SetType(int)
+}
+
+// copyOrderGeneric copies an Order into any orderSetter without reflection.
+func copyOrderGeneric[D orderSetter](dst D, src *Order) {
+	dst.SetPrice(src.Price)
+	dst.SetQty(src.Qty)
+}
+
+// copySmallObjectGeneric copies a SmallObject into any smallObjectSetter.
+func copySmallObjectGeneric[D smallObjectSetter](dst D, src *SmallObject) {
+	dst.SetID(src.ID)
+	dst.SetType(src.Type)
+}
+
+// fieldCopyBatch is the number of structs copied per benchmark op.
+const fieldCopyBatch = 10_000
+
+func sampleSmallObjects(n int) []SmallObject {
+	objs := make([]SmallObject, n)
+	for i := range objs {
+		objs[i] = SmallObject{ID: int64(i), Type: i % 4}
+	}
+	return objs
+}
+
+func TestFieldCopy_OrderIdentical(t *testing.T) {
+	for _, o := range sampleOrders(100) {
+		var viaReflect, viaSwitch, viaGeneric OrderRecord
+		viaReflect.Source, viaSwitch.Source, viaGeneric.Source = "keep", "keep", "keep"
+
+		if err := copyFieldsReflect(&viaReflect, &o); err != nil {
+			t.Fatalf("copyFieldsReflect: %v", err)
+		}
+		if err := copyFieldsSwitch(&viaSwitch, &o); err != nil {
+			t.Fatalf("copyFieldsSwitch: %v", err)
+		}
+		copyOrderGeneric(&viaGeneric, &o)
+
+		want := OrderRecord{Price: o.Price, Qty: o.Qty, Source: "keep"}
+		if viaReflect != want || viaSwitch != want || viaGeneric != want {
+			t.Fatalf("reflect=%+v switch=%+v generic=%+v, want %+v", viaReflect, viaSwitch, viaGeneric, want)
+		}
+	}
+}
+
+func TestFieldCopy_SmallObjectIdentical(t *testing.T) {
+	for _, o := range sampleSmallObjects(100) {
+		var viaReflect, viaSwitch, viaGeneric SmallRecord
+
+		if err := copyFieldsReflect(&viaReflect, o); err != nil {
+			t.Fatalf("copyFieldsReflect: %v", err)
+		}
+		if err := copyFieldsSwitch(&viaSwitch, &o); err != nil {
+			t.Fatalf("copyFieldsSwitch: %v", err)
+		}
+		copySmallObjectGeneric(&viaGeneric, &o)
+
+		want := SmallRecord{ID: o.ID, Type: o.Type}
+		if viaReflect != want || viaSwitch != want || viaGeneric != want {
+			t.Fatalf("reflect=%+v switch=%+v generic=%+v, want %+v", viaReflect, viaSwitch, viaGeneric, want)
+		}
+	}
+}
+
+func TestFieldCopy_Unsupported(t *testing.T) {
+	var rec OrderRecord
+	o := Order{}
+
+	if err := copyFieldsReflect(rec, &o); !errors.Is(err, errUnsupportedCopy) {
+		t.Errorf("copyFieldsReflect(non-pointer dst) error = %v, want %v", err, errUnsupportedCopy)
+	}
+	if err := copyFieldsReflect(&rec, 42); !errors.Is(err, errUnsupportedCopy) {
+		t.Errorf("copyFieldsReflect(non-struct src) error = %v, want %v", err, errUnsupportedCopy)
+	}
+	if err := copyFieldsSwitch(&SmallRecord{}, &o); !errors.Is(err, errUnsupportedCopy) {
+		t.Errorf("copyFieldsSwitch(mismatched pair) error = %v, want %v", err, errUnsupportedCopy)
+	}
+}
+
+var (
+	sinkOrderRecords []OrderRecord
+	sinkSmallRecords []SmallRecord
+)
+
+// BenchmarkFieldCopy compares reflection, a type switch, and generics for mapping
+// fieldCopyBatch structs per op. Pointer type arguments share one GC-shape instantiation,
+// so the generic setters are called through a dictionary rather than inlined.
+func BenchmarkFieldCopy(b *testing.B) {
+	orders := sampleOrders(fieldCopyBatch)
+	orderDst := make([]OrderRecord, fieldCopyBatch)
+	smalls := sampleSmallObjects(fieldCopyBatch)
+	smallDst := make([]SmallRecord, fieldCopyBatch)
+
+	b.Run("Order/reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for i := range orders {
+				if err := copyFieldsReflect(&orderDst[i], &orders[i]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+		sinkOrderRecords = orderDst
+	})
+
+	b.Run("Order/switch", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for i := range orders {
+				if err := copyFieldsSwitch(&orderDst[i], &orders[i]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+		sinkOrderRecords = orderDst
+	})
+
+	b.Run("Order/generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for i := range orders {
+				copyOrderGeneric(&orderDst[i], &orders[i])
+			}
+		}
+		sinkOrderRecords = orderDst
+	})
+
+	b.Run("SmallObject/reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for i := range smalls {
+				if err := copyFieldsReflect(&smallDst[i], &smalls[i]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+		sinkSmallRecords = smallDst
+	})
+
+	b.Run("SmallObject/switch", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for i := range smalls {
+				if err := copyFieldsSwitch(&smallDst[i], &smalls[i]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+		sinkSmallRecords = smallDst
+	})
+
+	b.Run("SmallObject/generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for i := range smalls {
+				copySmallObjectGeneric(&smallDst[i], &smalls[i])
+			}
+		}
+		sinkSmallRecords = smallDst
+	})
+}
@@ -0,0 +1,76 @@
+package benchmarks
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"weak"
+
+	"golang-backend/pkg/pool"
+)
+
+// finalizedObjects counts BigObject finalizers and cleanups that have run.
+var finalizedObjects atomic.Int64
+
+func finalizeBigObject(*BigObject) { finalizedObjects.Add(1) }
+
+func cleanupBigObject(int64) { finalizedObjects.Add(1) }
+
+var sinkWeak weak.Pointer[BigObject]
+
+// leakPool is objPool with leak detection.
+var leakPool = pool.NewLeakDetecting(pool.WithReset((*BigObject).Reset))
+
+// BenchmarkBigObjectLifetime allocates a BigObject per op and drops the previous one, with
+// nothing attached, a finalizer, a runtime.AddCleanup cleanup, or a weak pointer taken. A
+// finalized object survives one extra GC cycle so its finalizer can run, which shows up in
+// the GC metrics.
+func BenchmarkBigObjectLifetime(b *testing.B) {
+	cases := []struct {
+		name   string
+		attach func(o *BigObject)
+	}{
+		{name: "plain", attach: func(*BigObject) {}},
+		{name: "finalizer", attach: func(o *BigObject) { runtime.SetFinalizer(o, finalizeBigObject) }},
+		{name: "cleanup", attach: func(o *BigObject) { runtime.AddCleanup(o, cleanupBigObject, o.ID) }},
+		{name: "weak", attach: func(o *BigObject) { sinkWeak = weak.Make(o) }},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			ReportGC(b)
+			for i := range b.N {
+				obj := &BigObject{ID: int64(i)}
+				c.attach(obj)
+				globalSinkPool = obj
+			}
+			globalSinkPool = nil
+		})
+	}
+}
+
+// BenchmarkLeakDetectingPool compares a Get/Put cycle on the plain pool with one that arms
+// and disarms a finalizer on each object.
+func BenchmarkLeakDetectingPool(b *testing.B) {
+	b.Run("pool", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := range b.N {
+			obj := objPool.Get()
+			obj.ID = int64(i)
+			globalSinkPool = obj
+			objPool.Put(obj)
+		}
+	})
+	b.Run("leak-detecting", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := range b.N {
+			obj := leakPool.Get()
+			obj.ID = int64(i)
+			globalSinkPool = obj
+			leakPool.Put(obj)
+		}
+		if n := leakPool.Leaks(); n != 0 {
+			b.Errorf("%d leaks reported with every object put back", n)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package benchmarks
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// orderLineSep separates orders within a formatted log line.
+const orderLineSep = ", "
+
+// orderLineEstimate is the expected size of one formatted order, used to pre-size buffers.
+const orderLineEstimate = 32
+
+// FormatOrdersConcat builds the log line with naive + concatenation.
+// Every + allocates a new intermediate string.
+func FormatOrdersConcat(orders []Order) string {
+	var s string
+	for i, o := range orders {
+		if i > 0 {
+			s += orderLineSep
+		}
+		s += "price=" + strconv.FormatFloat(o.Price, 'f', 2, 64) + " qty=" + strconv.FormatInt(o.Qty, 10)
+	}
+	return s
+}
+
+// FormatOrdersSprintf builds the log line with fmt.Sprintf per order and + concatenation,
+// the way it is usually first written. Each order costs a Sprintf string and a new
+// intermediate line.
+func FormatOrdersSprintf(orders []Order) string {
+	var s string
+	for i, o := range orders {
+		if i > 0 {
+			s += orderLineSep
+		}
+		s += fmt.Sprintf("price=%.2f qty=%d", o.Price, o.Qty)
+	}
+	return s
+}
+
+// FormatOrdersFprintf builds the log line with fmt.Fprintf into a pre-grown strings.Builder.
+// It differs from FormatOrdersBuilder only in using fmt for the number formatting.
+func FormatOrdersFprintf(orders []Order) string {
+	var sb strings.Builder
+	sb.Grow(len(orders) * orderLineEstimate)
+
+	for i, o := range orders {
+		if i > 0 {
+			sb.WriteString(orderLineSep)
+		}
+		fmt.Fprintf(&sb, "price=%.2f qty=%d", o.Price, o.Qty)
+	}
+	return sb.String()
+}
+
+// FormatOrdersBuilder builds the log line with a pre-grown strings.Builder.
+func FormatOrdersBuilder(orders []Order) string {
+	var sb strings.Builder
+	sb.Grow(len(orders) * orderLineEstimate)
+
+	var scratch [32]byte
+	for i, o := range orders {
+		if i > 0 {
+			sb.WriteString(orderLineSep)
+		}
+		sb.WriteString("price=")
+		sb.Write(strconv.AppendFloat(scratch[:0], o.Price, 'f', 2, 64))
+		sb.WriteString(" qty=")
+		sb.Write(strconv.AppendInt(scratch[:0], o.Qty, 10))
+	}
+	return sb.String()
+}
+
+// FormatOrdersBuffer builds the log line with a pre-grown bytes.Buffer.
+// The final String call copies the buffer, which costs one extra allocation over strings.Builder.
+func FormatOrdersBuffer(orders []Order) string {
+	var buf bytes.Buffer
+	buf.Grow(len(orders) * orderLineEstimate)
+
+	var scratch [32]byte
+	for i, o := range orders {
+		if i > 0 {
+			buf.WriteString(orderLineSep)
+		}
+		buf.WriteString("price=")
+		buf.Write(strconv.AppendFloat(scratch[:0], o.Price, 'f', 2, 64))
+		buf.WriteString(" qty=")
+		buf.Write(strconv.AppendInt(scratch[:0], o.Qty, 10))
+	}
+	return buf.String()
+}
+
+// AppendOrder appends o formatted as "price=%.2f qty=%d" to dst and returns the extended slice.
+// It does not allocate when dst has enough spare capacity.
+func AppendOrder(dst []byte, o *Order) []byte {
+	dst = append(dst, "price="...)
+	dst = strconv.AppendFloat(dst, o.Price, 'f', 2, 64)
+	dst = append(dst, " qty="...)
+	return strconv.AppendInt(dst, o.Qty, 10)
+}
@@ -0,0 +1,66 @@
+package benchmarks
+
+import (
+	"fmt"
+	"testing"
+)
+
+// formatCounts are the order counts per log line used by the string building benchmarks.
+var formatCounts = []int{1, 10, 100}
+
+var formatters = []struct {
+	name string
+	fn   func([]Order) string
+}{
+	{name: "concat", fn: FormatOrdersConcat},
+	{name: "sprintf", fn: FormatOrdersSprintf},
+	{name: "fprintf", fn: FormatOrdersFprintf},
+	{name: "builder", fn: FormatOrdersBuilder},
+	{name: "buffer", fn: FormatOrdersBuffer},
+}
+
+var sinkString string
+
+// sampleOrders returns n orders with fractional prices so float formatting is exercised.
+func sampleOrders(n int) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{
+			Price: float64(i)*1.25 + 0.5,
+			Qty:   int64(i * 10),
+		}
+	}
+	return orders
+}
+
+// TestFormatOrders ensures every implementation produces byte-identical output.
+func TestFormatOrders(t *testing.T) {
+	if got, want := FormatOrdersConcat(sampleOrders(2)), "price=0.50 qty=0, price=1.75 qty=10"; got != want {
+		t.Fatalf("FormatOrdersConcat = %q, want %q", got, want)
+	}
+
+	for _, n := range []int{0, 1, 10, 100} {
+		orders := sampleOrders(n)
+		want := FormatOrdersConcat(orders)
+		for _, f := range formatters {
+			if got := f.fn(orders); got != want {
+				t.Errorf("%s(n=%d) = %q, want %q", f.name, n, got, want)
+			}
+		}
+	}
+}
+
+// BenchmarkFormatOrders compares the string building strategies for lines of 1, 10, and 100 orders.
+func BenchmarkFormatOrders(b *testing.B) {
+	for _, n := range formatCounts {
+		orders := sampleOrders(n)
+		for _, f := range formatters {
+			b.Run(fmt.Sprintf("%s/n=%d", f.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					sinkString = f.fn(orders)
+				}
+			})
+		}
+	}
+}
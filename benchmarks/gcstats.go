@@ -0,0 +1,35 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"golang-backend/pkg/gcstats"
+)
+
+// metricReporter is the part of *testing.B that ReportGC uses, so tests can substitute a fake.
+type metricReporter interface {
+	Cleanup(f func())
+	ReportMetric(n float64, unit string)
+}
+
+// ReportGC reports the garbage collector's work over the rest of the benchmark as custom
+// metrics: pause-total-ns/op, num-GC/op, and heap-alloc-bytes/op. Call it after setup and
+// after any ResetTimer, right before the timed loop; the metrics are emitted when the
+// benchmark function returns.
+func ReportGC(b *testing.B) {
+	reportGC(b, func() int { return b.N })
+}
+
+// reportGC takes a gcstats snapshot now and again at cleanup, dividing the delta by n().
+func reportGC(r metricReporter, n func() int) {
+	before := gcstats.Read()
+
+	r.Cleanup(func() {
+		d := gcstats.Read().Sub(before)
+
+		ops := float64(max(n(), 1))
+		r.ReportMetric(float64(d.PauseTotal.Nanoseconds())/ops, "pause-total-ns/op")
+		r.ReportMetric(float64(d.NumGC)/ops, "num-GC/op")
+		r.ReportMetric(float64(d.TotalAlloc)/ops, "heap-alloc-bytes/op")
+	})
+}
@@ -0,0 +1,85 @@
+package benchmarks
+
+import (
+	"runtime"
+	"testing"
+)
+
+// fakeReporter records what reportGC emits instead of attaching it to a benchmark.
+type fakeReporter struct {
+	cleanups []func()
+	metrics  map[string]float64
+}
+
+func (f *fakeReporter) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+
+func (f *fakeReporter) ReportMetric(n float64, unit string) {
+	if f.metrics == nil {
+		f.metrics = make(map[string]float64)
+	}
+	f.metrics[unit] = n
+}
+
+func (f *fakeReporter) finish() {
+	for _, fn := range f.cleanups {
+		fn()
+	}
+}
+
+var gcMetricUnits = []string{"pause-total-ns/op", "num-GC/op", "heap-alloc-bytes/op"}
+
+func TestReportGC_EmitsMetrics(t *testing.T) {
+	const ops = 4
+
+	var f fakeReporter
+	reportGC(&f, func() int { return ops })
+	if len(f.metrics) != 0 {
+		t.Fatalf("metrics reported before cleanup: %v", f.metrics)
+	}
+
+	for range ops {
+		globalSinkPool = new(BigObject)
+		runtime.GC()
+	}
+	f.finish()
+
+	for _, unit := range gcMetricUnits {
+		if _, ok := f.metrics[unit]; !ok {
+			t.Errorf("metric %q not reported; got %v", unit, f.metrics)
+		}
+	}
+	if got := f.metrics["num-GC/op"]; got < 1 {
+		t.Errorf("num-GC/op = %v, want at least 1 after one forced GC per op", got)
+	}
+	if got, min := f.metrics["heap-alloc-bytes/op"], float64(len(BigObject{}.Data)*8); got < min {
+		t.Errorf("heap-alloc-bytes/op = %v, want at least %v", got, min)
+	}
+}
+
+func TestReportGC_ZeroGCs(t *testing.T) {
+	var f fakeReporter
+	reportGC(&f, func() int { return 1 })
+	f.finish()
+
+	for _, unit := range gcMetricUnits {
+		if v, ok := f.metrics[unit]; !ok || v < 0 {
+			t.Errorf("metric %q = %v, %v; want a non-negative value", unit, v, ok)
+		}
+	}
+}
+
+// gcPressureObjects is the number of BigObjects allocated per op, nearly 1GB, enough to
+// drive many GC cycles per op.
+const gcPressureObjects = 100_000
+
+// BenchmarkGCPressure allocates gcPressureObjects BigObjects per op, each garbage as soon as
+// the next is allocated, to show what the GC metrics look like when cycles actually run.
+func BenchmarkGCPressure(b *testing.B) {
+	b.ReportAllocs()
+	ReportGC(b)
+	for range b.N {
+		for j := range gcPressureObjects {
+			globalSinkPool = &BigObject{ID: int64(j)}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package benchmarks
+
+import (
+	"context"
+	"testing"
+)
+
+var sinkGoroutineResult int64
+
+// smallFrameWork does a little arithmetic with a small stack frame.
+//
+//go:noinline
+func smallFrameWork(id int64) int64 {
+	o := SmallObject{ID: id, Type: 1}
+	return o.ID * int64(o.Type)
+}
+
+// bigFrameWork touches a BigObject-sized local, which forces the goroutine stack to grow past its initial size.
+//
+//go:noinline
+func bigFrameWork(id int64) int64 {
+	var o BigObject
+	o.ID = id
+	o.Data[len(o.Data)-1] = id
+	return o.ChecksumByPointer()
+}
+
+// startWorker runs fn on a long-lived goroutine. Each value sent on the
+// returned request channel is processed and its result sent on the reply
+// channel. The goroutine exits when ctx is done.
+func startWorker(ctx context.Context, fn func(int64) int64) (chan<- int64, <-chan int64) {
+	requests := make(chan int64)
+	replies := make(chan int64)
+
+	go func() {
+		defer close(replies)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case id := <-requests:
+				select {
+				case replies <- fn(id):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return requests, replies
+}
+
+func TestStartWorker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	requests, replies := startWorker(ctx, smallFrameWork)
+	for id := range int64(10) {
+		requests <- id
+		if got, want := <-replies, smallFrameWork(id); got != want {
+			t.Fatalf("reply for %d = %d, want %d", id, got, want)
+		}
+	}
+
+	cancel()
+	if _, ok := <-replies; ok {
+		t.Fatal("worker kept replying after cancellation")
+	}
+}
+
+// benchmarkSpawnPerOp starts a goroutine per op and waits for it to finish.
+func benchmarkSpawnPerOp(b *testing.B, fn func(int64) int64) {
+	done := make(chan int64)
+	b.ReportAllocs()
+	for i := range b.N {
+		go func() {
+			done <- fn(int64(i))
+		}()
+		sinkGoroutineResult = <-done
+	}
+}
+
+// benchmarkPersistentWorker hands each op to a long-lived goroutine and waits for the reply.
+func benchmarkPersistentWorker(b *testing.B, fn func(int64) int64) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests, replies := startWorker(ctx, fn)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		requests <- int64(i)
+		sinkGoroutineResult = <-replies
+	}
+}
+
+// BenchmarkGoroutineSpawnSmall measures go f(); <-done with a small stack frame.
+func BenchmarkGoroutineSpawnSmall(b *testing.B) {
+	benchmarkSpawnPerOp(b, smallFrameWork)
+}
+
+// BenchmarkGoroutineSpawnBigFrame measures go f(); <-done where f needs an 8KB frame.
+func BenchmarkGoroutineSpawnBigFrame(b *testing.B) {
+	benchmarkSpawnPerOp(b, bigFrameWork)
+}
+
+// BenchmarkGoroutineWorkerSmall measures a round trip to a persistent worker with a small frame.
+func BenchmarkGoroutineWorkerSmall(b *testing.B) {
+	benchmarkPersistentWorker(b, smallFrameWork)
+}
+
+// BenchmarkGoroutineWorkerBigFrame measures a round trip to a persistent worker whose stack has already grown.
+func BenchmarkGoroutineWorkerBigFrame(b *testing.B) {
+	benchmarkPersistentWorker(b, bigFrameWork)
+}
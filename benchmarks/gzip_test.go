@@ -0,0 +1,164 @@
+package benchmarks
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"golang-backend/pkg/gzippool"
+	"golang-backend/pkg/pool"
+)
+
+// compressPayloadSize is the size of the response body compressed per op.
+const compressPayloadSize = 64 << 10
+
+// compressPayload returns a compressPayloadSize slice of a JSON array of orders.
+func compressPayload() []byte {
+	data, err := json.Marshal(sampleOrders(4000))
+	if err != nil {
+		panic(err)
+	}
+	return data[:compressPayloadSize]
+}
+
+var gzipWriters = func() *gzippool.Pool {
+	p, err := gzippool.New(gzip.DefaultCompression)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}()
+
+// newFlatePool pools flate writers at level, reset to io.Discard on Put like gzippool does.
+func newFlatePool(level int) *pool.Pool[flate.Writer] {
+	return pool.New(
+		pool.WithNew(func() *flate.Writer {
+			w, err := flate.NewWriter(io.Discard, level)
+			if err != nil {
+				panic(err)
+			}
+			return w
+		}),
+		pool.WithReset(func(w *flate.Writer) { w.Reset(io.Discard) }),
+	)
+}
+
+var (
+	flateWriters1 = newFlatePool(flate.BestSpeed)
+	flateWriters6 = newFlatePool(6)
+)
+
+// compressGzipFresh allocates a gzip.Writer per call.
+func compressGzipFresh(dst io.Writer, data []byte) error {
+	w := gzip.NewWriter(dst)
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// compressGzipPooled takes a writer from gzipWriters.
+func compressGzipPooled(dst io.Writer, data []byte) error {
+	w := gzipWriters.Get(dst)
+	defer gzipWriters.Put(w)
+
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// compressFlatePooled returns a compressor writing raw DEFLATE through writers from p.
+func compressFlatePooled(p *pool.Pool[flate.Writer]) func(io.Writer, []byte) error {
+	return func(dst io.Writer, data []byte) error {
+		w := p.Get()
+		defer p.Put(w)
+
+		w.Reset(dst)
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		return w.Close()
+	}
+}
+
+var compressors = []struct {
+	name       string
+	compress   func(io.Writer, []byte) error
+	decompress func(io.Reader) (io.Reader, error)
+}{
+	{name: "gzip-fresh", compress: compressGzipFresh, decompress: gzipReader},
+	{name: "gzip-pooled", compress: compressGzipPooled, decompress: gzipReader},
+	{name: "flate-1-pooled", compress: compressFlatePooled(flateWriters1), decompress: flateReader},
+	{name: "flate-6-pooled", compress: compressFlatePooled(flateWriters6), decompress: flateReader},
+}
+
+func gzipReader(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }
+
+func flateReader(r io.Reader) (io.Reader, error) { return flate.NewReader(r), nil }
+
+func TestCompressors_RoundTrip(t *testing.T) {
+	payload := compressPayload()
+	for _, c := range compressors {
+		// Twice, so the pooled cases also run on a writer that came back from the pool.
+		for run := range 2 {
+			var buf bytes.Buffer
+			if err := c.compress(&buf, payload); err != nil {
+				t.Fatalf("%s: %v", c.name, err)
+			}
+			r, err := c.decompress(&buf)
+			if err != nil {
+				t.Fatalf("%s: %v", c.name, err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("%s run %d: %v", c.name, run, err)
+			}
+			if !bytes.Equal(got, payload) {
+				t.Errorf("%s run %d: round trip returned %d bytes, want %d", c.name, run, len(got), len(payload))
+			}
+		}
+	}
+}
+
+func TestCompressGzipPooled_MatchesFresh(t *testing.T) {
+	payload := compressPayload()
+
+	var fresh bytes.Buffer
+	if err := compressGzipFresh(&fresh, payload); err != nil {
+		t.Fatal(err)
+	}
+	for run := range 3 {
+		var pooled bytes.Buffer
+		if err := compressGzipPooled(&pooled, payload); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pooled.Bytes(), fresh.Bytes()) {
+			t.Errorf("run %d: pooled gzip output differs from a fresh writer's", run)
+		}
+	}
+}
+
+// BenchmarkCompress compresses a 64KB JSON payload with a fresh gzip.Writer per op, a
+// gzippool writer, and pooled flate writers at levels 1 and 6, reporting the ratio of
+// compressed to original size.
+func BenchmarkCompress(b *testing.B) {
+	payload := compressPayload()
+	for _, c := range compressors {
+		b.Run(c.name, func(b *testing.B) {
+			var buf bytes.Buffer
+			b.SetBytes(int64(len(payload)))
+			b.ReportAllocs()
+			for range b.N {
+				buf.Reset()
+				if err := c.compress(&buf, payload); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(buf.Len())/float64(len(payload)), "ratio")
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package benchmarks
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"golang-backend/pkg/hashring"
+)
+
+// ringNodes returns n node names.
+func ringNodes(n int) []string {
+	nodes := make([]string, n)
+	for i := range nodes {
+		nodes[i] = "node-" + strconv.Itoa(i)
+	}
+	return nodes
+}
+
+// nodeRouter routes a key to one of a list of nodes, the way a client picks a backend.
+type nodeRouter interface {
+	route(key string) string
+}
+
+// ringRouter routes through a consistent-hash ring.
+type ringRouter struct{ r *hashring.Ring }
+
+func (rr ringRouter) route(key string) string {
+	node, _ := rr.r.Get(key)
+	return node
+}
+
+// moduloRouter picks nodes[shard(key, len(nodes))].
+type moduloRouter struct {
+	nodes []string
+	shard func(key string, n int) int
+}
+
+func (m moduloRouter) route(key string) string {
+	return m.nodes[m.shard(key, len(m.nodes))]
+}
+
+// routerKinds build each router over a node list.
+var routerKinds = []struct {
+	name string
+	make func(tb testing.TB, nodes []string) nodeRouter
+}{
+	{name: "hashring", make: func(tb testing.TB, nodes []string) nodeRouter {
+		r, err := hashring.New(hashring.DefaultVirtualNodes)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		for _, n := range nodes {
+			r.Add(n)
+		}
+		return ringRouter{r}
+	}},
+	{name: "modulo-fnv", make: func(_ testing.TB, nodes []string) nodeRouter {
+		return moduloRouter{nodes: nodes, shard: shardFNV}
+	}},
+	{name: "modulo-maphash", make: func(_ testing.TB, nodes []string) nodeRouter {
+		return moduloRouter{nodes: nodes, shard: ShardFor}
+	}},
+}
+
+// keysMovedOnRemove returns the fraction of keys routed to a different node after one of
+// n nodes is removed.
+func keysMovedOnRemove(tb testing.TB, keys []string, n int, makeRouter func(testing.TB, []string) nodeRouter) float64 {
+	nodes := ringNodes(n)
+	before, after := makeRouter(tb, nodes), makeRouter(tb, slices.Delete(slices.Clone(nodes), 3, 4))
+	moved := 0
+	for _, k := range keys {
+		if before.route(k) != after.route(k) {
+			moved++
+		}
+	}
+	return float64(moved) / float64(len(keys))
+}
+
+// TestKeysMovedOnRemove compares how many of 1M order keys change node when one node goes
+// away: about 1/N with the ring, (N-1)/N with plain modulo. ShardFor's multiply-high
+// reduction keeps keys in contiguous ranges, so it moves fewer, but still several times 1/N.
+func TestKeysMovedOnRemove(t *testing.T) {
+	_, keys := shardTestKeys(shardKeys)
+	for _, n := range []int{8, 64} {
+		ideal := 1 / float64(n)
+		for _, rk := range routerKinds {
+			moved := keysMovedOnRemove(t, keys, n, rk.make)
+			if rk.name == "hashring" {
+				if moved > 1.25*ideal {
+					t.Errorf("%s, %d nodes: %.1f%% of keys moved, want about %.1f%%", rk.name, n, 100*moved, 100*ideal)
+				}
+			} else if moved < 2*ideal {
+				t.Errorf("%s, %d nodes: only %.1f%% of keys moved, want well over %.1f%%", rk.name, n, 100*moved, 100*ideal)
+			}
+		}
+	}
+}
+
+// BenchmarkNodeRouting measures the per-key cost of a ring lookup against modulo sharding
+// at 8 and 64 nodes. moved-% is the share of 1M keys that change node when one node is
+// removed; max/mean is the busiest node's load relative to an even split.
+func BenchmarkNodeRouting(b *testing.B) {
+	_, keys := shardTestKeys(shardKeys)
+	for _, n := range []int{8, 64} {
+		nodes := ringNodes(n)
+		for _, rk := range routerKinds {
+			b.Run("nodes="+strconv.Itoa(n)+"/"+rk.name, func(b *testing.B) {
+				r := rk.make(b, nodes)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := range b.N {
+					sinkString = r.route(keys[i%len(keys)])
+				}
+
+				b.StopTimer()
+				loads := map[string]int{}
+				for _, k := range keys {
+					loads[r.route(k)]++
+				}
+				busiest := 0
+				for _, l := range loads {
+					busiest = max(busiest, l)
+				}
+				b.ReportMetric(float64(busiest)*float64(n)/float64(len(keys)), "max/mean")
+				b.ReportMetric(100*keysMovedOnRemove(b, keys, n, rk.make), "moved-%")
+			})
+		}
+	}
+}
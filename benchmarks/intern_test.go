@@ -0,0 +1,128 @@
+package benchmarks
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"unique"
+
+	"golang-backend/pkg/intern"
+)
+
+// symbolOrder is a parsed order record carrying its instrument symbol.
+type symbolOrder struct {
+	Symbol string
+	Order  Order
+}
+
+// handleSymbolOrder is symbolOrder with the symbol kept as a unique.Handle, which is one
+// word and compares by pointer.
+type handleSymbolOrder struct {
+	Symbol unique.Handle[string]
+	Order  Order
+}
+
+const (
+	internRecords = 1_000_000
+	internSymbols = 100
+)
+
+// symbolInputs returns n symbols drawn from distinct values, as byte slices the way a
+// parser sees them. The padding makes each symbol long enough that its copy is not free.
+func symbolInputs(n, distinct int) [][]byte {
+	syms := make([][]byte, distinct)
+	for i := range syms {
+		syms[i] = fmt.Appendf(nil, "EXCH:SYMBOL-%04d", i)
+	}
+	inputs := make([][]byte, n)
+	for i := range inputs {
+		inputs[i] = syms[(i*31)%distinct]
+	}
+	return inputs
+}
+
+// liveHeap returns the bytes in live heap objects after a full collection.
+func liveHeap() uint64 {
+	runtime.GC()
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
+
+func TestInternedRecords_ShareSymbols(t *testing.T) {
+	inputs := symbolInputs(1000, 10)
+	m := intern.NewMap()
+	own := make([]symbolOrder, len(inputs))
+	shared := make([]symbolOrder, len(inputs))
+	for i, in := range inputs {
+		own[i].Symbol = string(in)
+		shared[i].Symbol = m.InternBytes(in)
+	}
+
+	for i := range own {
+		if own[i].Symbol != shared[i].Symbol {
+			t.Fatalf("record %d: interned %q, uninterned %q", i, shared[i].Symbol, own[i].Symbol)
+		}
+	}
+	if m.Len() != 10 {
+		t.Errorf("interned %d distinct symbols, want 10", m.Len())
+	}
+}
+
+// BenchmarkInternSymbols stores 1M records with 100 distinct symbols, copying each symbol,
+// interning it through a map or unique.Make, or keeping a unique.Handle. Besides the time
+// per 1M records, it reports the heap the finished records keep alive.
+func BenchmarkInternSymbols(b *testing.B) {
+	inputs := symbolInputs(internRecords, internSymbols)
+
+	cases := []struct {
+		name  string
+		build func() any
+	}{
+		{name: "none", build: func() any {
+			recs := make([]symbolOrder, len(inputs))
+			for i, in := range inputs {
+				recs[i].Symbol = string(in)
+			}
+			return recs
+		}},
+		{name: "map", build: func() any {
+			m := intern.NewMap()
+			recs := make([]symbolOrder, len(inputs))
+			for i, in := range inputs {
+				recs[i].Symbol = m.InternBytes(in)
+			}
+			return recs
+		}},
+		{name: "unique", build: func() any {
+			var u intern.Unique
+			recs := make([]symbolOrder, len(inputs))
+			for i, in := range inputs {
+				recs[i].Symbol = u.Intern(string(in))
+			}
+			return recs
+		}},
+		{name: "unique-handle", build: func() any {
+			recs := make([]handleSymbolOrder, len(inputs))
+			for i, in := range inputs {
+				recs[i].Symbol = unique.Make(string(in))
+			}
+			return recs
+		}},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				c.build()
+			}
+
+			b.StopTimer()
+			before := liveHeap()
+			recs := c.build()
+			retained := liveHeap() - before
+			runtime.KeepAlive(recs)
+			b.ReportMetric(float64(retained), "retained-B")
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package benchmarks
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"golang-backend/pkg/bufpool"
+)
+
+var sinkBytes []byte
+
+// sinkLen receives the encoded length when the buffer itself goes back to a pool
+// and must not be retained.
+var sinkLen int
+
+// jsonSampleOrder and jsonSampleBigObject are the payloads used by the JSON benchmarks.
+var (
+	jsonSampleOrder     = Order{Price: 99.5, Qty: 10}
+	jsonSampleBigObject = func() BigObject {
+		o := BigObject{ID: 42, Name: "big", IsActive: true}
+		for i := range o.Data {
+			o.Data[i] = int64(i)
+		}
+		return o
+	}()
+)
+
+// repeatReader yields payload over and over, so a single json.Decoder can be
+// reused across benchmark iterations without ever reaching EOF.
+type repeatReader struct {
+	payload []byte
+	off     int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := copy(p, r.payload[r.off:])
+	r.off = (r.off + n) % len(r.payload)
+	return n, nil
+}
+
+// encodePooled encodes v with a fresh Encoder writing into a pooled buffer.
+func encodePooled(v any) ([]byte, error) {
+	buf := bufpool.Get()
+	defer bufpool.Put(buf)
+
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	// Copy out: the buffer goes back to the pool when we return.
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		out  func() any
+	}{
+		{name: "Order", in: &jsonSampleOrder, out: func() any { return new(Order) }},
+		{name: "BigObject", in: &jsonSampleBigObject, out: func() any { return new(BigObject) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marshaled, err := json.Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			pooled, err := encodePooled(tt.in)
+			if err != nil {
+				t.Fatalf("encodePooled: %v", err)
+			}
+			// Encoder.Encode terminates each value with a newline.
+			if !bytes.Equal(append(marshaled, '\n'), pooled) {
+				t.Fatalf("pooled encoding differs:\n got %s\nwant %s", pooled, marshaled)
+			}
+
+			unmarshaled := tt.out()
+			if err := json.Unmarshal(marshaled, unmarshaled); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(unmarshaled, tt.in) {
+				t.Errorf("Unmarshal round trip = %+v, want %+v", unmarshaled, tt.in)
+			}
+
+			dec := json.NewDecoder(&repeatReader{payload: pooled})
+			for range 3 {
+				decoded := tt.out()
+				if err := dec.Decode(decoded); err != nil {
+					t.Fatalf("Decode: %v", err)
+				}
+				if !reflect.DeepEqual(decoded, tt.in) {
+					t.Fatalf("Decoder round trip = %+v, want %+v", decoded, tt.in)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkJSONEncode runs the encode-side variants for v.
+func benchmarkJSONEncode(b *testing.B, v any) {
+	b.Run("Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			out, err := json.Marshal(v)
+			if err != nil {
+				b.Fatal(err)
+			}
+			sinkBytes = out
+		}
+	})
+
+	b.Run("EncoderPooledBuffer", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			buf := bufpool.Get()
+			if err := json.NewEncoder(buf).Encode(v); err != nil {
+				b.Fatal(err)
+			}
+			sinkLen = buf.Len()
+			bufpool.Put(buf)
+		}
+	})
+
+	b.Run("ReusedEncoder", func(b *testing.B) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			buf.Reset()
+			if err := enc.Encode(v); err != nil {
+				b.Fatal(err)
+			}
+			sinkBytes = buf.Bytes()
+		}
+	})
+}
+
+// benchmarkJSONDecode runs the decode-side variants, decoding into a value produced by newV.
+func benchmarkJSONDecode(b *testing.B, v any, newV func() any) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Unmarshal", func(b *testing.B) {
+		out := newV()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			if err := json.Unmarshal(payload, out); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("ReusedDecoder", func(b *testing.B) {
+		dec := json.NewDecoder(&repeatReader{payload: append(payload, '\n')})
+		out := newV()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			if err := dec.Decode(out); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkJSONEncodeOrder compares encoding strategies for the small Order type.
+func BenchmarkJSONEncodeOrder(b *testing.B) {
+	benchmarkJSONEncode(b, &jsonSampleOrder)
+}
+
+// BenchmarkJSONEncodeBigObject compares encoding strategies for the 8KB BigObject.
+func BenchmarkJSONEncodeBigObject(b *testing.B) {
+	benchmarkJSONEncode(b, &jsonSampleBigObject)
+}
+
+// BenchmarkJSONDecodeOrder compares decoding strategies for the small Order type.
+func BenchmarkJSONDecodeOrder(b *testing.B) {
+	benchmarkJSONDecode(b, &jsonSampleOrder, func() any { return new(Order) })
+}
+
+// BenchmarkJSONDecodeBigObject compares decoding strategies for the 8KB BigObject.
+func BenchmarkJSONDecodeBigObject(b *testing.B) {
+	benchmarkJSONDecode(b, &jsonSampleBigObject, func() any { return new(BigObject) })
+}
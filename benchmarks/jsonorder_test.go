@@ -0,0 +1,204 @@
+package benchmarks
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+// appendJSONFloat appends f the way encoding/json does: shortest 'f' formatting, switching
+// to exponent form outside [1e-6, 1e21) and trimming an exponent's leading zero. NaN and
+// infinities have no JSON form.
+func appendJSONFloat(dst []byte, f float64) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return dst, &json.UnsupportedValueError{Str: strconv.FormatFloat(f, 'g', -1, 64)}
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, 64)
+	if format == 'e' {
+		// Turn e-07 into e-7, as encoding/json does.
+		if n := len(dst); n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, nil
+}
+
+// appendOrderJSON appends o as {"price":...,"qty":...}, byte for byte what json.Marshal
+// produces from Order's struct tags.
+func appendOrderJSON(dst []byte, o *Order) ([]byte, error) {
+	dst = append(dst, `{"price":`...)
+	dst, err := appendJSONFloat(dst, o.Price)
+	if err != nil {
+		return dst, err
+	}
+	dst = append(dst, `,"qty":`...)
+	dst = strconv.AppendInt(dst, o.Qty, 10)
+	return append(dst, '}'), nil
+}
+
+// fastJSONOrder is an Order with a hand-written MarshalJSON. json.Marshal still validates
+// and copies what MarshalJSON returns, so appending directly is the faster use of it.
+type fastJSONOrder Order
+
+func (o fastJSONOrder) MarshalJSON() ([]byte, error) {
+	return appendOrderJSON(make([]byte, 0, 48), (*Order)(&o))
+}
+
+// orderJSONMap builds the generic map a handler might assemble instead of using a type.
+func orderJSONMap(o *Order) map[string]any {
+	return map[string]any{"price": o.Price, "qty": o.Qty}
+}
+
+// appendOrdersJSON appends orders as a JSON array.
+func appendOrdersJSON(dst []byte, orders []Order) ([]byte, error) {
+	dst = append(dst, '[')
+	for i := range orders {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		var err error
+		if dst, err = appendOrderJSON(dst, &orders[i]); err != nil {
+			return dst, err
+		}
+	}
+	return append(dst, ']'), nil
+}
+
+// jsonPriceCases are prices whose formatting is easy to get wrong: long fractions, values
+// at the exponent thresholds, and negative zero.
+var jsonPriceCases = []float64{
+	0, math.Copysign(0, -1), 99.5, 0.1 + 0.2, 123456789.123456789, 1.0000000000000002,
+	-42.125, 1e-6, 9.99e-7, 1e-7, 1.5e-300, 1e20, 1e21, 1.7976931348623157e308,
+	math.SmallestNonzeroFloat64, float64(1<<53 + 1),
+}
+
+func TestOrderJSON_MatchesReflection(t *testing.T) {
+	for _, price := range jsonPriceCases {
+		for _, qty := range []int64{0, -7, math.MaxInt64, math.MinInt64} {
+			o := Order{Price: price, Qty: qty}
+			want, err := json.Marshal(&o)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := appendOrderJSON(nil, &o)
+			if err != nil || string(got) != string(want) {
+				t.Errorf("appendOrderJSON(%+v) = %s, %v; json.Marshal gives %s", o, got, err, want)
+			}
+			viaMarshaler, err := json.Marshal(fastJSONOrder(o))
+			if err != nil || string(viaMarshaler) != string(want) {
+				t.Errorf("json.Marshal(fastJSONOrder(%+v)) = %s, %v; want %s", o, viaMarshaler, err, want)
+			}
+			viaMap, err := json.Marshal(orderJSONMap(&o))
+			if err != nil || string(viaMap) != string(want) {
+				t.Errorf("json.Marshal(map of %+v) = %s, %v; want %s", o, viaMap, err, want)
+			}
+		}
+	}
+}
+
+func TestOrderJSON_SliceMatchesReflection(t *testing.T) {
+	orders := sampleOrders(100)
+	want, err := json.Marshal(orders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := appendOrdersJSON(nil, orders); err != nil || string(got) != string(want) {
+		t.Errorf("appendOrdersJSON differs from json.Marshal:\n got %.80s...\nwant %.80s...", got, want)
+	}
+	if got, err := appendOrdersJSON(nil, nil); err != nil || string(got) != "[]" {
+		t.Errorf("appendOrdersJSON(nil) = %s, %v; want []", got, err)
+	}
+}
+
+func TestOrderJSON_RejectsNonFinite(t *testing.T) {
+	for _, price := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		o := Order{Price: price, Qty: 1}
+		if _, err := json.Marshal(&o); err == nil {
+			t.Fatalf("json.Marshal accepted price %v", price)
+		}
+		var unsupported *json.UnsupportedValueError
+		_, err := appendOrderJSON(nil, &o)
+		if !errors.As(err, &unsupported) {
+			t.Errorf("appendOrderJSON(price %v) error = %v, want *json.UnsupportedValueError", price, err)
+		}
+		if _, err := json.Marshal(fastJSONOrder(o)); err == nil {
+			t.Errorf("json.Marshal(fastJSONOrder) accepted price %v", price)
+		}
+	}
+}
+
+// jsonSliceOrders is the number of orders in the slice-encoding benchmark.
+const jsonSliceOrders = 10_000
+
+// BenchmarkOrderJSON encodes one Order and a 10k-order slice with reflection over struct
+// tags, with the hand-written encoder appending into a reused buffer or called through
+// json.Marshal as a MarshalJSON method, and by building map[string]any values first.
+func BenchmarkOrderJSON(b *testing.B) {
+	o := jsonSampleOrder
+	orders := sampleOrders(jsonSliceOrders)
+	fast := make([]fastJSONOrder, len(orders))
+	for i, o := range orders {
+		fast[i] = fastJSONOrder(o)
+	}
+
+	cases := []struct {
+		name   string
+		single func(buf []byte) ([]byte, error)
+		slice  func(buf []byte) ([]byte, error)
+	}{
+		{
+			name:   "reflect",
+			single: func([]byte) ([]byte, error) { return json.Marshal(&o) },
+			slice:  func([]byte) ([]byte, error) { return json.Marshal(orders) },
+		},
+		{
+			name:   "append",
+			single: func(buf []byte) ([]byte, error) { return appendOrderJSON(buf[:0], &o) },
+			slice:  func(buf []byte) ([]byte, error) { return appendOrdersJSON(buf[:0], orders) },
+		},
+		{
+			name:   "MarshalJSON",
+			single: func([]byte) ([]byte, error) { return json.Marshal(fastJSONOrder(o)) },
+			slice:  func([]byte) ([]byte, error) { return json.Marshal(fast) },
+		},
+		{
+			name:   "map",
+			single: func([]byte) ([]byte, error) { return json.Marshal(orderJSONMap(&o)) },
+			slice: func([]byte) ([]byte, error) {
+				maps := make([]map[string]any, len(orders))
+				for i := range orders {
+					maps[i] = orderJSONMap(&orders[i])
+				}
+				return json.Marshal(maps)
+			},
+		},
+	}
+	for _, shape := range []string{"single", "slice"} {
+		for _, c := range cases {
+			encode := c.single
+			if shape == "slice" {
+				encode = c.slice
+			}
+			b.Run(shape+"/"+c.name, func(b *testing.B) {
+				var buf []byte
+				b.ReportAllocs()
+				for range b.N {
+					out, err := encode(buf)
+					if err != nil {
+						b.Fatal(err)
+					}
+					buf = out
+				}
+				sinkLen = len(buf)
+			})
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package benchmarks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// errNotJSONArray is returned when the streamed input is not a JSON array.
+var errNotJSONArray = errors.New("input is not a JSON array")
+
+// streamOrdersJSON decodes a JSON array of orders from r one element at a time, calling fn
+// with each, so only one Order and the decoder's buffer are in memory at once. It accepts
+// what json.Unmarshal into []Order accepts, including null for an empty array, and rejects
+// anything after the array other than whitespace.
+//
+// Unlike Unmarshal, which validates the whole input before decoding any of it, fn has
+// already seen the elements before a syntax error further on.
+func streamOrdersJSON(r io.Reader, fn func(Order) error) error {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case nil: // null
+	case json.Delim('['):
+		// One Order reused for every element: declaring it in the loop would allocate each
+		// time, since Decode takes it as an interface.
+		var o Order
+		for dec.More() {
+			o = Order{}
+			if err := dec.Decode(&o); err != nil {
+				return err
+			}
+			if err := fn(o); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil { // the closing ']'
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: starts with %v", errNotJSONArray, tok)
+	}
+
+	if tok, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = fmt.Errorf("unexpected %v after the array", tok)
+		}
+		return err
+	}
+	return nil
+}
+
+// streamOrdersCollect streams data into a slice, for comparing with json.Unmarshal.
+func streamOrdersCollect(data []byte) ([]Order, error) {
+	var orders []Order
+	err := streamOrdersJSON(bytes.NewReader(data), func(o Order) error {
+		orders = append(orders, o)
+		return nil
+	})
+	return orders, err
+}
+
+func TestStreamOrdersJSON_MatchesUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "two orders", in: `[{"price":1.5,"qty":2},{"price":3,"qty":4}]`},
+		{name: "whitespace everywhere", in: " \n\t[ \n{ \"price\" : 1.5 ,\r\n \"qty\":2 } ,\n\t{\"qty\":4}\n]\n\t "},
+		{name: "nested unknown fields", in: `[{"price":1,"meta":{"tags":["a",{"deep":[1,2,{"x":null}]}],"n":1e3},"qty":2,"extra":"]"}]`},
+		{name: "empty array", in: `[]`},
+		{name: "empty array with whitespace", in: " [ \n ] "},
+		{name: "null", in: `null`},
+		{name: "empty element", in: `[{}]`},
+		{name: "trailing garbage", in: `[{"price":1,"qty":2}] x`},
+		{name: "trailing second array", in: `[] []`},
+		{name: "trailing comma", in: `[{"price":1},]`},
+		{name: "unterminated array", in: `[{"price":1}`},
+		{name: "unterminated element", in: `[{"price":1`},
+		{name: "empty input", in: ``},
+		{name: "object not array", in: `{"price":1}`},
+		{name: "number not array", in: `42`},
+		{name: "element of wrong type", in: `[1]`},
+		{name: "field of wrong type", in: `[{"price":"cheap"}]`},
+	}
+	for _, tt := range tests {
+		var want []Order
+		wantErr := json.Unmarshal([]byte(tt.in), &want)
+		got, gotErr := streamOrdersCollect([]byte(tt.in))
+
+		if (gotErr == nil) != (wantErr == nil) {
+			t.Errorf("%s: stream error = %v, Unmarshal error = %v", tt.name, gotErr, wantErr)
+			continue
+		}
+		if wantErr == nil && !slices.Equal(got, want) {
+			t.Errorf("%s: stream decoded %v, Unmarshal decoded %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestStreamOrdersJSON_CallbackErrorStops(t *testing.T) {
+	data := orderJSONArray(10)
+	calls := 0
+	err := streamOrdersJSON(bytes.NewReader(data), func(Order) error {
+		calls++
+		if calls == 3 {
+			return errInvalidOrder
+		}
+		return nil
+	})
+	if !errors.Is(err, errInvalidOrder) || calls != 3 {
+		t.Errorf("stream = %v after %d calls, want errInvalidOrder after 3", err, calls)
+	}
+}
+
+func TestStreamOrdersJSON_LargeInput(t *testing.T) {
+	data := orderJSONArray(10_000)
+	var want []Order
+	if err := json.Unmarshal(data, &want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := streamOrdersCollect(data)
+	if err != nil || !slices.Equal(got, want) {
+		t.Errorf("stream of %d orders: err = %v, %d decoded, equal = %v", len(want), err, len(got), slices.Equal(got, want))
+	}
+}
+
+// orderJSONArray encodes n sample orders as a JSON array, padded with an unknown field the
+// way real payloads carry more than a client reads.
+func orderJSONArray(n int) []byte {
+	buf := []byte{'['}
+	for i, o := range sampleOrders(n) {
+		if i > 0 {
+			buf = append(buf, ",\n"...)
+		}
+		buf = append(buf, `{"id":`...)
+		buf = strconv.AppendInt(buf, int64(i), 10)
+		buf = append(buf, `,"price":`...)
+		buf = strconv.AppendFloat(buf, o.Price, 'f', -1, 64)
+		buf = append(buf, `,"qty":`...)
+		buf = strconv.AppendInt(buf, o.Qty, 10)
+		buf = append(buf, `,"note":"generated order"}`...)
+	}
+	return append(buf, ']')
+}
+
+// BenchmarkJSONOrderArray decodes arrays of 1k, 100k, and 1M orders (about 70MB at the top)
+// with json.Unmarshal into a []Order and with streamOrdersJSON summing each order as it is
+// decoded. B/op is everything allocated; result-B is the heap still held when the decode
+// returns, which for Unmarshal is the whole slice and bounds its peak from below.
+func BenchmarkJSONOrderArray(b *testing.B) {
+	for _, n := range []int{1_000, 100_000, 1_000_000} {
+		data := orderJSONArray(n)
+
+		decoders := []struct {
+			name   string
+			decode func() any
+		}{
+			{name: "Unmarshal", decode: func() any {
+				var orders []Order
+				if err := json.Unmarshal(data, &orders); err != nil {
+					b.Fatal(err)
+				}
+				return orders
+			}},
+			{name: "stream", decode: func() any {
+				var total float64
+				if err := streamOrdersJSON(bytes.NewReader(data), func(o Order) error {
+					total += orderValue(o)
+					return nil
+				}); err != nil {
+					b.Fatal(err)
+				}
+				return total
+			}},
+		}
+		for _, d := range decoders {
+			b.Run(fmt.Sprintf("n=%d/%s", n, d.name), func(b *testing.B) {
+				b.SetBytes(int64(len(data)))
+				b.ReportAllocs()
+				for range b.N {
+					d.decode()
+				}
+
+				b.StopTimer()
+				before := liveHeap()
+				result := d.decode()
+				held := liveHeap() - min(before, liveHeap())
+				runtime.KeepAlive(result)
+				b.ReportMetric(float64(held), "result-B")
+			})
+		}
+	}
+}
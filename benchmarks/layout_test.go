@@ -0,0 +1,144 @@
+package benchmarks
+
+import (
+	"testing"
+	"unsafe"
+)
+
+// layoutElements is the slice length used by the field alignment benchmarks.
+const layoutElements = 1_000_000
+
+// PaddedRecord orders its fields so the compiler must insert padding:
+// A(1) +7 pad, B(8), C(1) +3 pad, D(4), E(8) = 32 bytes.
+type PaddedRecord struct {
+	A bool
+	B int64
+	C bool
+	D int32
+	E int64
+}
+
+// PackedRecord holds the same fields sorted by alignment:
+// B(8), E(8), D(4), A(1), C(1) +2 pad = 24 bytes.
+type PackedRecord struct {
+	B int64
+	E int64
+	D int32
+	A bool
+	C bool
+}
+
+// OrderWithFlagFirst adds a bool flag to Order ahead of the 8-byte fields.
+type OrderWithFlagFirst struct {
+	Active bool
+	Price  float64
+	Qty    int64
+}
+
+// OrderWithFlagLast adds the same bool flag after the 8-byte fields.
+// Reordering cannot help here: the struct is still padded to a multiple of 8.
+type OrderWithFlagLast struct {
+	Price  float64
+	Qty    int64
+	Active bool
+}
+
+var sinkLayoutSum int64
+
+func TestLayout_Sizes(t *testing.T) {
+	if unsafe.Alignof(int64(0)) != 8 {
+		t.Skip("expected sizes assume 8-byte alignment for int64")
+	}
+
+	tests := []struct {
+		name string
+		got  uintptr
+		want uintptr
+	}{
+		{name: "PaddedRecord", got: unsafe.Sizeof(PaddedRecord{}), want: 32},
+		{name: "PackedRecord", got: unsafe.Sizeof(PackedRecord{}), want: 24},
+		{name: "Order", got: unsafe.Sizeof(Order{}), want: 16},
+		{name: "OrderWithFlagFirst", got: unsafe.Sizeof(OrderWithFlagFirst{}), want: 24},
+		{name: "OrderWithFlagLast", got: unsafe.Sizeof(OrderWithFlagLast{}), want: 24},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("unsafe.Sizeof(%s) = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// BenchmarkLayoutPaddedRecord allocates and walks 1M 32-byte records.
+func BenchmarkLayoutPaddedRecord(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		records := make([]PaddedRecord, layoutElements)
+		var sum int64
+		for i := range records {
+			records[i].B = int64(i)
+			sum += records[i].B + int64(records[i].D)
+		}
+		sinkLayoutSum = sum
+	}
+}
+
+// BenchmarkLayoutPackedRecord allocates and walks 1M 24-byte records.
+func BenchmarkLayoutPackedRecord(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		records := make([]PackedRecord, layoutElements)
+		var sum int64
+		for i := range records {
+			records[i].B = int64(i)
+			sum += records[i].B + int64(records[i].D)
+		}
+		sinkLayoutSum = sum
+	}
+}
+
+// BenchmarkLayoutOrder allocates and walks 1M 16-byte orders as a baseline.
+func BenchmarkLayoutOrder(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		orders := make([]Order, layoutElements)
+		var sum int64
+		for i := range orders {
+			orders[i].Qty = int64(i)
+			sum += orders[i].Qty
+		}
+		sinkLayoutSum = sum
+	}
+}
+
+// BenchmarkLayoutOrderWithFlagFirst allocates and walks 1M orders with a leading flag.
+func BenchmarkLayoutOrderWithFlagFirst(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		orders := make([]OrderWithFlagFirst, layoutElements)
+		var sum int64
+		for i := range orders {
+			orders[i].Qty = int64(i)
+			if !orders[i].Active {
+				sum += orders[i].Qty
+			}
+		}
+		sinkLayoutSum = sum
+	}
+}
+
+// BenchmarkLayoutOrderWithFlagLast allocates and walks 1M orders with a trailing flag.
+func BenchmarkLayoutOrderWithFlagLast(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		orders := make([]OrderWithFlagLast, layoutElements)
+		var sum int64
+		for i := range orders {
+			orders[i].Qty = int64(i)
+			if !orders[i].Active {
+				sum += orders[i].Qty
+			}
+		}
+		sinkLayoutSum = sum
+	}
+}
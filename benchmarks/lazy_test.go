@@ -0,0 +1,170 @@
+package benchmarks
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// onceLazy initializes its value on first Get through sync.Once.
+type onceLazy struct {
+	once sync.Once
+	init func() *BigObject
+	v    *BigObject
+}
+
+func newOnceLazy(init func() *BigObject) *onceLazy { return &onceLazy{init: init} }
+
+func (l *onceLazy) Get() *BigObject {
+	l.once.Do(func() { l.v = l.init() })
+	return l.v
+}
+
+// onceValueLazy wraps the initializer in sync.OnceValue.
+type onceValueLazy struct {
+	get func() *BigObject
+}
+
+func newOnceValueLazy(init func() *BigObject) *onceValueLazy {
+	return &onceValueLazy{get: sync.OnceValue(init)}
+}
+
+func (l *onceValueLazy) Get() *BigObject { return l.get() }
+
+// atomicLazy is the double-checked pattern: an atomic load on the fast path, and a mutex
+// with a second check so only the first caller runs the initializer.
+type atomicLazy struct {
+	p    atomic.Pointer[BigObject]
+	mu   sync.Mutex
+	init func() *BigObject
+}
+
+func newAtomicLazy(init func() *BigObject) *atomicLazy { return &atomicLazy{init: init} }
+
+func (l *atomicLazy) Get() *BigObject {
+	if v := l.p.Load(); v != nil {
+		return v
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if v := l.p.Load(); v != nil {
+		return v
+	}
+	v := l.init()
+	l.p.Store(v)
+	return v
+}
+
+// eagerLazy runs the initializer in its constructor, so Get is a plain field read.
+type eagerLazy struct {
+	v *BigObject
+}
+
+func newEagerLazy(init func() *BigObject) *eagerLazy { return &eagerLazy{v: init()} }
+
+func (l *eagerLazy) Get() *BigObject { return l.v }
+
+// newLazyBigObject is the singleton initializer shared by every variant.
+func newLazyBigObject() *BigObject {
+	o := serializeSample()
+	return &o
+}
+
+// The package-level singletons used by the benchmarks. eagerBig is built at package init.
+var (
+	onceBig      = newOnceLazy(newLazyBigObject)
+	onceValueBig = newOnceValueLazy(newLazyBigObject)
+	atomicBig    = newAtomicLazy(newLazyBigObject)
+	eagerBig     = newEagerLazy(newLazyBigObject)
+)
+
+var lazyVariants = []struct {
+	name string
+	new  func(init func() *BigObject) func() *BigObject
+	get  func() *BigObject
+}{
+	{
+		name: "once",
+		new:  func(init func() *BigObject) func() *BigObject { return newOnceLazy(init).Get },
+		get:  onceBig.Get,
+	},
+	{
+		name: "onceValue",
+		new:  func(init func() *BigObject) func() *BigObject { return newOnceValueLazy(init).Get },
+		get:  onceValueBig.Get,
+	},
+	{
+		name: "atomic",
+		new:  func(init func() *BigObject) func() *BigObject { return newAtomicLazy(init).Get },
+		get:  atomicBig.Get,
+	},
+	{
+		name: "eager",
+		new:  func(init func() *BigObject) func() *BigObject { return newEagerLazy(init).Get },
+		get:  eagerBig.Get,
+	},
+}
+
+func TestLazy_InitOnceUnderConcurrentFirstAccess(t *testing.T) {
+	const goroutines = 100
+
+	for _, v := range lazyVariants {
+		t.Run(v.name, func(t *testing.T) {
+			var calls atomic.Int32
+			get := v.new(func() *BigObject {
+				calls.Add(1)
+				return newLazyBigObject()
+			})
+
+			start := make(chan struct{})
+			got := make([]*BigObject, goroutines)
+			var wg sync.WaitGroup
+			for i := range goroutines {
+				wg.Go(func() {
+					<-start
+					got[i] = get()
+				})
+			}
+			close(start)
+			wg.Wait()
+
+			if n := calls.Load(); n != 1 {
+				t.Fatalf("initializer ran %d times, want 1", n)
+			}
+			for i, p := range got {
+				if p == nil || p != got[0] {
+					t.Fatalf("goroutine %d got %p, goroutine 0 got %p", i, p, got[0])
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLazyInit measures the steady-state cost of reaching an already-initialized
+// singleton. Every variant is called through the same func value, so the difference between
+// them is the initialization check itself.
+func BenchmarkLazyInit(b *testing.B) {
+	for _, v := range lazyVariants {
+		v.get() // initialize outside the timed region
+
+		b.Run(v.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				globalSinkPool = v.get()
+			}
+		})
+
+		b.Run(v.name+"/parallel", func(b *testing.B) {
+			runWithProcs(b, func(b *testing.B) {
+				b.RunParallel(func(pb *testing.PB) {
+					var o *BigObject
+					for pb.Next() {
+						o = v.get()
+					}
+					globalSinkParallel.Store(o)
+				})
+			})
+		})
+	}
+}
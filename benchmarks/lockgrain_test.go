@@ -0,0 +1,176 @@
+package benchmarks
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"golang-backend/pkg/lockstats"
+)
+
+const (
+	lockBatchSize = 100
+	ledgerBatches = 64
+)
+
+// orderLedger is shared state that workers read in batches of lockBatchSize orders and
+// fold into a running total, all guarded by one instrumented mutex.
+type orderLedger struct {
+	mu     lockstats.Mutex
+	orders []Order
+	total  float64
+}
+
+func newOrderLedger() *orderLedger {
+	return &orderLedger{orders: sampleOrders(ledgerBatches * lockBatchSize)}
+}
+
+func (l *orderLedger) batch(n int) []Order {
+	start := n % ledgerBatches * lockBatchSize
+	return l.orders[start : start+lockBatchSize]
+}
+
+// pricedValue is the per-order work: processOrder's arithmetic on a copy of the price,
+// times the quantity.
+func pricedValue(o *Order) float64 {
+	v := o.Price
+	for range 16 {
+		v = v*1.0001 + 1
+	}
+	return v * float64(o.Qty)
+}
+
+// ledgerHoldBatch locks once and processes the whole batch under the lock.
+func ledgerHoldBatch(l *orderLedger, n int, _ []Order) {
+	l.mu.Lock()
+	for i, orders := 0, l.batch(n); i < len(orders); i++ {
+		l.total += pricedValue(&orders[i])
+	}
+	l.mu.Unlock()
+}
+
+// ledgerPerOrder locks around each order, so waiters get in between orders but every
+// order pays for a lock and unlock.
+func ledgerPerOrder(l *orderLedger, n int, _ []Order) {
+	orders := l.batch(n)
+	for i := range orders {
+		l.mu.Lock()
+		l.total += pricedValue(&orders[i])
+		l.mu.Unlock()
+	}
+}
+
+// ledgerCopyOut copies the batch into scratch under the lock, processes it with the lock
+// released, and locks again only to add the batch's sum.
+func ledgerCopyOut(l *orderLedger, n int, scratch []Order) {
+	l.mu.Lock()
+	copy(scratch, l.batch(n))
+	l.mu.Unlock()
+
+	var sum float64
+	for i := range scratch {
+		sum += pricedValue(&scratch[i])
+	}
+
+	l.mu.Lock()
+	l.total += sum
+	l.mu.Unlock()
+}
+
+var ledgerStrategies = []struct {
+	name    string
+	process func(l *orderLedger, n int, scratch []Order)
+}{
+	{name: "hold-batch", process: ledgerHoldBatch},
+	{name: "per-order", process: ledgerPerOrder},
+	{name: "copy-out", process: ledgerCopyOut},
+}
+
+// closeTotals compares totals summed in different orders.
+func closeTotals(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func TestLedgerStrategies_SameTotal(t *testing.T) {
+	want := 0.0
+	for _, o := range newOrderLedger().orders {
+		want += pricedValue(&o)
+	}
+
+	for _, s := range ledgerStrategies {
+		l := newOrderLedger()
+		scratch := make([]Order, lockBatchSize)
+		for n := range ledgerBatches {
+			s.process(l, n, scratch)
+		}
+		if !closeTotals(l.total, want) {
+			t.Errorf("%s: total %v, want %v", s.name, l.total, want)
+		}
+		if st := l.mu.Stats(); st.Contended != 0 {
+			t.Errorf("%s: %d contended locks with one goroutine", s.name, st.Contended)
+		}
+	}
+}
+
+// TestLedgerStrategies_Concurrent is meant for -race: workers share one ledger and every
+// batch must be counted exactly once.
+func TestLedgerStrategies_Concurrent(t *testing.T) {
+	const workers = 8
+	want := 0.0
+	for _, o := range newOrderLedger().orders {
+		want += pricedValue(&o)
+	}
+	want *= workers
+
+	for _, s := range ledgerStrategies {
+		l := newOrderLedger()
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Go(func() {
+				scratch := make([]Order, lockBatchSize)
+				for n := range ledgerBatches {
+					s.process(l, n, scratch)
+				}
+			})
+		}
+		wg.Wait()
+		if !closeTotals(l.total, want) {
+			t.Errorf("%s: total %v, want %v", s.name, l.total, want)
+		}
+	}
+}
+
+// BenchmarkLockGranularity runs four worker goroutines per P over a shared ledger, each
+// op processing one batch of 100 orders. From lockstats.Mutex, max-wait-µs is the longest
+// any Lock waited, mean-wait-ns the average contended wait, and contended-% the share of
+// Locks that had to wait. Holding the lock for a batch queues every waiter behind 100
+// orders, locking per order pays a lock round trip per order, and copying out holds the
+// lock only for a copy. The tail is set by a holder being preempted inside its critical
+// section, so with few CPUs max-wait tracks scheduler time slices more than hold time.
+func BenchmarkLockGranularity(b *testing.B) {
+	for _, s := range ledgerStrategies {
+		b.Run(s.name, func(b *testing.B) {
+			runWithProcs(b, func(b *testing.B) {
+				l := newOrderLedger()
+				var next atomic.Int64
+				b.SetParallelism(4)
+				b.ResetTimer()
+				l.mu.ResetStats()
+				b.RunParallel(func(pb *testing.PB) {
+					scratch := make([]Order, lockBatchSize)
+					for pb.Next() {
+						s.process(l, int(next.Add(1)), scratch)
+					}
+				})
+				b.StopTimer()
+
+				st := l.mu.Stats()
+				b.ReportMetric(float64(st.MaxWait.Microseconds()), "max-wait-µs")
+				b.ReportMetric(float64(st.MeanWait().Nanoseconds()), "mean-wait-ns")
+				b.ReportMetric(100*float64(st.Contended)/float64(max(st.Locks, 1)), "contended-%")
+				sinkTotal = l.total
+			})
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+)
+
+// logSymbol is the symbol field of the logged order event.
+const logSymbol = "BTC-USD"
+
+// The order event logged by every variant has four fields: id, symbol, price, and qty.
+
+func logOrderFprintf(w io.Writer, id int64, o *Order) {
+	fmt.Fprintf(w, "order id=%d symbol=%s price=%.2f qty=%d\n", id, logSymbol, o.Price, o.Qty)
+}
+
+func logOrderPrintf(l *log.Logger, id int64, o *Order) {
+	l.Printf("order id=%d symbol=%s price=%.2f qty=%d", id, logSymbol, o.Price, o.Qty)
+}
+
+// logOrderAttrs uses typed attributes, which slog stores without boxing.
+func logOrderAttrs(ctx context.Context, l *slog.Logger, id int64, o *Order) {
+	l.LogAttrs(ctx, slog.LevelInfo, "order",
+		slog.Int64("id", id),
+		slog.String("symbol", logSymbol),
+		slog.Float64("price", o.Price),
+		slog.Int64("qty", o.Qty),
+	)
+}
+
+// logOrderKV passes alternating keys and values as ...any, boxing each non-constant value.
+func logOrderKV(ctx context.Context, l *slog.Logger, id int64, o *Order) {
+	l.InfoContext(ctx, "order", "id", id, "symbol", logSymbol, "price", o.Price, "qty", o.Qty)
+}
+
+// recordingHandler keeps the attributes of every record it is given.
+type recordingHandler struct {
+	level   slog.Level
+	records []map[string]slog.Value
+}
+
+func (h *recordingHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	attrs := make(map[string]slog.Value, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value
+		return true
+	})
+	h.records = append(h.records, attrs)
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *recordingHandler) WithGroup(string) slog.Handler { return h }
+
+func TestSlogOrderEvent_Attributes(t *testing.T) {
+	ctx := context.Background()
+	o := &Order{Price: 99.5, Qty: 10}
+	want := map[string]slog.Value{
+		"id":     slog.Int64Value(42),
+		"symbol": slog.StringValue(logSymbol),
+		"price":  slog.Float64Value(99.5),
+		"qty":    slog.Int64Value(10),
+	}
+
+	for name, logFn := range map[string]func(context.Context, *slog.Logger, int64, *Order){
+		"attrs": logOrderAttrs,
+		"kv":    logOrderKV,
+	} {
+		h := &recordingHandler{level: slog.LevelInfo}
+		logFn(ctx, slog.New(h), 42, o)
+
+		if len(h.records) != 1 {
+			t.Fatalf("%s: handler saw %d records, want 1", name, len(h.records))
+		}
+		got := h.records[0]
+		if len(got) != len(want) {
+			t.Errorf("%s: got %d attributes %v, want %d", name, len(got), got, len(want))
+		}
+		for key, v := range want {
+			if g, ok := got[key]; !ok || !g.Equal(v) {
+				t.Errorf("%s: attribute %s = %v (kind %v), want %v (kind %v)", name, key, g, g.Kind(), v, v.Kind())
+			}
+		}
+	}
+}
+
+func TestSlogOrderEvent_DisabledLevel(t *testing.T) {
+	h := &recordingHandler{level: slog.LevelWarn}
+	l := slog.New(h)
+	logOrderAttrs(context.Background(), l, 1, &Order{})
+	logOrderKV(context.Background(), l, 1, &Order{})
+
+	if len(h.records) != 0 {
+		t.Errorf("handler below its level saw %d records, want 0", len(h.records))
+	}
+}
+
+// BenchmarkLogOrderEvent logs a four-field order event with fmt.Fprintf, log.Printf, and
+// slog with typed attributes and with ...any key-value pairs. All write text to a
+// countingWriter, because log.Logger skips formatting entirely when its output is io.Discard.
+// The disabled cases log below the handler's level: slog checks Enabled before building the
+// record, but the ...any call has already boxed its arguments, and only an explicit Enabled
+// guard or typed attributes keep the disabled path allocation-free.
+func BenchmarkLogOrderEvent(b *testing.B) {
+	ctx := context.Background()
+	orders := sampleOrders(1024)
+	var out countingWriter
+	stdLogger := log.New(&out, "", log.LstdFlags)
+	slogger := slog.New(slog.NewTextHandler(&out, nil))
+	quiet := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	cases := []struct {
+		name string
+		fn   func(id int64, o *Order)
+	}{
+		{name: "fmt.Fprintf", fn: func(id int64, o *Order) { logOrderFprintf(&out, id, o) }},
+		{name: "log.Printf", fn: func(id int64, o *Order) { logOrderPrintf(stdLogger, id, o) }},
+		{name: "slog/attrs", fn: func(id int64, o *Order) { logOrderAttrs(ctx, slogger, id, o) }},
+		{name: "slog/kv", fn: func(id int64, o *Order) { logOrderKV(ctx, slogger, id, o) }},
+		{name: "slog/disabled/attrs", fn: func(id int64, o *Order) { logOrderAttrs(ctx, quiet, id, o) }},
+		{name: "slog/disabled/kv", fn: func(id int64, o *Order) { logOrderKV(ctx, quiet, id, o) }},
+		{name: "slog/disabled/guarded", fn: func(id int64, o *Order) {
+			if quiet.Enabled(ctx, slog.LevelInfo) {
+				logOrderKV(ctx, quiet, id, o)
+			}
+		}},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				c.fn(int64(i), &orders[i%len(orders)])
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package benchmarks
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// The lookups find an Order by ID. Order has no ID of its own, so the collections hold
+// orderLine records, the same {ID, Order} pairs the line parsers produce.
+
+// orderLookup finds the order stored under id.
+type orderLookup interface {
+	Find(id int64) (*Order, bool)
+}
+
+// linearLookup scans an unsorted slice.
+type linearLookup []orderLine
+
+func (l linearLookup) Find(id int64) (*Order, bool) {
+	for i := range l {
+		if l[i].ID == id {
+			return &l[i].Order, true
+		}
+	}
+	return nil, false
+}
+
+// binaryLookup binary-searches a slice sorted by ID.
+type binaryLookup []orderLine
+
+func newBinaryLookup(records []orderLine) binaryLookup {
+	sorted := slices.Clone(records)
+	slices.SortFunc(sorted, func(a, b orderLine) int { return cmp.Compare(a.ID, b.ID) })
+	return sorted
+}
+
+func (l binaryLookup) Find(id int64) (*Order, bool) {
+	i, ok := slices.BinarySearchFunc(l, id, func(r orderLine, id int64) int { return cmp.Compare(r.ID, id) })
+	if !ok {
+		return nil, false
+	}
+	return &l[i].Order, true
+}
+
+// mapLookup indexes the records by ID.
+type mapLookup map[int64]*Order
+
+func newMapLookup(records []orderLine) mapLookup {
+	m := make(mapLookup, len(records))
+	for i := range records {
+		m[records[i].ID] = &records[i].Order
+	}
+	return m
+}
+
+func (m mapLookup) Find(id int64) (*Order, bool) {
+	o, ok := m[id]
+	return o, ok
+}
+
+var orderLookups = []struct {
+	name string
+	new  func(records []orderLine) orderLookup
+}{
+	{name: "linear", new: func(r []orderLine) orderLookup { return linearLookup(r) }},
+	{name: "binary", new: func(r []orderLine) orderLookup { return newBinaryLookup(r) }},
+	{name: "map", new: func(r []orderLine) orderLookup { return newMapLookup(r) }},
+}
+
+// lookupRecords returns n records with distinct random even IDs in random order, so any
+// odd ID is a miss. The Order's Qty echoes its ID to check what a lookup returned.
+func lookupRecords(n int, rng *rand.Rand) []orderLine {
+	seen := make(map[int64]bool, n)
+	records := make([]orderLine, 0, n)
+	for len(records) < n {
+		id := rng.Int64N(1<<40) * 2
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		records = append(records, orderLine{ID: id, Order: Order{Price: float64(len(records)), Qty: id}})
+	}
+	return records
+}
+
+func TestOrderLookups_Agree(t *testing.T) {
+	rng := rand.New(rand.NewPCG(4, 4))
+	for _, n := range []int{0, 1, 4, 17, 256} {
+		records := lookupRecords(n, rng)
+		for _, l := range orderLookups {
+			lookup := l.new(records)
+			for _, r := range records {
+				if o, ok := lookup.Find(r.ID); !ok || o.Qty != r.ID {
+					t.Errorf("%s n=%d: Find(%d) = %v, %v; want the stored order", l.name, n, r.ID, o, ok)
+				}
+				if o, ok := lookup.Find(r.ID + 1); ok {
+					t.Errorf("%s n=%d: Find(%d) = %v, want a miss", l.name, n, r.ID+1, o)
+				}
+			}
+			if _, ok := lookup.Find(-1); ok {
+				t.Errorf("%s n=%d: Find(-1) hit", l.name, n)
+			}
+		}
+	}
+}
+
+// lookupSizes are the collection sizes benchmarked, spanning the crossovers.
+var lookupSizes = []int{4, 16, 64, 256, 4096}
+
+// BenchmarkOrderLookup finds orders by ID with a linear scan, a binary search, and a map,
+// cycling through hit or miss IDs in random order.
+func BenchmarkOrderLookup(b *testing.B) {
+	for _, n := range lookupSizes {
+		rng := rand.New(rand.NewPCG(uint64(n), 5))
+		records := lookupRecords(n, rng)
+
+		hits := make([]int64, 0, 1024)
+		for len(hits) < cap(hits) {
+			hits = append(hits, records[rng.IntN(n)].ID)
+		}
+		misses := make([]int64, len(hits))
+		for i, id := range hits {
+			misses[i] = id + 1
+		}
+
+		for _, probe := range []struct {
+			name string
+			ids  []int64
+		}{{name: "hit", ids: hits}, {name: "miss", ids: misses}} {
+			for _, l := range orderLookups {
+				lookup := l.new(records)
+				b.Run(fmt.Sprintf("%s/n=%d/%s", probe.name, n, l.name), func(b *testing.B) {
+					b.ReportAllocs()
+					for i := range b.N {
+						_, sinkFound = lookup.Find(probe.ids[i%len(probe.ids)])
+					}
+				})
+			}
+		}
+	}
+}
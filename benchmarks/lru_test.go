@@ -0,0 +1,198 @@
+package benchmarks
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"golang-backend/pkg/lru"
+)
+
+// lruCapacity is the number of orders each cache is filled with before measuring.
+const lruCapacity = 10_000
+
+// orderCache is the surface shared by the LRU and the unbounded maps it is compared with.
+// *lru.Cache[int64, Order] satisfies it directly.
+type orderCache interface {
+	Get(key int64) (*Order, bool)
+	Put(key int64, o *Order)
+}
+
+// orderDeleter is implemented by the unbounded caches, which the miss-path benchmark keeps
+// at a steady size by deleting keys itself.
+type orderDeleter interface {
+	Delete(key int64)
+}
+
+type mapOrderCache map[int64]*Order
+
+func (m mapOrderCache) Get(key int64) (*Order, bool) {
+	o, ok := m[key]
+	return o, ok
+}
+
+func (m mapOrderCache) Put(key int64, o *Order) { m[key] = o }
+func (m mapOrderCache) Delete(key int64)        { delete(m, key) }
+
+type syncMapOrderCache struct {
+	m sync.Map
+}
+
+func (c *syncMapOrderCache) Get(key int64) (*Order, bool) {
+	v, ok := c.m.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return v.(*Order), true
+}
+
+func (c *syncMapOrderCache) Put(key int64, o *Order) { c.m.Store(key, o) }
+func (c *syncMapOrderCache) Delete(key int64)        { c.m.Delete(key) }
+
+// lockedOrderCache guards a cache that is not safe for concurrent use with a mutex.
+type lockedOrderCache struct {
+	mu sync.Mutex
+	c  orderCache
+}
+
+func (l *lockedOrderCache) Get(key int64) (*Order, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.c.Get(key)
+}
+
+func (l *lockedOrderCache) Put(key int64, o *Order) {
+	l.mu.Lock()
+	l.c.Put(key, o)
+	l.mu.Unlock()
+}
+
+func (l *lockedOrderCache) Delete(key int64) {
+	if d, ok := l.c.(orderDeleter); ok {
+		l.mu.Lock()
+		d.Delete(key)
+		l.mu.Unlock()
+	}
+}
+
+// orderCacheImpls builds each cache empty. concurrent marks those safe to share without a lock.
+var orderCacheImpls = []struct {
+	name       string
+	new        func(tb testing.TB) orderCache
+	concurrent bool
+}{
+	{name: "lru", new: func(tb testing.TB) orderCache {
+		c, err := lru.New[int64, Order](lruCapacity)
+		if err != nil {
+			tb.Fatalf("lru.New: %v", err)
+		}
+		return c
+	}},
+	{name: "map", new: func(testing.TB) orderCache { return make(mapOrderCache, lruCapacity) }},
+	{name: "syncMap", new: func(testing.TB) orderCache { return &syncMapOrderCache{} }, concurrent: true},
+}
+
+// cacheWorker holds one goroutine's state for the cache workloads.
+type cacheWorker struct {
+	r    *rand.Rand
+	base int64 // keeps each goroutine's fresh miss-path keys disjoint from the others'
+	seq  int64
+	hits int
+}
+
+var lruOrders = BuildOrderPointers(lruCapacity)
+
+// cacheWorkloads are the per-op bodies. The cache starts filled with keys [0, lruCapacity).
+var cacheWorkloads = []struct {
+	name string
+	op   func(c orderCache, w *cacheWorker)
+}{
+	{name: "hit", op: func(c orderCache, w *cacheWorker) {
+		if _, ok := c.Get(w.seq % lruCapacity); ok {
+			w.hits++
+		}
+		w.seq++
+	}},
+	{name: "missEvict", op: func(c orderCache, w *cacheWorker) {
+		// The LRU evicts on its own; the unbounded maps delete the key written lruCapacity Puts
+		// earlier, the cheapest eviction they could have.
+		key := w.base + lruCapacity + w.seq
+		c.Put(key, lruOrders[w.seq%lruCapacity])
+		if d, ok := c.(orderDeleter); ok {
+			d.Delete(key - lruCapacity)
+		}
+		w.seq++
+	}},
+	{name: "mixed90/10", op: func(c orderCache, w *cacheWorker) {
+		// Keys span twice the capacity, so about half the reads miss.
+		key := w.r.Int64N(2 * lruCapacity)
+		if w.r.IntN(10) == 0 {
+			c.Put(key, lruOrders[key%lruCapacity])
+			return
+		}
+		if _, ok := c.Get(key); ok {
+			w.hits++
+		}
+	}},
+}
+
+func newFilledOrderCache(tb testing.TB, newCache func(testing.TB) orderCache) orderCache {
+	c := newCache(tb)
+	for i := range int64(lruCapacity) {
+		c.Put(i, lruOrders[i])
+	}
+	return c
+}
+
+func TestOrderCaches_HitAfterFill(t *testing.T) {
+	for _, impl := range orderCacheImpls {
+		c := newFilledOrderCache(t, impl.new)
+		for i := range int64(lruCapacity) {
+			if got, ok := c.Get(i); !ok || got != lruOrders[i] {
+				t.Fatalf("%s: Get(%d) = %p, %v, want %p", impl.name, i, got, ok, lruOrders[i])
+			}
+		}
+	}
+}
+
+// sinkCacheHits collects the hit counts of the cache benchmarks.
+var sinkCacheHits atomic.Int64
+
+// BenchmarkOrderCache compares the LRU with an unbounded map and sync.Map, single-threaded
+// and under RunParallel. In parallel, the LRU and map are guarded by a mutex.
+func BenchmarkOrderCache(b *testing.B) {
+	for _, wl := range cacheWorkloads {
+		for _, impl := range orderCacheImpls {
+			b.Run(wl.name+"/"+impl.name, func(b *testing.B) {
+				c := newFilledOrderCache(b, impl.new)
+				w := &cacheWorker{r: rand.New(rand.NewPCG(1, 1))}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					wl.op(c, w)
+				}
+				sinkCacheHits.Add(int64(w.hits))
+			})
+
+			b.Run(wl.name+"/"+impl.name+"/parallel", func(b *testing.B) {
+				runWithProcs(b, func(b *testing.B) {
+					c := newFilledOrderCache(b, impl.new)
+					if !impl.concurrent {
+						c = &lockedOrderCache{c: c}
+					}
+					var workers atomic.Uint64
+					b.ResetTimer()
+					b.RunParallel(func(pb *testing.PB) {
+						g := workers.Add(1)
+						w := &cacheWorker{r: rand.New(rand.NewPCG(g, g)), base: int64(g) << 40}
+						for pb.Next() {
+							wl.op(c, w)
+						}
+						sinkCacheHits.Add(int64(w.hits))
+					})
+				})
+			})
+		}
+	}
+}
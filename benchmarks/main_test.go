@@ -0,0 +1,42 @@
+package benchmarks
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+)
+
+var (
+	benchProfile    = flag.String("benchprofile", "", "comma-separated `profiles` to capture around the run: cpu, heap")
+	benchProfileDir = flag.String("benchprofiledir", ".", "`directory` for -benchprofile output")
+)
+
+// TestMain wraps the run in the profiles requested with -benchprofile, named after the
+// -bench pattern:
+//
+//	go test -run '^$' -bench BenchmarkSortOrders -benchprofile cpu,heap -benchprofiledir /tmp/prof
+//
+// Without -benchprofile it only runs the tests.
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	cpu, heap, err := parseProfileKinds(*benchProfile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "-benchprofile:", err)
+		os.Exit(2)
+	}
+	stop, err := startProfiles(profileConfig{cpu: cpu, heap: heap, dir: *benchProfileDir},
+		profileName(flag.Lookup("test.bench").Value.String()))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "-benchprofile:", err)
+		os.Exit(2)
+	}
+
+	code := m.Run()
+	if err := stop(); err != nil {
+		fmt.Fprintln(os.Stderr, "-benchprofile:", err)
+		code = max(code, 1)
+	}
+	os.Exit(code)
+}
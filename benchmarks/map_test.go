@@ -0,0 +1,73 @@
+package benchmarks
+
+import (
+	"fmt"
+	"testing"
+)
+
+// mapSizes are the key counts used by the map sizing benchmarks.
+var mapSizes = []int{100, 10_000, 1_000_000}
+
+var (
+	sinkOrderMap map[int64]*Order
+	sinkOrder    *Order
+)
+
+// fillOrderMap inserts one entry per element of orders, keyed by index.
+// Values point into orders so the benchmark measures map cost, not Order allocation.
+func fillOrderMap(m map[int64]*Order, orders []Order) {
+	for i := range orders {
+		m[int64(i)] = &orders[i]
+	}
+}
+
+// BenchmarkMapInsertNoHint measures inserting N keys into a map that grows incrementally.
+func BenchmarkMapInsertNoHint(b *testing.B) {
+	for _, n := range mapSizes {
+		orders := make([]Order, n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				m := make(map[int64]*Order)
+				fillOrderMap(m, orders)
+				sinkOrderMap = m
+			}
+		})
+	}
+}
+
+// BenchmarkMapInsertWithHint measures inserting N keys into a map pre-sized with make(map, N).
+func BenchmarkMapInsertWithHint(b *testing.B) {
+	for _, n := range mapSizes {
+		orders := make([]Order, n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				m := make(map[int64]*Order, n)
+				fillOrderMap(m, orders)
+				sinkOrderMap = m
+			}
+		})
+	}
+}
+
+// BenchmarkMapLookup measures lookup throughput once the map is built, for both construction strategies.
+func BenchmarkMapLookup(b *testing.B) {
+	for _, n := range mapSizes {
+		orders := make([]Order, n)
+		for _, hint := range []bool{false, true} {
+			m := make(map[int64]*Order)
+			if hint {
+				m = make(map[int64]*Order, n)
+			}
+			fillOrderMap(m, orders)
+
+			b.Run(fmt.Sprintf("n=%d/hint=%v", n, hint), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := range b.N {
+					sinkOrder = m[int64(i%n)]
+				}
+			})
+		}
+	}
+}
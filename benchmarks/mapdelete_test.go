@@ -0,0 +1,147 @@
+package benchmarks
+
+import (
+	"fmt"
+	"maps"
+	"runtime"
+	"testing"
+)
+
+// expireMap removes every entry for which expired reports true and returns the map
+// holding the survivors, which may be m itself.
+type expireMap func(m map[int64]*Order, expired func(id int64) bool) map[int64]*Order
+
+// expireDelete deletes expired keys while ranging, which the spec allows. The map keeps
+// the capacity it had at its largest.
+func expireDelete(m map[int64]*Order, expired func(int64) bool) map[int64]*Order {
+	for id := range m {
+		if expired(id) {
+			delete(m, id)
+		}
+	}
+	return m
+}
+
+// expireRebuild copies the survivors into a new map sized for them and drops the old one.
+func expireRebuild(m map[int64]*Order, expired func(int64) bool) map[int64]*Order {
+	n := 0
+	for id := range m {
+		if !expired(id) {
+			n++
+		}
+	}
+	out := make(map[int64]*Order, n)
+	for id, o := range m {
+		if !expired(id) {
+			out[id] = o
+		}
+	}
+	return out
+}
+
+// expireClear saves the survivors aside, clears the map, and puts them back. clear keeps
+// the map's storage, so the survivors go back into a map still sized for every entry.
+func expireClear(m map[int64]*Order, expired func(int64) bool) map[int64]*Order {
+	type entry struct {
+		id int64
+		o  *Order
+	}
+	var keep []entry
+	for id, o := range m {
+		if !expired(id) {
+			keep = append(keep, entry{id, o})
+		}
+	}
+	clear(m)
+	for _, e := range keep {
+		m[e.id] = e.o
+	}
+	return m
+}
+
+var expireStrategies = []struct {
+	name   string
+	expire expireMap
+}{
+	{name: "delete", expire: expireDelete},
+	{name: "rebuild", expire: expireRebuild},
+	{name: "clear-reinsert", expire: expireClear},
+}
+
+// expireMapInput maps IDs 0 to n-1 to Orders in one slab, so the Orders themselves are a
+// single allocation that outlives every map built over them.
+func expireMapInput(n int) (map[int64]*Order, []Order) {
+	orders := sampleOrders(n)
+	m := make(map[int64]*Order, n)
+	for i := range orders {
+		m[int64(i)] = &orders[i]
+	}
+	return m, orders
+}
+
+// expireFraction returns a predicate expiring pct percent of IDs, spread across the
+// key space.
+func expireFraction(pct int) func(int64) bool {
+	return func(id int64) bool { return (id*7919)%100 < int64(pct) }
+}
+
+var expirePercents = []int{10, 50, 95}
+
+func TestExpireStrategies_SameSurvivors(t *testing.T) {
+	const n = 10_000
+	src, _ := expireMapInput(n)
+	for _, pct := range expirePercents {
+		expired := expireFraction(pct)
+		want := make(map[int64]*Order)
+		for id, o := range src {
+			if !expired(id) {
+				want[id] = o
+			}
+		}
+		if got, lo, hi := len(want), n*(100-pct)/100-n/100, n*(100-pct)/100+n/100; got < lo || got > hi {
+			t.Fatalf("pct=%d: %d survivors, want about %d", pct, got, n*(100-pct)/100)
+		}
+
+		for _, s := range expireStrategies {
+			got := s.expire(maps.Clone(src), expired)
+			if !maps.Equal(got, want) {
+				t.Errorf("pct=%d/%s: %d survivors differ from the %d expected", pct, s.name, len(got), len(want))
+			}
+		}
+	}
+}
+
+// expireMapSize is the number of entries in the map expired per op.
+const expireMapSize = 1_000_000
+
+// BenchmarkMapExpire removes 10%, 50%, or 95% of a 1M-entry map[int64]*Order by deleting in
+// place, rebuilding a map of the survivors, or clearing and reinserting them. Each op gets a
+// fresh clone of the map, made off the timer. retained-B is the heap still held by the
+// resulting map: deleting or clearing does not give back the storage of a map that was
+// once full, while a rebuilt map is sized for its survivors.
+func BenchmarkMapExpire(b *testing.B) {
+	src, orders := expireMapInput(expireMapSize)
+	for _, pct := range expirePercents {
+		expired := expireFraction(pct)
+		for _, s := range expireStrategies {
+			b.Run(fmt.Sprintf("pct=%d/%s", pct, s.name), func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					b.StopTimer()
+					m := maps.Clone(src)
+					b.StartTimer()
+					sinkOrderMap = s.expire(m, expired)
+				}
+
+				b.StopTimer()
+				sinkOrderMap = nil
+				before := liveHeap()
+				m := s.expire(maps.Clone(src), expired)
+				retained := liveHeap() - before
+				runtime.KeepAlive(m)
+				b.ReportMetric(float64(retained), "retained-B")
+			})
+		}
+	}
+	runtime.KeepAlive(orders)
+}
@@ -0,0 +1,138 @@
+package benchmarks
+
+import (
+	"fmt"
+	"testing"
+)
+
+// mapKeyEntries is the number of entries in every map-key benchmark map.
+const mapKeyEntries = 100_000
+
+// orderKey is a two-field comparable key, e.g. an order ID scoped to a venue.
+type orderKey struct {
+	Venue uint32
+	ID    int64
+}
+
+// mapKeyHitRates are the percentages of lookups that find their key.
+var mapKeyHitRates = []int{100, 50}
+
+// lookupIDs returns mapKeyEntries IDs to look up, of which hitPct percent exist in a map
+// holding IDs [0, mapKeyEntries). Misses are drawn from above the key range.
+func lookupIDs(hitPct int) []int64 {
+	ids := make([]int64, mapKeyEntries)
+	for i := range ids {
+		id := int64(i*7919) % mapKeyEntries // visit keys out of insertion order
+		if i%100 >= hitPct {
+			id += mapKeyEntries
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+// formatOrderKey renders id as a fixed-width string key, as it would appear on the wire.
+// At 22 bytes it is too large for the runtime's tiny allocator, so each copy is a real allocation.
+func formatOrderKey(id int64) string {
+	return fmt.Sprintf("order-%016d", id)
+}
+
+// keyFromBytes models a parser that turns a wire-format key into a string it hands back.
+// Returning the string forces the conversion to copy onto the heap.
+//
+//go:noinline
+func keyFromBytes(b []byte) string {
+	return string(b)
+}
+
+func TestLookupIDs_HitRate(t *testing.T) {
+	for _, pct := range mapKeyHitRates {
+		var hits int
+		for _, id := range lookupIDs(pct) {
+			if id < mapKeyEntries {
+				hits++
+			}
+		}
+		if got, want := hits*100/mapKeyEntries, pct; got != want {
+			t.Errorf("hitPct=%d: got %d%% hits", want, got)
+		}
+	}
+}
+
+func TestKeyFromBytes(t *testing.T) {
+	key := formatOrderKey(12345)
+	if want := "order-0000000000012345"; key != want {
+		t.Errorf("formatOrderKey = %q, want %q", key, want)
+	}
+	if got := keyFromBytes([]byte(key)); got != key {
+		t.Errorf("keyFromBytes = %q, want %q", got, key)
+	}
+}
+
+var sinkFound bool
+
+// BenchmarkMapKeyLookup compares lookups in 100k-entry maps keyed by int64, string, and orderKey,
+// at several hit rates. The string variants show the cost of where the key comes from:
+//
+//   - string: a string key that already exists.
+//   - bytesInline: m[string(b)], which the compiler performs without allocating.
+//   - bytesConverted: the []byte is first converted by a helper, allocating a copy per lookup.
+func BenchmarkMapKeyLookup(b *testing.B) {
+	orders := make([]Order, mapKeyEntries)
+
+	intMap := make(map[int64]*Order, mapKeyEntries)
+	strMap := make(map[string]*Order, mapKeyEntries)
+	structMap := make(map[orderKey]*Order, mapKeyEntries)
+	for i := range orders {
+		intMap[int64(i)] = &orders[i]
+		strMap[formatOrderKey(int64(i))] = &orders[i]
+		structMap[orderKey{Venue: 1, ID: int64(i)}] = &orders[i]
+	}
+
+	for _, pct := range mapKeyHitRates {
+		ids := lookupIDs(pct)
+		strKeys := make([]string, len(ids))
+		byteKeys := make([][]byte, len(ids))
+		structKeys := make([]orderKey, len(ids))
+		for i, id := range ids {
+			strKeys[i] = formatOrderKey(id)
+			byteKeys[i] = []byte(strKeys[i])
+			structKeys[i] = orderKey{Venue: 1, ID: id}
+		}
+
+		b.Run(fmt.Sprintf("hit=%d/int64", pct), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				_, sinkFound = intMap[ids[i%len(ids)]]
+			}
+		})
+
+		b.Run(fmt.Sprintf("hit=%d/string", pct), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				_, sinkFound = strMap[strKeys[i%len(strKeys)]]
+			}
+		})
+
+		b.Run(fmt.Sprintf("hit=%d/struct", pct), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				_, sinkFound = structMap[structKeys[i%len(structKeys)]]
+			}
+		})
+
+		b.Run(fmt.Sprintf("hit=%d/bytesInline", pct), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				_, sinkFound = strMap[string(byteKeys[i%len(byteKeys)])]
+			}
+		})
+
+		b.Run(fmt.Sprintf("hit=%d/bytesConverted", pct), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				_, sinkFound = strMap[keyFromBytes(byteKeys[i%len(byteKeys)])]
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package benchmarks
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// orderTable holds orders by ID and supports the three access patterns benchmarked:
+// building it, updating one order's price in place, and scanning every order.
+type orderTable interface {
+	Build(records []orderLine)
+	SetPrice(id int64, price float64)
+	Get(id int64) (Order, bool)
+	SumPrices() float64
+}
+
+// valueTable tables Orders by value. Map values are not addressable, so m[id].Price = p
+// does not compile and every update is a read-modify-write of the whole Order.
+type valueTable struct {
+	m map[int64]Order
+}
+
+func (s *valueTable) Build(records []orderLine) {
+	s.m = make(map[int64]Order, len(records))
+	for _, r := range records {
+		s.m[r.ID] = r.Order
+	}
+}
+
+func (s *valueTable) SetPrice(id int64, price float64) {
+	o := s.m[id]
+	o.Price = price
+	s.m[id] = o
+}
+
+func (s *valueTable) Get(id int64) (Order, bool) {
+	o, ok := s.m[id]
+	return o, ok
+}
+
+func (s *valueTable) SumPrices() float64 {
+	var sum float64
+	for _, o := range s.m {
+		sum += o.Price
+	}
+	return sum
+}
+
+// pointerTable tables *Order, so updates write through the pointer, at the cost of one
+// allocation per entry and a pointer chase per read.
+type pointerTable struct {
+	m map[int64]*Order
+}
+
+func (s *pointerTable) Build(records []orderLine) {
+	s.m = make(map[int64]*Order, len(records))
+	for _, r := range records {
+		o := r.Order
+		s.m[r.ID] = &o
+	}
+}
+
+func (s *pointerTable) SetPrice(id int64, price float64) {
+	s.m[id].Price = price
+}
+
+func (s *pointerTable) Get(id int64) (Order, bool) {
+	o, ok := s.m[id]
+	if !ok {
+		return Order{}, false
+	}
+	return *o, true
+}
+
+func (s *pointerTable) SumPrices() float64 {
+	var sum float64
+	for _, o := range s.m {
+		sum += o.Price
+	}
+	return sum
+}
+
+// indexedTable keeps the orders contiguous in a slice and maps IDs to slice indexes:
+// in-place updates like pointerTable, one allocation and linear scans like a slice.
+type indexedTable struct {
+	orders []Order
+	index  map[int64]int
+}
+
+func (s *indexedTable) Build(records []orderLine) {
+	s.orders = make([]Order, len(records))
+	s.index = make(map[int64]int, len(records))
+	for i, r := range records {
+		s.orders[i] = r.Order
+		s.index[r.ID] = i
+	}
+}
+
+func (s *indexedTable) SetPrice(id int64, price float64) {
+	s.orders[s.index[id]].Price = price
+}
+
+func (s *indexedTable) Get(id int64) (Order, bool) {
+	i, ok := s.index[id]
+	if !ok {
+		return Order{}, false
+	}
+	return s.orders[i], true
+}
+
+func (s *indexedTable) SumPrices() float64 {
+	var sum float64
+	for i := range s.orders {
+		sum += s.orders[i].Price
+	}
+	return sum
+}
+
+var orderTables = []struct {
+	name string
+	new  func() orderTable
+}{
+	{name: "map-value", new: func() orderTable { return &valueTable{} }},
+	{name: "map-pointer", new: func() orderTable { return &pointerTable{} }},
+	{name: "slice-index", new: func() orderTable { return &indexedTable{} }},
+}
+
+// tableUpdates returns count random IDs from records to update, with the price each gets.
+// Prices are whole numbers so sums are exact whatever order a map is scanned in.
+func tableUpdates(records []orderLine, count int, rng *rand.Rand) (ids []int64, prices []float64) {
+	ids = make([]int64, count)
+	prices = make([]float64, count)
+	for i := range ids {
+		ids[i] = records[rng.IntN(len(records))].ID
+		prices[i] = float64(rng.IntN(1000))
+	}
+	return ids, prices
+}
+
+func TestOrderTables_SameDataAfterUpdates(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 3))
+	records := lookupRecords(5000, rng)
+	ids, prices := tableUpdates(records, 2000, rng)
+
+	tables := make([]orderTable, len(orderTables))
+	for i, s := range orderTables {
+		tables[i] = s.new()
+		tables[i].Build(records)
+		for j, id := range ids {
+			tables[i].SetPrice(id, prices[j])
+		}
+	}
+
+	want := tables[0]
+	for i, s := range tables[1:] {
+		name := orderTables[i+1].name
+		for _, r := range records {
+			got, ok := s.Get(r.ID)
+			if w, _ := want.Get(r.ID); !ok || got != w {
+				t.Fatalf("%s: Get(%d) = %+v, %v; %s has %+v", name, r.ID, got, ok, orderTables[0].name, w)
+			}
+		}
+		if got, w := s.SumPrices(), want.SumPrices(); got != w {
+			t.Errorf("%s: SumPrices = %v, %s = %v", name, got, orderTables[0].name, w)
+		}
+		if _, ok := s.Get(-1); ok {
+			t.Errorf("%s: Get of a missing ID reported ok", name)
+		}
+	}
+}
+
+// BenchmarkOrderTable builds 100k orders, updates the price of 10% of them, and sums all
+// prices, for Order map values, *Order map values, and a slice with an index map.
+func BenchmarkOrderTable(b *testing.B) {
+	const n = 100_000
+	rng := rand.New(rand.NewPCG(9, 9))
+	records := lookupRecords(n, rng)
+	ids, prices := tableUpdates(records, n/10, rng)
+
+	for _, s := range orderTables {
+		b.Run("build/"+s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				s.new().Build(records)
+			}
+		})
+	}
+	built := make([]orderTable, len(orderTables))
+	for i, s := range orderTables {
+		built[i] = s.new()
+		built[i].Build(records)
+	}
+	for i, s := range orderTables {
+		b.Run("update-10pct/"+s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				for j, id := range ids {
+					built[i].SetPrice(id, prices[j])
+				}
+			}
+		})
+	}
+	for i, s := range orderTables {
+		b.Run("sum/"+s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkTotal = built[i].SumPrices()
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package benchmarks
+
+import (
+	"sync"
+	"testing"
+)
+
+// update is the method the callback variants invoke.
+//
+//go:noinline
+func (o *Order) update(price float64) {
+	o.Price = price
+}
+
+// process is the method the goroutine variants run, in the `go o.process(&wg)` shape.
+//
+//go:noinline
+func (o *Order) process(wg *sync.WaitGroup) {
+	o.Qty++
+	wg.Done()
+}
+
+// sinkUpdate keeps callbacks alive, like a callback registered on a longer-lived struct.
+var sinkUpdate func(float64)
+
+// callUpdate runs fn without retaining it, so a method value or closure built for the call
+// can live on the caller's stack.
+//
+//go:noinline
+func callUpdate(fn func(float64), price float64) {
+	fn(price)
+}
+
+// methodCallCases invoke (*Order).update once per op. The ones that store the callback
+// make it escape, which is where a method value costs an allocation.
+func methodCallCases(o *Order) []struct {
+	name string
+	op   func(price float64)
+} {
+	hoisted := o.update
+	return []struct {
+		name string
+		op   func(price float64)
+	}{
+		{name: "direct", op: func(p float64) { o.update(p) }},
+		{name: "method-value/hoisted", op: func(p float64) { hoisted(p) }},
+		{name: "method-value/passed", op: func(p float64) { callUpdate(o.update, p) }},
+		{name: "method-value/stored", op: func(p float64) {
+			sinkUpdate = o.update
+			sinkUpdate(p)
+		}},
+		{name: "func-literal/passed", op: func(p float64) { callUpdate(func(p float64) { o.update(p) }, p) }},
+		{name: "func-literal/stored", op: func(p float64) {
+			sinkUpdate = func(p float64) { o.update(p) }
+			sinkUpdate(p)
+		}},
+	}
+}
+
+// goroutineCases start one goroutine running (*Order).process and wait for it.
+func goroutineCases(o *Order, wg *sync.WaitGroup) []struct {
+	name string
+	op   func()
+} {
+	hoisted := o.process
+	return []struct {
+		name string
+		op   func()
+	}{
+		{name: "go-method", op: func() {
+			wg.Add(1)
+			go o.process(wg)
+			wg.Wait()
+		}},
+		{name: "go-method-value/hoisted", op: func() {
+			wg.Add(1)
+			go hoisted(wg)
+			wg.Wait()
+		}},
+		{name: "go-func-literal", op: func() {
+			wg.Add(1)
+			go func() { o.process(wg) }()
+			wg.Wait()
+		}},
+	}
+}
+
+// Allocation counts per op with the current compiler. A change means escape analysis or
+// the lowering of go statements moved; update the table and the docs together.
+var methodCallAllocs = map[string]float64{
+	"direct":                  0,
+	"method-value/hoisted":    0,
+	"method-value/passed":     0,
+	"method-value/stored":     1,
+	"func-literal/passed":     0,
+	"func-literal/stored":     1,
+	"go-method":               1,
+	"go-method-value/hoisted": 1,
+	"go-func-literal":         1,
+}
+
+func TestMethodValues_Allocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector changes allocation counts")
+	}
+
+	var o Order
+	for _, c := range methodCallCases(&o) {
+		got := testing.AllocsPerRun(100, func() { c.op(1) })
+		if want := methodCallAllocs[c.name]; got != want {
+			t.Errorf("%s: %v allocs per call, want %v", c.name, got, want)
+		}
+	}
+	var wg sync.WaitGroup
+	for _, c := range goroutineCases(&o, &wg) {
+		got := testing.AllocsPerRun(100, c.op)
+		if want := methodCallAllocs[c.name]; got != want {
+			t.Errorf("%s: %v allocs per spawn, want %v", c.name, got, want)
+		}
+	}
+}
+
+func TestMethodValues_Call(t *testing.T) {
+	var o Order
+	for _, c := range methodCallCases(&o) {
+		o.Price = 0
+		c.op(42)
+		if o.Price != 42 {
+			t.Errorf("%s: Price = %v after the call, want 42", c.name, o.Price)
+		}
+	}
+	var wg sync.WaitGroup
+	for _, c := range goroutineCases(&o, &wg) {
+		before := o.Qty
+		c.op()
+		if o.Qty != before+1 {
+			t.Errorf("%s: Qty = %d after the goroutine, want %d", c.name, o.Qty, before+1)
+		}
+	}
+}
+
+// BenchmarkMethodValue calls a method on *Order directly, through a method value made once
+// or per call, and through a wrapping func literal, with the callback passed down the
+// stack or stored, then spawns a goroutine running the method each of three ways.
+func BenchmarkMethodValue(b *testing.B) {
+	var o Order
+	for _, c := range methodCallCases(&o) {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				c.op(float64(i))
+			}
+		})
+	}
+	var wg sync.WaitGroup
+	for _, c := range goroutineCases(&o, &wg) {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				c.op()
+			}
+		})
+	}
+	sinkUpdate = nil
+}
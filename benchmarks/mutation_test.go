@@ -1,12 +1,7 @@
-package main
+package benchmarks
 
 import "testing"
 
-type Order struct {
-	Price float64
-	Qty   int64
-}
-
 // UpdateInside modifies the object in-place.
 func UpdateInside(o *Order, price float64) {
 	o.Price = price
@@ -14,16 +9,58 @@ func UpdateInside(o *Order, price float64) {
 
 // UpdateInsideReturn modifies the object in-place and returns it (fluent API style).
 func UpdateInsideReturn(o *Order, price float64) *Order {
+	o.Price = price
+	return o
+}
+
+// UpdateCopyReturn constructs a new object carrying the updated price (copy-and-return style).
+//
+//go:noinline
+func UpdateCopyReturn(o *Order, price float64) *Order {
 	return &Order{
 		Price: price,
+		Qty:   o.Qty,
 	}
 }
 
 // Avoid compiler optimization
 var sink *Order
 
-// BenchmarkReturnOnly measures the cost of a simple in-place update.
-func BenchmarkReturnOnly(b *testing.B) {
+// TestUpdateInsideReturn ensures the fluent variant mutates and returns the same object.
+func TestUpdateInsideReturn(t *testing.T) {
+	o := &Order{Price: 100, Qty: 42}
+
+	got := UpdateInsideReturn(o, 200)
+	if got != o {
+		t.Fatalf("UpdateInsideReturn returned %p, want the receiver %p", got, o)
+	}
+	if got.Price != 200 {
+		t.Errorf("Price = %v, want 200", got.Price)
+	}
+	if got.Qty != 42 {
+		t.Errorf("Qty = %d, want 42 (field dropped)", got.Qty)
+	}
+}
+
+// TestUpdateCopyReturn ensures the copy variant preserves Qty and leaves the input untouched.
+func TestUpdateCopyReturn(t *testing.T) {
+	o := &Order{Price: 100, Qty: 42}
+
+	got := UpdateCopyReturn(o, 200)
+	if got == o {
+		t.Fatal("UpdateCopyReturn returned the receiver, want a new object")
+	}
+	if got.Price != 200 || got.Qty != 42 {
+		t.Errorf("got %+v, want {Price:200 Qty:42}", *got)
+	}
+	if o.Price != 100 {
+		t.Errorf("input Price = %v, want 100 (input mutated)", o.Price)
+	}
+}
+
+// BenchmarkUpdateInside measures the cost of a simple in-place update.
+func BenchmarkUpdateInside(b *testing.B) {
+	b.ReportAllocs()
 	o := &Order{
 		Price: 100,
 		Qty:   100,
@@ -34,8 +71,9 @@ func BenchmarkReturnOnly(b *testing.B) {
 	}
 }
 
-// BenchmarkReturnModitfy measures the cost of update with a return value (fluent style).
-func BenchmarkReturnModitfy(b *testing.B) {
+// BenchmarkUpdateInsideReturn measures the cost of an in-place update that returns the receiver (fluent style).
+func BenchmarkUpdateInsideReturn(b *testing.B) {
+	b.ReportAllocs()
 	o := &Order{
 		Price: 100,
 		Qty:   100,
@@ -45,3 +83,16 @@ func BenchmarkReturnModitfy(b *testing.B) {
 		sink = UpdateInsideReturn(o, float64(i))
 	}
 }
+
+// BenchmarkUpdateCopyReturn measures the cost of constructing and returning a new object per update.
+func BenchmarkUpdateCopyReturn(b *testing.B) {
+	b.ReportAllocs()
+	o := &Order{
+		Price: 100,
+		Qty:   100,
+	}
+	b.ResetTimer()
+	for i := range b.N {
+		sink = UpdateCopyReturn(o, float64(i))
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !race
+
+package benchmarks
+
+// raceEnabled reports whether the race detector is on. The race runtime makes
+// sync.Pool drop objects at random, so steady-state allocation counts differ.
+const raceEnabled = false
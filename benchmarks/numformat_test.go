@@ -0,0 +1,76 @@
+package benchmarks
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestAppendOrder(t *testing.T) {
+	tests := []struct {
+		o    Order
+		want string
+	}{
+		{o: Order{}, want: "price=0.00 qty=0"},
+		{o: Order{Price: 99.5, Qty: 10}, want: "price=99.50 qty=10"},
+		{o: Order{Price: 1.005, Qty: -3}, want: "price=1.00 qty=-3"},
+		{o: Order{Price: 1234567.891, Qty: 1 << 40}, want: "price=1234567.89 qty=1099511627776"},
+	}
+
+	for _, tt := range tests {
+		if got := string(AppendOrder(nil, &tt.o)); got != tt.want {
+			t.Errorf("AppendOrder(%+v) = %q, want %q", tt.o, got, tt.want)
+		}
+		if got, want := string(AppendOrder(nil, &tt.o)), fmt.Sprintf("price=%.2f qty=%d", tt.o.Price, tt.o.Qty); got != want {
+			t.Errorf("AppendOrder(%+v) = %q, fmt.Sprintf gives %q", tt.o, got, want)
+		}
+	}
+}
+
+func TestAppendOrder_AppendsToPrefix(t *testing.T) {
+	got := AppendOrder([]byte("order: "), &Order{Price: 2, Qty: 3})
+	if want := "order: price=2.00 qty=3"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAllocs_AppendOrder(t *testing.T) {
+	o := Order{Price: 1234567.891, Qty: 1 << 40}
+	buf := make([]byte, 0, 64)
+
+	assertAllocs(t, "AppendOrder with spare capacity", 0, func() {
+		buf = AppendOrder(buf[:0], &o)
+	})
+}
+
+// BenchmarkNumericFormat compares formatting a single Order with fmt.Sprintf,
+// strconv.Format*+concatenation, and strconv.Append* into a pooled buffer.
+func BenchmarkNumericFormat(b *testing.B) {
+	o := jsonSampleOrder
+
+	b.Run("Sprintf", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkString = fmt.Sprintf("price=%.2f qty=%d", o.Price, o.Qty)
+		}
+	})
+
+	b.Run("FormatConcat", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkString = "price=" + strconv.FormatFloat(o.Price, 'f', 2, 64) + " qty=" + strconv.FormatInt(o.Qty, 10)
+		}
+	})
+
+	b.Run("AppendPooled", func(b *testing.B) {
+		p := newScratchPool(b)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			buf := p.Get()
+			*buf = AppendOrder(*buf, &o)
+			sinkLen = len(*buf)
+			p.Put(buf)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package benchmarks
+
+import (
+	"errors"
+	"testing"
+
+	"golang-backend/pkg/optional"
+)
+
+var errOrderMissing = errors.New("order not found")
+
+// optionalStore holds orders by ID: IDs 0 to len-1 exist and anything else is a miss.
+type optionalStore []Order
+
+// The lookups below make the same bounds check and differ only in how they report it.
+// Each is called through a second noinline function, as a value passes through a
+// repository and a service layer before reaching a handler.
+
+//go:noinline
+func (s optionalStore) findOK(id int64) (Order, bool) {
+	if id < 0 || id >= int64(len(s)) {
+		return Order{}, false
+	}
+	return s[id], true
+}
+
+//go:noinline
+func (s optionalStore) findPtr(id int64) *Order {
+	if id < 0 || id >= int64(len(s)) {
+		return nil
+	}
+	return &s[id]
+}
+
+//go:noinline
+func (s optionalStore) findErr(id int64) (Order, error) {
+	if id < 0 || id >= int64(len(s)) {
+		return Order{}, errOrderMissing
+	}
+	return s[id], nil
+}
+
+//go:noinline
+func (s optionalStore) findOption(id int64) optional.Option[Order] {
+	if id < 0 || id >= int64(len(s)) {
+		return optional.None[Order]()
+	}
+	return optional.Some(s[id])
+}
+
+//go:noinline
+func (s optionalStore) findResult(id int64) optional.Result[Order] {
+	if id < 0 || id >= int64(len(s)) {
+		return optional.Err[Order](errOrderMissing)
+	}
+	return optional.Ok(s[id])
+}
+
+//go:noinline
+func priceOK(s optionalStore, id int64) float64 {
+	o, ok := s.findOK(id)
+	if !ok {
+		return -1
+	}
+	return o.Price
+}
+
+//go:noinline
+func pricePtr(s optionalStore, id int64) float64 {
+	o := s.findPtr(id)
+	if o == nil {
+		return -1
+	}
+	return o.Price
+}
+
+//go:noinline
+func priceErr(s optionalStore, id int64) float64 {
+	o, err := s.findErr(id)
+	if err != nil {
+		return -1
+	}
+	return o.Price
+}
+
+//go:noinline
+func priceOption(s optionalStore, id int64) float64 {
+	return s.findOption(id).UnwrapOr(Order{Price: -1}).Price
+}
+
+//go:noinline
+func priceResult(s optionalStore, id int64) float64 {
+	return s.findResult(id).UnwrapOr(Order{Price: -1}).Price
+}
+
+var optionalLookups = []struct {
+	name  string
+	price func(s optionalStore, id int64) float64
+}{
+	{name: "value-bool", price: priceOK},
+	{name: "pointer-nil", price: pricePtr},
+	{name: "value-error", price: priceErr},
+	{name: "Option", price: priceOption},
+	{name: "Result", price: priceResult},
+}
+
+func TestOptionalLookups_Agree(t *testing.T) {
+	s := optionalStore(sampleOrders(100))
+	for _, id := range []int64{-1, 0, 1, 50, 99, 100, 1000} {
+		want := -1.0
+		if id >= 0 && id < int64(len(s)) {
+			want = s[id].Price
+		}
+		for _, l := range optionalLookups {
+			if got := l.price(s, id); got != want {
+				t.Errorf("%s(%d) = %v, want %v", l.name, id, got, want)
+			}
+		}
+	}
+}
+
+func TestOptionalLookups_NoAllocs(t *testing.T) {
+	s := optionalStore(sampleOrders(100))
+	for _, l := range optionalLookups {
+		for _, id := range []int64{10, 1000} {
+			if allocs := testing.AllocsPerRun(100, func() { sinkTotal = l.price(s, id) }); allocs != 0 {
+				t.Errorf("%s(%d) allocated %v times, want 0", l.name, id, allocs)
+			}
+		}
+	}
+}
+
+// BenchmarkOptionalLookup looks up orders through two noinline calls returning (Order,
+// bool), *Order, (Order, error), optional.Option[Order], or optional.Result[Order], for
+// hits and misses. The generic wrappers are structs returned by value, so none of them
+// allocate, and they cost about the same as the conventional shapes.
+func BenchmarkOptionalLookup(b *testing.B) {
+	s := optionalStore(sampleOrders(1024))
+	for _, hit := range []bool{true, false} {
+		name, base := "hit", int64(0)
+		if !hit {
+			name, base = "miss", int64(len(s))
+		}
+		for _, l := range optionalLookups {
+			b.Run(name+"/"+l.name, func(b *testing.B) {
+				b.ReportAllocs()
+				var total float64
+				for i := range b.N {
+					total += l.price(s, base+int64(i&1023))
+				}
+				sinkTotal = total
+			})
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package benchmarks
+
+// Order is the small domain object shared by the benchmarks.
+type Order struct {
+	Price float64 `json:"price"`
+	Qty   int64   `json:"qty"`
+}
+
+// TotalByValue returns Price*Qty using a value receiver, copying the 16-byte Order per call.
+//
+//go:noinline
+func (o Order) TotalByValue() float64 {
+	return o.Price * float64(o.Qty)
+}
+
+// TotalByPointer returns Price*Qty using a pointer receiver.
+//
+//go:noinline
+func (o *Order) TotalByPointer() float64 {
+	return o.Price * float64(o.Qty)
+}
+
+// Value returns the order's notional, Price*Qty, so *Order implements agg.Valuer.
+func (o *Order) Value() float64 {
+	return o.Price * float64(o.Qty)
+}
+
+// Clone returns a copy of o. Order holds no references, so a plain copy is already deep.
+func (o *Order) Clone() *Order {
+	c := *o
+	return &c
+}
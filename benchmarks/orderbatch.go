@@ -0,0 +1,43 @@
+package benchmarks
+
+import "slices"
+
+// Trader owns an OrderBatch.
+type Trader struct {
+	Name  string
+	Desks []string
+}
+
+// OrderBatch is a nested structure with pointers, slices, and a map, the kind of value a
+// defensive copy has to walk rather than copy flat.
+type OrderBatch struct {
+	ID     int64
+	Owner  *Trader // may be nil
+	Orders []*Order
+	Tags   map[string][]string
+}
+
+// Clone returns a deep copy of b: nothing reachable from the copy is shared with b. Nil
+// pointers, slices, and maps stay nil. Two Orders entries pointing at the same Order
+// become two separate copies.
+func (b *OrderBatch) Clone() *OrderBatch {
+	c := &OrderBatch{ID: b.ID}
+	if b.Owner != nil {
+		c.Owner = &Trader{Name: b.Owner.Name, Desks: slices.Clone(b.Owner.Desks)}
+	}
+	if b.Orders != nil {
+		c.Orders = make([]*Order, len(b.Orders))
+		for i, o := range b.Orders {
+			if o != nil {
+				c.Orders[i] = o.Clone()
+			}
+		}
+	}
+	if b.Tags != nil {
+		c.Tags = make(map[string][]string, len(b.Tags))
+		for k, v := range b.Tags {
+			c.Tags[k] = slices.Clone(v)
+		}
+	}
+	return c
+}
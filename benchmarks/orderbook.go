@@ -0,0 +1,105 @@
+package benchmarks
+
+// OrderBookAoS stores the book as an array of structs: each Price sits next to its Qty.
+type OrderBookAoS struct {
+	orders []Order
+}
+
+// NewOrderBookAoS returns an OrderBookAoS holding a copy of orders.
+func NewOrderBookAoS(orders []Order) *OrderBookAoS {
+	return &OrderBookAoS{orders: append([]Order(nil), orders...)}
+}
+
+// Len returns the number of orders in the book.
+func (b *OrderBookAoS) Len() int { return len(b.orders) }
+
+// At returns the i-th order.
+func (b *OrderBookAoS) At(i int) Order { return b.orders[i] }
+
+// SumNotional returns the sum of Price*Qty across the book.
+func (b *OrderBookAoS) SumNotional() float64 {
+	var total float64
+	for i := range b.orders {
+		total += b.orders[i].Price * float64(b.orders[i].Qty)
+	}
+	return total
+}
+
+// MaxPrice returns the highest price in the book, or false if the book is empty.
+// Only Price is read, but every cache line loaded also carries the Qty beside it.
+func (b *OrderBookAoS) MaxPrice() (float64, bool) {
+	if len(b.orders) == 0 {
+		return 0, false
+	}
+	best := b.orders[0].Price
+	for i := 1; i < len(b.orders); i++ {
+		if p := b.orders[i].Price; p > best {
+			best = p
+		}
+	}
+	return best, true
+}
+
+// ScalePrices multiplies every price by factor.
+func (b *OrderBookAoS) ScalePrices(factor float64) {
+	for i := range b.orders {
+		b.orders[i].Price *= factor
+	}
+}
+
+// OrderBookSoA stores the book as a struct of arrays: all prices contiguous, all
+// quantities contiguous, so a price-only scan touches half the memory of OrderBookAoS.
+type OrderBookSoA struct {
+	prices []float64
+	qtys   []int64
+}
+
+// NewOrderBookSoA returns an OrderBookSoA holding the contents of orders.
+func NewOrderBookSoA(orders []Order) *OrderBookSoA {
+	b := &OrderBookSoA{
+		prices: make([]float64, len(orders)),
+		qtys:   make([]int64, len(orders)),
+	}
+	for i := range orders {
+		b.prices[i] = orders[i].Price
+		b.qtys[i] = orders[i].Qty
+	}
+	return b
+}
+
+// Len returns the number of orders in the book.
+func (b *OrderBookSoA) Len() int { return len(b.prices) }
+
+// At returns the i-th order.
+func (b *OrderBookSoA) At(i int) Order { return Order{Price: b.prices[i], Qty: b.qtys[i]} }
+
+// SumNotional returns the sum of Price*Qty across the book.
+func (b *OrderBookSoA) SumNotional() float64 {
+	var total float64
+	qtys := b.qtys[:len(b.prices)] // one bounds check up front instead of one per element
+	for i, p := range b.prices {
+		total += p * float64(qtys[i])
+	}
+	return total
+}
+
+// MaxPrice returns the highest price in the book, or false if the book is empty.
+func (b *OrderBookSoA) MaxPrice() (float64, bool) {
+	if len(b.prices) == 0 {
+		return 0, false
+	}
+	best := b.prices[0]
+	for _, p := range b.prices[1:] {
+		if p > best {
+			best = p
+		}
+	}
+	return best, true
+}
+
+// ScalePrices multiplies every price by factor.
+func (b *OrderBookSoA) ScalePrices(factor float64) {
+	for i := range b.prices {
+		b.prices[i] *= factor
+	}
+}
@@ -0,0 +1,106 @@
+package benchmarks
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// orderBookSize is large enough that the book does not fit in cache.
+const orderBookSize = 1_000_000
+
+// orderBookInput returns n orders with random prices and quantities.
+func orderBookInput(n int) []Order {
+	r := rand.New(rand.NewPCG(7, 7))
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{Price: r.Float64() * 1000, Qty: r.Int64N(500)}
+	}
+	return orders
+}
+
+func TestOrderBook_SameResults(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 10_000} {
+		input := orderBookInput(n)
+		aos, soa := NewOrderBookAoS(input), NewOrderBookSoA(input)
+
+		if aos.Len() != n || soa.Len() != n {
+			t.Fatalf("n=%d: Len aos=%d soa=%d", n, aos.Len(), soa.Len())
+		}
+		if a, s := aos.SumNotional(), soa.SumNotional(); a != s {
+			t.Errorf("n=%d: SumNotional aos=%v soa=%v", n, a, s)
+		}
+		aMax, aOK := aos.MaxPrice()
+		sMax, sOK := soa.MaxPrice()
+		if aMax != sMax || aOK != sOK || aOK != (n > 0) {
+			t.Errorf("n=%d: MaxPrice aos=(%v, %v) soa=(%v, %v)", n, aMax, aOK, sMax, sOK)
+		}
+
+		aos.ScalePrices(1.5)
+		soa.ScalePrices(1.5)
+		for i := range n {
+			if aos.At(i) != soa.At(i) {
+				t.Fatalf("n=%d: after ScalePrices order %d aos=%+v soa=%+v", n, i, aos.At(i), soa.At(i))
+			}
+			if want := (Order{Price: input[i].Price * 1.5, Qty: input[i].Qty}); aos.At(i) != want {
+				t.Fatalf("n=%d: after ScalePrices order %d = %+v, want %+v", n, i, aos.At(i), want)
+			}
+		}
+	}
+}
+
+func TestOrderBook_CopiesInput(t *testing.T) {
+	input := orderBookInput(3)
+	aos, soa := NewOrderBookAoS(input), NewOrderBookSoA(input)
+	input[0].Price = -1
+
+	if aos.At(0).Price == -1 || soa.At(0).Price == -1 {
+		t.Error("book shares memory with the input slice")
+	}
+}
+
+// BenchmarkOrderBook compares an array-of-structs and a struct-of-arrays book on a 1M-order
+// scan of both fields, a Price-only scan, and an in-place Price-only update.
+func BenchmarkOrderBook(b *testing.B) {
+	input := orderBookInput(orderBookSize)
+	aos, soa := NewOrderBookAoS(input), NewOrderBookSoA(input)
+
+	b.Run("SumNotional/aos", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkNotional = aos.SumNotional()
+		}
+	})
+	b.Run("SumNotional/soa", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkNotional = soa.SumNotional()
+		}
+	})
+
+	b.Run("MaxPrice/aos", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkNotional, _ = aos.MaxPrice()
+		}
+	})
+	b.Run("MaxPrice/soa", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkNotional, _ = soa.MaxPrice()
+		}
+	})
+
+	// Alternating 2 and 0.5 is exact in binary floating point, so prices never drift.
+	b.Run("ScalePrices/aos", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := range b.N {
+			aos.ScalePrices(float64(1+i%2) / float64(2-i%2))
+		}
+	})
+	b.Run("ScalePrices/soa", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := range b.N {
+			soa.ScalePrices(float64(1+i%2) / float64(2-i%2))
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package benchmarks
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang-backend/pkg/unsafeconv"
+)
+
+// orderLine is one parsed "id,price,qty" line.
+type orderLine struct {
+	ID    int64
+	Order Order
+}
+
+// parseOrderFields parses the three fields of a line. All parsers share it, so they differ
+// only in how they split the input; strconv rejects empty fields and surrounding spaces.
+func parseOrderFields(id, price, qty string) (orderLine, bool) {
+	i, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return orderLine{}, false
+	}
+	p, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return orderLine{}, false
+	}
+	q, err := strconv.ParseInt(qty, 10, 64)
+	if err != nil {
+		return orderLine{}, false
+	}
+	return orderLine{ID: i, Order: Order{Price: p, Qty: q}}, true
+}
+
+// The parsers below read newline-separated "id,price,qty" lines, skip blank lines (so any
+// number of trailing newlines is accepted), and append to dst. They stop at the first
+// malformed line, reporting false.
+
+// parseOrdersSplit converts data to a string and splits it into lines, then each line into
+// fields, allocating a []string per line.
+func parseOrdersSplit(data []byte, dst []orderLine) ([]orderLine, bool) {
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) != 3 {
+			return dst, false
+		}
+		ol, ok := parseOrderFields(fields[0], fields[1], fields[2])
+		if !ok {
+			return dst, false
+		}
+		dst = append(dst, ol)
+	}
+	return dst, true
+}
+
+// parseOrdersCut converts data to a string once and walks it with strings.Cut; the fields
+// are substrings of that copy, so nothing else is allocated.
+func parseOrdersCut(data []byte, dst []orderLine) ([]orderLine, bool) {
+	rest := string(data)
+	for rest != "" {
+		var line string
+		line, rest, _ = strings.Cut(rest, "\n")
+		if line == "" {
+			continue
+		}
+		id, tail, ok1 := strings.Cut(line, ",")
+		price, qty, ok2 := strings.Cut(tail, ",")
+		if !ok1 || !ok2 || strings.IndexByte(qty, ',') >= 0 {
+			return dst, false
+		}
+		ol, ok := parseOrderFields(id, price, qty)
+		if !ok {
+			return dst, false
+		}
+		dst = append(dst, ol)
+	}
+	return dst, true
+}
+
+// parseOrdersBytes scans data with bytes.IndexByte and hands strconv sub-slices viewed as
+// strings through unsafeconv.B2S, never copying the input. strconv does not retain its
+// argument (its errors clone it), which is what makes the view safe here.
+func parseOrdersBytes(data []byte, dst []orderLine) ([]orderLine, bool) {
+	for len(data) > 0 {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			data = nil
+		}
+		if len(line) == 0 {
+			continue
+		}
+
+		c1 := bytes.IndexByte(line, ',')
+		if c1 < 0 {
+			return dst, false
+		}
+		c2 := bytes.IndexByte(line[c1+1:], ',')
+		if c2 < 0 {
+			return dst, false
+		}
+		c2 += c1 + 1
+		if bytes.IndexByte(line[c2+1:], ',') >= 0 {
+			return dst, false
+		}
+
+		ol, ok := parseOrderFields(
+			unsafeconv.B2S(line[:c1]), unsafeconv.B2S(line[c1+1:c2]), unsafeconv.B2S(line[c2+1:]))
+		if !ok {
+			return dst, false
+		}
+		dst = append(dst, ol)
+	}
+	return dst, true
+}
+
+var orderLineParsers = []struct {
+	name  string
+	parse func(data []byte, dst []orderLine) ([]orderLine, bool)
+}{
+	{name: "split", parse: parseOrdersSplit},
+	{name: "cut", parse: parseOrdersCut},
+	{name: "bytes", parse: parseOrdersBytes},
+}
+
+func TestParseOrderLines(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []orderLine
+		ok   bool
+	}{
+		{name: "single line", in: "12345,99.5,10", ok: true,
+			want: []orderLine{{ID: 12345, Order: Order{Price: 99.5, Qty: 10}}}},
+		{name: "trailing newline", in: "1,2.5,3\n", ok: true,
+			want: []orderLine{{ID: 1, Order: Order{Price: 2.5, Qty: 3}}}},
+		{name: "several trailing newlines", in: "1,2.5,3\n\n\n", ok: true,
+			want: []orderLine{{ID: 1, Order: Order{Price: 2.5, Qty: 3}}}},
+		{name: "multiple lines with blank between", in: "1,2.5,3\n\n4,-0.25,-6\n", ok: true,
+			want: []orderLine{{ID: 1, Order: Order{Price: 2.5, Qty: 3}}, {ID: 4, Order: Order{Price: -0.25, Qty: -6}}}},
+		{name: "exponent price", in: "7,1e3,1", ok: true,
+			want: []orderLine{{ID: 7, Order: Order{Price: 1000, Qty: 1}}}},
+		{name: "empty input", in: "", ok: true},
+		{name: "only newlines", in: "\n\n", ok: true},
+		{name: "empty id", in: ",99.5,10"},
+		{name: "empty price", in: "1,,10"},
+		{name: "empty qty", in: "1,99.5,"},
+		{name: "all fields empty", in: ",,"},
+		{name: "too few fields", in: "1,99.5"},
+		{name: "one field", in: "12345"},
+		{name: "too many fields", in: "1,99.5,10,4"},
+		{name: "trailing comma", in: "1,99.5,10,"},
+		{name: "non-numeric id", in: "abc,99.5,10"},
+		{name: "non-numeric price", in: "1,cheap,10"},
+		{name: "fractional qty", in: "1,99.5,10.5"},
+		{name: "space around field", in: "1, 99.5,10"},
+		{name: "CRLF line ending", in: "1,99.5,10\r\n"},
+		{name: "id overflow", in: "9223372036854775808,1,1"},
+		{name: "bad line after good", in: "1,2,3\nbad\n"},
+	}
+
+	for _, p := range orderLineParsers {
+		for _, tt := range tests {
+			got, ok := p.parse([]byte(tt.in), nil)
+			if ok != tt.ok {
+				t.Errorf("%s(%q) ok = %v, want %v", p.name, tt.in, ok, tt.ok)
+				continue
+			}
+			if ok && !slices.Equal(got, tt.want) {
+				t.Errorf("%s(%q) = %v, want %v", p.name, tt.in, got, tt.want)
+			}
+		}
+	}
+}
+
+// orderLinesInput formats n orders as "id,price,qty" lines with a trailing newline.
+func orderLinesInput(n int) ([]byte, []orderLine) {
+	var buf []byte
+	want := make([]orderLine, n)
+	for i, o := range sampleOrders(n) {
+		id := int64(100000 + i)
+		want[i] = orderLine{ID: id, Order: o}
+		buf = strconv.AppendInt(buf, id, 10)
+		buf = append(buf, ',')
+		buf = strconv.AppendFloat(buf, o.Price, 'f', -1, 64)
+		buf = append(buf, ',')
+		buf = strconv.AppendInt(buf, o.Qty, 10)
+		buf = append(buf, '\n')
+	}
+	return buf, want
+}
+
+func TestParseOrderLines_RoundTrip(t *testing.T) {
+	data, want := orderLinesInput(1000)
+	for _, p := range orderLineParsers {
+		got, ok := p.parse(data, nil)
+		if !ok || !slices.Equal(got, want) {
+			t.Errorf("%s: round trip of %d lines failed (ok=%v, got %d lines)", p.name, len(want), ok, len(got))
+		}
+	}
+}
+
+// orderLineCount is the number of lines parsed per op.
+const orderLineCount = 10_000
+
+// BenchmarkParseOrderLines compares strings.Split, strings.Cut, and byte scanning over a
+// 10k-line input, reusing the destination slice so only the parsing allocates.
+func BenchmarkParseOrderLines(b *testing.B) {
+	data, _ := orderLinesInput(orderLineCount)
+	for _, p := range orderLineParsers {
+		b.Run(p.name, func(b *testing.B) {
+			dst := make([]orderLine, 0, orderLineCount)
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for range b.N {
+				var ok bool
+				dst, ok = p.parse(data, dst[:0])
+				if !ok {
+					b.Fatal("parse failed")
+				}
+			}
+			sinkLen = len(dst)
+		})
+	}
+}
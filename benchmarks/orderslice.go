@@ -0,0 +1,67 @@
+package benchmarks
+
+import "math/rand/v2"
+
+// BuildOrderPointers returns n orders as individually allocated pointers,
+// with the same contents as BuildOrders.
+func BuildOrderPointers(n int) []*Order {
+	orders := make([]*Order, n)
+	for i := range orders {
+		orders[i] = &Order{
+			Price: float64(i),
+			Qty:   int64(i),
+		}
+	}
+	return orders
+}
+
+// ShuffleOrderPointers returns a copy of orders in a deterministic random order.
+// Walking the result visits the pointees out of allocation order, which models
+// a long-lived heap where neighbouring pointers are not neighbouring objects.
+func ShuffleOrderPointers(orders []*Order, seed uint64) []*Order {
+	shuffled := make([]*Order, len(orders))
+	copy(shuffled, orders)
+
+	r := rand.New(rand.NewPCG(seed, seed))
+	r.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// SumNotional returns the sum of Price*Qty across a slice of values.
+func SumNotional(orders []Order) float64 {
+	var total float64
+	for i := range orders {
+		total += orders[i].Price * float64(orders[i].Qty)
+	}
+	return total
+}
+
+// SumNotionalPtr returns the sum of Price*Qty across a slice of pointers.
+func SumNotionalPtr(orders []*Order) float64 {
+	var total float64
+	for _, o := range orders {
+		total += o.Price * float64(o.Qty)
+	}
+	return total
+}
+
+// SumQty returns the total Qty of its arguments. Each call site builds a slice for the
+// variadic arguments; because orders does not escape, that slice can live on the stack.
+//
+//go:noinline
+func SumQty(orders ...*Order) int64 {
+	return SumQtySlice(orders)
+}
+
+// SumQtySlice returns the total Qty of a pre-built slice.
+//
+//go:noinline
+func SumQtySlice(orders []*Order) int64 {
+	var total int64
+	for _, o := range orders {
+		total += o.Qty
+	}
+	return total
+}
@@ -0,0 +1,113 @@
+package benchmarks
+
+import "testing"
+
+// orderSliceSize is the element count for the value vs pointer layout benchmarks.
+const orderSliceSize = 100_000
+
+var (
+	sinkOrderPtrs []*Order
+	sinkNotional  float64
+)
+
+func TestBuildOrderPointers(t *testing.T) {
+	values := BuildOrders(100, true)
+	ptrs := BuildOrderPointers(100)
+
+	if len(ptrs) != len(values) {
+		t.Fatalf("len = %d, want %d", len(ptrs), len(values))
+	}
+	for i := range values {
+		if *ptrs[i] != values[i] {
+			t.Fatalf("ptrs[%d] = %+v, want %+v", i, *ptrs[i], values[i])
+		}
+	}
+}
+
+func TestShuffleOrderPointers(t *testing.T) {
+	ptrs := BuildOrderPointers(100)
+	shuffled := ShuffleOrderPointers(ptrs, 1)
+
+	seen := make(map[*Order]bool, len(ptrs))
+	for _, o := range shuffled {
+		seen[o] = true
+	}
+	for i, o := range ptrs {
+		if !seen[o] {
+			t.Fatalf("ptrs[%d] missing from shuffled slice", i)
+		}
+	}
+
+	moved := 0
+	for i := range ptrs {
+		if ptrs[i] != shuffled[i] {
+			moved++
+		}
+	}
+	if moved == 0 {
+		t.Error("shuffled slice is in allocation order")
+	}
+}
+
+func TestSumNotional(t *testing.T) {
+	values := BuildOrders(100, true)
+	ptrs := BuildOrderPointers(100)
+
+	// sum of i*i for i in [0, 100)
+	const want = 328350
+	if got := SumNotional(values); got != want {
+		t.Errorf("SumNotional = %v, want %v", got, want)
+	}
+	if got := SumNotionalPtr(ptrs); got != want {
+		t.Errorf("SumNotionalPtr = %v, want %v", got, want)
+	}
+	if got := SumNotionalPtr(ShuffleOrderPointers(ptrs, 1)); got != want {
+		t.Errorf("SumNotionalPtr(shuffled) = %v, want %v", got, want)
+	}
+}
+
+// BenchmarkBuildOrderValues measures building []Order: a single allocation.
+func BenchmarkBuildOrderValues(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		sinkOrders = BuildOrders(orderSliceSize, true)
+	}
+}
+
+// BenchmarkBuildOrderPointers measures building []*Order: one allocation per element.
+func BenchmarkBuildOrderPointers(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		sinkOrderPtrs = BuildOrderPointers(orderSliceSize)
+	}
+}
+
+// BenchmarkSumOrderValues measures a full pass over contiguous values.
+func BenchmarkSumOrderValues(b *testing.B) {
+	orders := BuildOrders(orderSliceSize, true)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		sinkNotional = SumNotional(orders)
+	}
+}
+
+// BenchmarkSumOrderPointers measures a full pass over pointers visited in allocation order.
+func BenchmarkSumOrderPointers(b *testing.B) {
+	orders := BuildOrderPointers(orderSliceSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		sinkNotional = SumNotionalPtr(orders)
+	}
+}
+
+// BenchmarkSumOrderPointersShuffled measures a full pass over pointers visited in random order.
+func BenchmarkSumOrderPointersShuffled(b *testing.B) {
+	orders := ShuffleOrderPointers(BuildOrderPointers(orderSliceSize), 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		sinkNotional = SumNotionalPtr(orders)
+	}
+}
@@ -0,0 +1,46 @@
+package benchmarks
+
+import "sync/atomic"
+
+// cacheLineSize is the cache line size assumed for padding (64 bytes on amd64 and most arm64).
+const cacheLineSize = 64
+
+// paddedSlot occupies a full cache line so neighbouring slots never share one.
+type paddedSlot struct {
+	n atomic.Int64
+	_ [cacheLineSize - 8]byte
+}
+
+// PaddedCounter is a set of per-writer counters, each on its own cache line.
+// Writers that own distinct slots do not invalidate each other's cache lines
+// (false sharing), so increments scale with the number of cores.
+type PaddedCounter struct {
+	slots []paddedSlot
+}
+
+// NewPaddedCounter creates a PaddedCounter with n slots. Values below 1 create a single slot.
+func NewPaddedCounter(n int) *PaddedCounter {
+	return &PaddedCounter{
+		slots: make([]paddedSlot, max(n, 1)),
+	}
+}
+
+// Add adds delta to the given slot. Slot indexes wrap around the slot count.
+func (c *PaddedCounter) Add(slot int, delta int64) {
+	c.slots[uint(slot)%uint(len(c.slots))].n.Add(delta)
+}
+
+// Sum returns the total across all slots. It is safe to call concurrently with Add,
+// in which case the result reflects some interleaving of in-flight increments.
+func (c *PaddedCounter) Sum() int64 {
+	var total int64
+	for i := range c.slots {
+		total += c.slots[i].n.Load()
+	}
+	return total
+}
+
+// Len returns the number of slots.
+func (c *PaddedCounter) Len() int {
+	return len(c.slots)
+}
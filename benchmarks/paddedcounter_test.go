@@ -0,0 +1,92 @@
+package benchmarks
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+)
+
+// falseSharingWriters is the fixed goroutine count for the false sharing benchmarks.
+const falseSharingWriters = 8
+
+func TestPaddedCounter_SlotSize(t *testing.T) {
+	if got := unsafe.Sizeof(paddedSlot{}); got != cacheLineSize {
+		t.Errorf("unsafe.Sizeof(paddedSlot{}) = %d, want %d", got, cacheLineSize)
+	}
+}
+
+func TestPaddedCounter_Sum(t *testing.T) {
+	c := NewPaddedCounter(falseSharingWriters)
+
+	var wg sync.WaitGroup
+	for w := range falseSharingWriters {
+		wg.Go(func() {
+			for range 1000 {
+				c.Add(w, 1)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got, want := c.Sum(), int64(falseSharingWriters*1000); got != want {
+		t.Errorf("Sum = %d, want %d", got, want)
+	}
+}
+
+func TestPaddedCounter_SlotWraps(t *testing.T) {
+	c := NewPaddedCounter(2)
+
+	c.Add(5, 3)
+	c.Add(-1, 4)
+
+	if got := c.Sum(); got != 7 {
+		t.Errorf("Sum = %d, want 7", got)
+	}
+}
+
+func TestNewPaddedCounter_ClampsSlots(t *testing.T) {
+	if got := NewPaddedCounter(0).Len(); got != 1 {
+		t.Errorf("Len = %d, want 1", got)
+	}
+}
+
+// runFalseSharingWriters starts exactly falseSharingWriters goroutines, hands each its own
+// slot index, and splits b.N iterations between them. RunParallel only guarantees a lower
+// bound on the goroutine count, which would put extra writers on the same slots.
+func runFalseSharingWriters(b *testing.B, inc func(slot int)) {
+	b.ReportAllocs()
+
+	var wg sync.WaitGroup
+	for w := range falseSharingWriters {
+		iters := b.N / falseSharingWriters
+		if w < b.N%falseSharingWriters {
+			iters++
+		}
+		wg.Go(func() {
+			for range iters {
+				inc(w)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// BenchmarkFalseSharing has each goroutine increment its own element of a shared [8]int64,
+// so all writers fight over the same cache line.
+func BenchmarkFalseSharing(b *testing.B) {
+	var counters [falseSharingWriters]int64
+
+	runFalseSharingWriters(b, func(slot int) {
+		atomic.AddInt64(&counters[slot], 1)
+	})
+}
+
+// BenchmarkPaddedCounter has each goroutine increment its own cache-line padded slot.
+func BenchmarkPaddedCounter(b *testing.B) {
+	c := NewPaddedCounter(falseSharingWriters)
+
+	runFalseSharingWriters(b, func(slot int) {
+		c.Add(slot, 1)
+	})
+}
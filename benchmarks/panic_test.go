@@ -0,0 +1,134 @@
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+var (
+	errBadPrice = fmt.Errorf("%w: price must be positive", errInvalidOrder)
+	errBadQty   = fmt.Errorf("%w: qty must be positive", errInvalidOrder)
+)
+
+// checkOrder validates o by returning an error.
+//
+//go:noinline
+func checkOrder(o *Order) error {
+	if !(o.Price > 0) { // also rejects NaN
+		return errBadPrice
+	}
+	if o.Qty <= 0 {
+		return errBadQty
+	}
+	return nil
+}
+
+// mustCheckOrder validates o by panicking with the same errors checkOrder returns.
+//
+//go:noinline
+func mustCheckOrder(o *Order) {
+	if !(o.Price > 0) {
+		panic(errBadPrice)
+	}
+	if o.Qty <= 0 {
+		panic(errBadQty)
+	}
+}
+
+// handleWithError is the handler layer for checkOrder: it just propagates the error.
+func handleWithError(o *Order) error {
+	if err := checkOrder(o); err != nil {
+		return err
+	}
+	sinkQty += o.Qty
+	return nil
+}
+
+// handleWithRecover is the handler layer for mustCheckOrder: a deferred recover turns a
+// validation panic back into an error and re-panics anything else.
+func handleWithRecover(o *Order) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(error)
+			if !ok || !errors.Is(e, errInvalidOrder) {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+
+	mustCheckOrder(o)
+	sinkQty += o.Qty
+	return nil
+}
+
+func TestOrderValidation_SameVerdicts(t *testing.T) {
+	inputs := []Order{
+		{Price: 10, Qty: 1},
+		{Price: math.SmallestNonzeroFloat64, Qty: math.MaxInt64},
+		{Price: 0, Qty: 1},
+		{Price: -1, Qty: 1},
+		{Price: math.NaN(), Qty: 1},
+		{Price: math.Inf(1), Qty: 1},
+		{Price: 10, Qty: 0},
+		{Price: 10, Qty: -5},
+		{Price: 0, Qty: 0},
+	}
+
+	for _, o := range inputs {
+		viaErr, viaPanic := handleWithError(&o), handleWithRecover(&o)
+		if viaErr != viaPanic {
+			t.Errorf("%+v: error style = %v, panic style = %v", o, viaErr, viaPanic)
+		}
+		if viaErr != nil && !errors.Is(viaErr, errInvalidOrder) {
+			t.Errorf("%+v: error %v does not wrap errInvalidOrder", o, viaErr)
+		}
+	}
+}
+
+func TestHandleWithRecover_RepanicsOtherPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("nil-pointer panic was swallowed by the handler")
+		}
+	}()
+	handleWithRecover(nil)
+}
+
+// validationInputs returns 100 orders of which failPct are invalid, spread evenly.
+func validationInputs(failPct int) []Order {
+	orders := make([]Order, 100)
+	for i := range orders {
+		orders[i] = Order{Price: 10, Qty: 1}
+		if failPct > 0 && i%(100/failPct) == 0 {
+			orders[i].Qty = 0
+		}
+	}
+	return orders
+}
+
+// BenchmarkValidationFailure compares returning an error with panicking and recovering at
+// the handler, at 0%, 1%, and 50% invalid orders. Each op handles one order.
+func BenchmarkValidationFailure(b *testing.B) {
+	handlers := []struct {
+		name   string
+		handle func(*Order) error
+	}{
+		{name: "error", handle: handleWithError},
+		{name: "panic", handle: handleWithRecover},
+	}
+
+	for _, failPct := range []int{0, 1, 50} {
+		orders := validationInputs(failPct)
+		for _, h := range handlers {
+			b.Run(fmt.Sprintf("%s/fail=%d%%", h.name, failPct), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := range b.N {
+					sinkErr = h.handle(&orders[i%len(orders)])
+				}
+			})
+		}
+	}
+}
@@ -1,28 +1,51 @@
-package main
+package benchmarks
 
 import (
+	"fmt"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"testing"
-)
 
-// BigObject simulates a significant memory footprint (approx 8KB).
-type BigObject struct {
-	ID       int64
-	Name     string
-	Data     [1024]int64 // 1024 * 8 bytes = 8KB
-	IsActive bool
-}
+	"golang-backend/pkg/pool"
+)
 
 var globalSinkPool *BigObject
 
-var objPool = sync.Pool{
+// globalSinkParallel is the goroutine-safe sink used by the RunParallel benchmarks.
+var globalSinkParallel atomic.Pointer[BigObject]
+
+// objPool resets objects automatically on Put, so callers cannot forget to.
+var objPool = pool.New(pool.WithReset((*BigObject).Reset))
+
+// rawObjPool is the untyped equivalent of objPool, used to measure the wrapper overhead.
+var rawObjPool = sync.Pool{
 	New: func() any {
 		return new(BigObject)
 	},
 }
 
+// TestObjPool_NoStaleState ensures a previous user's state never leaks through Get.
+func TestObjPool_NoStaleState(t *testing.T) {
+	for i := range 100 {
+		obj := objPool.Get()
+		if obj.ID != 0 || obj.Name != "" || obj.IsActive || obj.Data != [1024]int64{} {
+			t.Fatalf("iteration %d: Get returned stale object {ID:%d Name:%q IsActive:%v}",
+				i, obj.ID, obj.Name, obj.IsActive)
+		}
+
+		obj.ID = int64(i + 1)
+		obj.Name = "previous user"
+		obj.Data[len(obj.Data)-1] = 1
+		obj.IsActive = true
+		objPool.Put(obj)
+	}
+}
+
 // BenchmarkStructWithoutPool measures the performance of frequent heap allocations.
 func BenchmarkStructWithoutPool(b *testing.B) {
+	b.ReportAllocs()
+	ReportGC(b)
 	for i := range b.N {
 		// New allocation every iteration, likely escaping to heap
 		obj := &BigObject{
@@ -32,19 +55,214 @@ func BenchmarkStructWithoutPool(b *testing.B) {
 	}
 }
 
-// BenchmarkStructWithPool measures the performance of reusing objects via sync.Pool.
+// BenchmarkStructWithPool measures the performance of reusing objects via the typed pool.
 func BenchmarkStructWithPool(b *testing.B) {
+	b.ReportAllocs()
+	ReportGC(b)
+	for i := range b.N {
+		// Acquire from pool, no type assertion required
+		obj := objPool.Get()
+
+		obj.ID = int64(i)
+		obj.IsActive = true
+
+		globalSinkPool = obj
+
+		// Release back to pool, reset happens automatically
+		objPool.Put(obj)
+	}
+}
+
+// BenchmarkStructWithRawSyncPool measures the same cycle against a bare sync.Pool.
+func BenchmarkStructWithRawSyncPool(b *testing.B) {
+	b.ReportAllocs()
 	for i := range b.N {
 		// Acquire from pool
-		obj := objPool.Get().(*BigObject)
+		obj := rawObjPool.Get().(*BigObject)
 
-		// Reset state (Critical step when using sync.Pool)
 		obj.ID = int64(i)
 		obj.IsActive = true
 
 		globalSinkPool = obj
 
+		// Reset state (Critical step when using sync.Pool)
+		obj.Reset()
+
 		// Release back to pool
-		objPool.Put(obj)
+		rawObjPool.Put(obj)
+	}
+}
+
+// parallelism lists the GOMAXPROCS values used by the contention benchmarks.
+var parallelism = []int{1, 2, 4, 8}
+
+// runWithProcs runs fn as a sub-benchmark for every entry in parallelism,
+// restoring the original GOMAXPROCS afterwards.
+func runWithProcs(b *testing.B, fn func(b *testing.B)) {
+	for _, procs := range parallelism {
+		b.Run(fmt.Sprintf("procs=%d", procs), func(b *testing.B) {
+			prev := runtime.GOMAXPROCS(procs)
+			defer runtime.GOMAXPROCS(prev)
+
+			b.ReportAllocs()
+			fn(b)
+		})
 	}
 }
+
+// BenchmarkStructWithoutPoolParallel measures heap allocation throughput when every goroutine allocates.
+func BenchmarkStructWithoutPoolParallel(b *testing.B) {
+	runWithProcs(b, func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			var i int64
+			for pb.Next() {
+				obj := &BigObject{
+					ID: i,
+				}
+				obj.IsActive = true
+				globalSinkParallel.Store(obj)
+				i++
+			}
+		})
+	})
+}
+
+// BenchmarkStructWithPoolParallel measures pool throughput when goroutines hit their per-P caches concurrently.
+func BenchmarkStructWithPoolParallel(b *testing.B) {
+	runWithProcs(b, func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			var i int64
+			for pb.Next() {
+				obj := objPool.Get()
+				obj.ID = i
+				obj.IsActive = true
+				globalSinkParallel.Store(obj)
+				objPool.Put(obj)
+				i++
+			}
+		})
+	})
+}
+
+// boundedPoolSize is the retention limit used by the bounded pool benchmarks.
+const boundedPoolSize = 64
+
+// newBoundedObjPool creates the channel-backed counterpart of objPool.
+func newBoundedObjPool(b *testing.B) *pool.Bounded[BigObject] {
+	b.Helper()
+
+	p, err := pool.NewBounded(boundedPoolSize, pool.WithReset((*BigObject).Reset))
+	if err != nil {
+		b.Fatalf("NewBounded: %v", err)
+	}
+	return p
+}
+
+// BenchmarkStructWithBoundedPool measures the Get/Put cycle against a channel-backed bounded pool.
+func BenchmarkStructWithBoundedPool(b *testing.B) {
+	p := newBoundedObjPool(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		obj := p.Get()
+
+		obj.ID = int64(i)
+		obj.IsActive = true
+
+		globalSinkPool = obj
+
+		p.Put(obj)
+	}
+}
+
+// BenchmarkStructWithBoundedPoolParallel measures the bounded pool when all goroutines share one channel.
+func BenchmarkStructWithBoundedPoolParallel(b *testing.B) {
+	runWithProcs(b, func(b *testing.B) {
+		p := newBoundedObjPool(b)
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			var i int64
+			for pb.Next() {
+				obj := p.Get()
+				obj.ID = i
+				obj.IsActive = true
+				globalSinkParallel.Store(obj)
+				p.Put(obj)
+				i++
+			}
+		})
+	})
+}
+
+// startupBurst is the number of concurrent in-flight objects in the first request burst after startup.
+const startupBurst = 100
+
+// benchmarkStartupBurst measures the first startupBurst Gets on a freshly created pool.
+// When warm is true the pool is pre-populated before the timer starts.
+func benchmarkStartupBurst(b *testing.B, warm bool) {
+	held := make([]*BigObject, startupBurst)
+	b.ReportAllocs()
+	for range b.N {
+		b.StopTimer()
+		p := pool.New(pool.WithReset((*BigObject).Reset))
+		if warm {
+			p.Warm(startupBurst)
+		}
+		b.StartTimer()
+
+		for i := range held {
+			held[i] = p.Get()
+		}
+
+		b.StopTimer()
+		for i := range held {
+			p.Put(held[i])
+		}
+		b.StartTimer()
+	}
+}
+
+// BenchmarkPoolColdStart measures a startup burst that pays every allocation.
+func BenchmarkPoolColdStart(b *testing.B) {
+	benchmarkStartupBurst(b, false)
+}
+
+// BenchmarkPoolWarmStart measures the same burst against a pool warmed with Warm.
+func BenchmarkPoolWarmStart(b *testing.B) {
+	benchmarkStartupBurst(b, true)
+}
+
+// meteredObjPool is objPool with hit/miss instrumentation.
+var meteredObjPool = pool.NewMetered(pool.WithReset((*BigObject).Reset))
+
+// BenchmarkStructWithMeteredPool measures the cost of counting every Get and Put.
+func BenchmarkStructWithMeteredPool(b *testing.B) {
+	b.ReportAllocs()
+	for i := range b.N {
+		obj := meteredObjPool.Get()
+
+		obj.ID = int64(i)
+		obj.IsActive = true
+
+		globalSinkPool = obj
+
+		meteredObjPool.Put(obj)
+	}
+}
+
+// BenchmarkStructWithMeteredPoolParallel measures the instrumentation overhead when counters are contended.
+func BenchmarkStructWithMeteredPoolParallel(b *testing.B) {
+	runWithProcs(b, func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			var i int64
+			for pb.Next() {
+				obj := meteredObjPool.Get()
+				obj.ID = i
+				obj.IsActive = true
+				globalSinkParallel.Store(obj)
+				meteredObjPool.Put(obj)
+				i++
+			}
+		})
+	})
+}
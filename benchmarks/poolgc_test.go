@@ -0,0 +1,120 @@
+package benchmarks
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"golang-backend/pkg/pool"
+)
+
+// newMeteredObjPool returns a metered BigObject pool like meteredObjPool, but private to
+// one test or benchmark so its counters start at zero.
+func newMeteredObjPool() *pool.Metered[BigObject] {
+	return pool.NewMetered(pool.WithReset((*BigObject).Reset))
+}
+
+// poolMissesAfterGC puts n distinct objects into an idle pool, runs gcs garbage
+// collections, and returns how many of n Gets then found the pool empty and allocated.
+//
+// Each GC moves a sync.Pool's contents to its victim cache and drops whatever was already
+// there, so an object survives one GC but not two.
+func poolMissesAfterGC(p *pool.Metered[BigObject], n, gcs int) int {
+	for range n {
+		p.Put(new(BigObject))
+	}
+	for range gcs {
+		runtime.GC()
+	}
+
+	before := p.Stats().News
+	objs := make([]*BigObject, n)
+	for i := range objs {
+		objs[i] = p.Get() // held until all n are taken, so none is handed out twice
+	}
+	return int(p.Stats().News - before)
+}
+
+// TestPool_VictimCacheSurvivesOneGC checks the sync.Pool lifetime that surprises people:
+// pooled objects are not kept indefinitely, but they are not dropped at the first GC
+// either.
+func TestPool_VictimCacheSurvivesOneGC(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops a random share of Puts under the race detector")
+	}
+	// Only the collections the test asks for may run.
+	defer debug.SetGCPercent(debug.SetGCPercent(-1))
+
+	const n = 100
+	tests := []struct {
+		gcs        int
+		wantMisses int
+	}{
+		{gcs: 0, wantMisses: 0},
+		{gcs: 1, wantMisses: 0}, // moved to the victim cache, still found by Get
+		{gcs: 2, wantMisses: n}, // the victim cache was dropped at the second GC
+		{gcs: 3, wantMisses: n},
+	}
+	for _, tt := range tests {
+		p := newMeteredObjPool()
+		if got := poolMissesAfterGC(p, n, tt.gcs); got != tt.wantMisses {
+			t.Errorf("after %d GCs: %d of %d Gets allocated, want %d", tt.gcs, got, n, tt.wantMisses)
+		}
+	}
+}
+
+// TestPool_VictimRefilledByUse shows why a busy pool rarely misses despite GC: an object
+// taken from the victim cache and put back goes into the primary cache again, so it
+// survives the next GC too.
+func TestPool_VictimRefilledByUse(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops a random share of Puts under the race detector")
+	}
+	defer debug.SetGCPercent(debug.SetGCPercent(-1))
+
+	p := newMeteredObjPool()
+	obj := new(BigObject)
+	p.Put(obj)
+	for gc := range 5 {
+		runtime.GC()
+		got := p.Get()
+		if got != obj {
+			t.Fatalf("after GC %d: pool handed out a new object", gc+1)
+		}
+		p.Put(got)
+	}
+	if st := p.Stats(); st.News != 0 {
+		t.Errorf("Stats = %+v, want no allocations while the object keeps being used", st)
+	}
+}
+
+// poolGCPercents are the GOGC settings BenchmarkPoolUnderGC runs at; 100 is the default.
+var poolGCPercents = []int{100, 10, 1}
+
+// BenchmarkPoolUnderGC runs the steady Get/Put cycle of BenchmarkStructWithMeteredPool
+// beside 128 bytes of garbage per op, at the default GOGC and with it forced low so
+// collections run more often. miss-% is the share of Gets that allocated. A pool used on
+// every op refills its primary cache after each GC, so misses stay near zero however
+// often collections run; what grows with GC frequency is the cost of the collections.
+func BenchmarkPoolUnderGC(b *testing.B) {
+	for _, gogc := range poolGCPercents {
+		b.Run(fmt.Sprintf("GOGC=%d", gogc), func(b *testing.B) {
+			defer debug.SetGCPercent(debug.SetGCPercent(gogc))
+			p := newMeteredObjPool()
+			b.ReportAllocs()
+			ReportGC(b)
+			for i := range b.N {
+				obj := p.Get()
+				obj.ID = int64(i)
+				obj.IsActive = true
+				globalSinkPool = obj
+				sinkBytes = make([]byte, 128)
+				p.Put(obj)
+			}
+
+			st := p.Stats()
+			b.ReportMetric(100*float64(st.News)/float64(max(st.Gets, 1)), "miss-%")
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package benchmarks
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// requestUpdates is the number of UpdateInside calls standing in for one request's work.
+const requestUpdates = 64
+
+// updateOrder runs the per-request hot loop.
+func updateOrder(o *Order) {
+	for i := range requestUpdates {
+		UpdateInside(o, float64(i))
+	}
+}
+
+// requestLabels builds the labels attached to one request: n key/value pairs, with the
+// order ID as the first value so it changes per request as it would in production.
+func requestLabels(n int, id int) pprof.LabelSet {
+	kv := []string{"order", strconv.Itoa(id), "route", "/orders", "method", "POST", "tenant", "acme", "region", "eu-1"}
+	return pprof.Labels(kv[:2*n]...)
+}
+
+// runLabeled runs updateOrder inside pprof.Do with n labels.
+func runLabeled(ctx context.Context, o *Order, n, id int) {
+	pprof.Do(ctx, requestLabels(n, id), func(context.Context) {
+		updateOrder(o)
+	})
+}
+
+// runSetLabels sets n labels on the goroutine directly, runs updateOrder, and restores
+// the previous labels, the manual equivalent of pprof.Do.
+func runSetLabels(ctx context.Context, o *Order, n, id int) {
+	pprof.SetGoroutineLabels(pprof.WithLabels(ctx, requestLabels(n, id)))
+	updateOrder(o)
+	pprof.SetGoroutineLabels(ctx)
+}
+
+// runSpawned runs updateOrder on a new goroutine and waits for it. A goroutine inherits
+// its creator's labels, so inside pprof.Do the child is attributed to the same labels.
+func runSpawned(o *Order) {
+	done := make(chan struct{})
+	go func() {
+		updateOrder(o)
+		close(done)
+	}()
+	<-done
+}
+
+func TestPprofLabels_VisibleInContext(t *testing.T) {
+	for _, n := range []int{1, 5} {
+		got := map[string]string{}
+		pprof.Do(context.Background(), requestLabels(n, 42), func(ctx context.Context) {
+			pprof.ForLabels(ctx, func(k, v string) bool {
+				got[k] = v
+				return true
+			})
+		})
+		if len(got) != n || got["order"] != "42" {
+			t.Errorf("%d labels: ForLabels saw %v", n, got)
+		}
+	}
+}
+
+func TestPprofLabels_InheritedBySpawnedGoroutine(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	pprof.Do(context.Background(), requestLabels(1, 777), func(context.Context) {
+		go func() {
+			close(started)
+			<-release
+		}()
+	})
+	<-started
+	defer close(release)
+
+	// The goroutine profile at debug=1 prints each stack's labels; the child must carry
+	// the label even though pprof.Do has already returned in its parent.
+	want := []byte(`"order":"777"`)
+	deadline := time.Now().Add(time.Second)
+	for {
+		var buf bytes.Buffer
+		if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(buf.Bytes(), want) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutine profile has no %s label:\n%s", want, buf.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// BenchmarkPprofLabels runs one request's UpdateInside loop bare, under pprof.Do with one
+// and five labels, with SetGoroutineLabels, and on a spawned goroutine with and without
+// inherited labels.
+func BenchmarkPprofLabels(b *testing.B) {
+	ctx := context.Background()
+	o := &Order{Price: 100, Qty: 100}
+
+	b.Run("bare", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			updateOrder(o)
+		}
+	})
+	for _, n := range []int{1, 5} {
+		b.Run("do/labels="+strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				runLabeled(ctx, o, n, i)
+			}
+		})
+		b.Run("set-goroutine-labels/labels="+strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				runSetLabels(ctx, o, n, i)
+			}
+		})
+	}
+	b.Run("spawn/bare", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			runSpawned(o)
+		}
+	})
+	b.Run("spawn/do/labels=5", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := range b.N {
+			pprof.Do(ctx, requestLabels(5, i), func(context.Context) {
+				runSpawned(o)
+			})
+		}
+	})
+}
@@ -0,0 +1,273 @@
+package benchmarks
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// orderQueue pops the highest-priced order first. Orders with equal prices pop in the
+// order they were pushed, so every implementation yields the same sequence.
+type orderQueue interface {
+	Push(o *Order)
+	Pop() (*Order, bool)
+	Len() int
+}
+
+// queuedOrder carries the push sequence number that breaks price ties.
+type queuedOrder struct {
+	o   *Order
+	seq uint64
+}
+
+// popsBefore reports whether a leaves the queue before b.
+func popsBefore(a, b queuedOrder) bool {
+	if a.o.Price != b.o.Price {
+		return a.o.Price > b.o.Price
+	}
+	return a.seq < b.seq
+}
+
+// orderHeap implements heap.Interface. Push and Pop take and return any, so every element
+// passed through them is boxed.
+type orderHeap []queuedOrder
+
+func (h orderHeap) Len() int           { return len(h) }
+func (h orderHeap) Less(i, j int) bool { return popsBefore(h[i], h[j]) }
+func (h orderHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *orderHeap) Push(x any)        { *h = append(*h, x.(queuedOrder)) }
+
+func (h *orderHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	old[len(old)-1] = queuedOrder{}
+	*h = old[:len(old)-1]
+	return x
+}
+
+// containerHeapQueue is an orderQueue on container/heap.
+type containerHeapQueue struct {
+	h   orderHeap
+	seq uint64
+}
+
+func (q *containerHeapQueue) Push(o *Order) {
+	q.seq++
+	heap.Push(&q.h, queuedOrder{o: o, seq: q.seq})
+}
+
+func (q *containerHeapQueue) Pop() (*Order, bool) {
+	if len(q.h) == 0 {
+		return nil, false
+	}
+	return heap.Pop(&q.h).(queuedOrder).o, true
+}
+
+func (q *containerHeapQueue) Len() int { return len(q.h) }
+
+// genericHeap is a binary heap over a slice of T ordered by less, with no interface
+// conversions on Push or Pop.
+type genericHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *genericHeap[T]) push(x T) {
+	h.items = append(h.items, x)
+	i := len(h.items) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			break
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+func (h *genericHeap[T]) pop() T {
+	top := h.items[0]
+	last := len(h.items) - 1
+	h.items[0] = h.items[last]
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+
+	i := 0
+	for {
+		smallest, l, r := i, 2*i+1, 2*i+2
+		if l < last && h.less(h.items[l], h.items[smallest]) {
+			smallest = l
+		}
+		if r < last && h.less(h.items[r], h.items[smallest]) {
+			smallest = r
+		}
+		if smallest == i {
+			return top
+		}
+		h.items[i], h.items[smallest] = h.items[smallest], h.items[i]
+		i = smallest
+	}
+}
+
+// genericHeapQueue is an orderQueue on genericHeap.
+type genericHeapQueue struct {
+	h   genericHeap[queuedOrder]
+	seq uint64
+}
+
+func newGenericHeapQueue() *genericHeapQueue {
+	return &genericHeapQueue{h: genericHeap[queuedOrder]{less: popsBefore}}
+}
+
+func (q *genericHeapQueue) Push(o *Order) {
+	q.seq++
+	q.h.push(queuedOrder{o: o, seq: q.seq})
+}
+
+func (q *genericHeapQueue) Pop() (*Order, bool) {
+	if len(q.h.items) == 0 {
+		return nil, false
+	}
+	return q.h.pop().o, true
+}
+
+func (q *genericHeapQueue) Len() int { return len(q.h.items) }
+
+// sortedSliceQueue keeps its items sorted with the next to pop at the end, so Pop is
+// O(1) and Push is a binary search plus an O(n) shift.
+type sortedSliceQueue struct {
+	items []queuedOrder
+	seq   uint64
+}
+
+func (q *sortedSliceQueue) Push(o *Order) {
+	q.seq++
+	x := queuedOrder{o: o, seq: q.seq}
+	i := sort.Search(len(q.items), func(i int) bool { return popsBefore(q.items[i], x) })
+	q.items = slices.Insert(q.items, i, x)
+}
+
+func (q *sortedSliceQueue) Pop() (*Order, bool) {
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	last := len(q.items) - 1
+	o := q.items[last].o
+	q.items[last] = queuedOrder{}
+	q.items = q.items[:last]
+	return o, true
+}
+
+func (q *sortedSliceQueue) Len() int { return len(q.items) }
+
+var orderQueues = []struct {
+	name string
+	new  func() orderQueue
+}{
+	{name: "container-heap", new: func() orderQueue { return &containerHeapQueue{} }},
+	{name: "generic-heap", new: func() orderQueue { return newGenericHeapQueue() }},
+	{name: "sorted-slice", new: func() orderQueue { return &sortedSliceQueue{} }},
+}
+
+// queueOrders returns n orders whose prices come from a small set, so ties are common.
+func queueOrders(n int, seed uint64) []*Order {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	orders := make([]*Order, n)
+	for i := range orders {
+		orders[i] = &Order{Price: float64(rng.IntN(50)) / 4, Qty: int64(i)}
+	}
+	return orders
+}
+
+func TestOrderQueues_SamePopOrder(t *testing.T) {
+	orders := queueOrders(5000, 1)
+	rng := rand.New(rand.NewPCG(2, 2))
+	// true pushes the next order, false pops; pushes are twice as likely so the queue grows.
+	var ops []bool
+	for range 3 * len(orders) {
+		ops = append(ops, rng.IntN(3) > 0)
+	}
+
+	var want []*Order
+	for qi, q := range orderQueues {
+		queue := q.new()
+		var got []*Order
+		next := 0
+		for _, push := range ops {
+			if push && next < len(orders) {
+				queue.Push(orders[next])
+				next++
+			} else if o, ok := queue.Pop(); ok {
+				got = append(got, o)
+			}
+		}
+		for queue.Len() > 0 {
+			o, _ := queue.Pop()
+			got = append(got, o)
+		}
+
+		if len(got) != len(orders) {
+			t.Fatalf("%s popped %d orders, pushed %d", q.name, len(got), len(orders))
+		}
+		if qi == 0 {
+			want = got
+			continue
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("%s: pop %d = %+v, %s popped %+v", q.name, i, *got[i], orderQueues[0].name, *want[i])
+			}
+		}
+	}
+}
+
+func TestOrderQueues_MaxPriceFirstTiesFIFO(t *testing.T) {
+	orders := []*Order{
+		{Price: 1, Qty: 0}, {Price: 3, Qty: 1}, {Price: 2, Qty: 2},
+		{Price: 3, Qty: 3}, {Price: 1, Qty: 4}, {Price: 3, Qty: 5},
+	}
+	wantQty := []int64{1, 3, 5, 2, 0, 4}
+
+	for _, q := range orderQueues {
+		queue := q.new()
+		for _, o := range orders {
+			queue.Push(o)
+		}
+		for i, want := range wantQty {
+			o, ok := queue.Pop()
+			if !ok || o.Qty != want {
+				t.Fatalf("%s: pop %d = %+v, %v; want Qty %d", q.name, i, o, ok, want)
+			}
+		}
+		if _, ok := queue.Pop(); ok {
+			t.Errorf("%s: Pop on an empty queue reported ok", q.name)
+		}
+	}
+}
+
+// BenchmarkOrderQueue pushes one order and pops one per op on a queue holding 1k or 100k
+// live orders, comparing container/heap, a generic heap, and a sorted slice.
+func BenchmarkOrderQueue(b *testing.B) {
+	for _, live := range []int{1_000, 100_000} {
+		orders := queueOrders(2*live, 3)
+		for _, q := range orderQueues {
+			b.Run(fmt.Sprintf("%s/live=%d", q.name, live), func(b *testing.B) {
+				queue := q.new()
+				for _, o := range orders[:live] {
+					queue.Push(o)
+				}
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := range b.N {
+					queue.Push(orders[live+i%live])
+					sinkOrder, _ = queue.Pop()
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+)
+
+// profileConfig selects which profiles TestMain captures around the run and where they go.
+// The zero value captures nothing.
+type profileConfig struct {
+	cpu, heap bool
+	dir       string
+}
+
+// parseProfileKinds parses a comma-separated -benchprofile value such as "cpu,heap".
+func parseProfileKinds(s string) (cpu, heap bool, err error) {
+	if s == "" {
+		return false, false, nil
+	}
+	for kind := range strings.SplitSeq(s, ",") {
+		switch strings.TrimSpace(kind) {
+		case "cpu":
+			cpu = true
+		case "heap":
+			heap = true
+		default:
+			return false, false, fmt.Errorf("unknown profile kind %q (want cpu or heap)", kind)
+		}
+	}
+	return cpu, heap, nil
+}
+
+// profileName turns a -bench pattern into a file name stem, so profiling one benchmark
+// yields e.g. BenchmarkSortOrders.cpu.pprof. Characters unsafe in file names become '_'.
+func profileName(pattern string) string {
+	if pattern == "" || pattern == "." {
+		return "bench"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, pattern)
+}
+
+// startProfiles starts the CPU profile if cfg asks for one and returns a function that stops
+// it and writes the heap profile, to be called once the run is over. Files are written to
+// cfg.dir as <name>.cpu.pprof and <name>.heap.pprof.
+func startProfiles(cfg profileConfig, name string) (stop func() error, err error) {
+	if !cfg.cpu && !cfg.heap {
+		return func() error { return nil }, nil
+	}
+	if err := os.MkdirAll(cfg.dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	var cpuFile *os.File
+	if cfg.cpu {
+		cpuFile, err = os.Create(filepath.Join(cfg.dir, name+".cpu.pprof"))
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(cpuFile); err != nil {
+			cpuFile.Close()
+			return nil, err
+		}
+	}
+
+	return func() error {
+		var errs []error
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			errs = append(errs, cpuFile.Close())
+		}
+		if cfg.heap {
+			errs = append(errs, writeHeapProfile(filepath.Join(cfg.dir, name+".heap.pprof")))
+		}
+		return errors.Join(errs...)
+	}, nil
+}
+
+// writeHeapProfile writes the heap profile to path after a GC, as go test -memprofile does,
+// so the profile reflects live objects as of the end of the run.
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,161 @@
+package benchmarks
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// checkProfile fails unless path holds a pprof profile: a gzip stream whose payload is a
+// well-formed protobuf message carrying profile.proto's sample_type (1) and string_table (6).
+func checkProfile(t *testing.T, path string) {
+	t.Helper()
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("profile not written: %v", err)
+	}
+	if !bytes.HasPrefix(raw, []byte{0x1f, 0x8b}) {
+		t.Fatalf("%s: missing gzip header", path)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	payload, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+
+	fields, err := protoFields(payload)
+	if err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	for _, want := range []uint64{1, 6} {
+		if !fields[want] {
+			t.Errorf("%s: profile has no field %d; got %v", path, want, fields)
+		}
+	}
+}
+
+// protoFields walks the top-level fields of a protobuf message and returns the field
+// numbers present, failing on a truncated or malformed encoding.
+func protoFields(msg []byte) (map[uint64]bool, error) {
+	fields := make(map[uint64]bool)
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+		if n <= 0 {
+			return nil, errors.New("bad field key")
+		}
+		msg = msg[n:]
+
+		var size uint64
+		switch key & 7 {
+		case 0: // varint
+			if _, n = binary.Uvarint(msg); n <= 0 {
+				return nil, errors.New("bad varint")
+			}
+			size = uint64(n)
+		case 1: // fixed64
+			size = 8
+		case 2: // length-delimited
+			l, n := binary.Uvarint(msg)
+			if n <= 0 {
+				return nil, errors.New("bad length")
+			}
+			msg = msg[n:]
+			size = l
+		case 5: // fixed32
+			size = 4
+		default:
+			return nil, fmt.Errorf("unsupported wire type %d", key&7)
+		}
+		if size > uint64(len(msg)) {
+			return nil, errors.New("truncated field")
+		}
+		msg = msg[size:]
+		fields[key>>3] = true
+	}
+	return fields, nil
+}
+
+func TestStartProfiles_WritesValidProfiles(t *testing.T) {
+	if *benchProfile != "" {
+		t.Skip("-benchprofile already holds the CPU profile")
+	}
+	if f := flag.Lookup("test.cpuprofile"); f != nil && f.Value.String() != "" {
+		t.Skip("-cpuprofile already holds the CPU profile")
+	}
+
+	dir := filepath.Join(t.TempDir(), "nested")
+	stop, err := startProfiles(profileConfig{cpu: true, heap: true, dir: dir}, "BenchmarkX")
+	if err != nil {
+		t.Fatalf("startProfiles: %v", err)
+	}
+	for range 1000 {
+		sinkOrders = sampleOrders(100)
+	}
+	if err := stop(); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+
+	checkProfile(t, filepath.Join(dir, "BenchmarkX.cpu.pprof"))
+	checkProfile(t, filepath.Join(dir, "BenchmarkX.heap.pprof"))
+}
+
+func TestStartProfiles_DisabledIsNoOp(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "unused")
+	stop, err := startProfiles(profileConfig{dir: dir}, "BenchmarkX")
+	if err != nil {
+		t.Fatalf("startProfiles: %v", err)
+	}
+	if err := stop(); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("disabled profiling touched %s: %v", dir, err)
+	}
+}
+
+func TestParseProfileKinds(t *testing.T) {
+	tests := []struct {
+		in        string
+		cpu, heap bool
+		wantErr   bool
+	}{
+		{in: ""},
+		{in: "cpu", cpu: true},
+		{in: "heap", heap: true},
+		{in: "cpu,heap", cpu: true, heap: true},
+		{in: "heap, cpu", cpu: true, heap: true},
+		{in: "cpu,block", wantErr: true},
+	}
+	for _, tt := range tests {
+		cpu, heap, err := parseProfileKinds(tt.in)
+		if (err != nil) != tt.wantErr || cpu != tt.cpu || heap != tt.heap {
+			t.Errorf("parseProfileKinds(%q) = %v, %v, %v; want %v, %v, error %v",
+				tt.in, cpu, heap, err, tt.cpu, tt.heap, tt.wantErr)
+		}
+	}
+}
+
+func TestProfileName(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                        "bench",
+		".":                       "bench",
+		"BenchmarkSortOrders":     "BenchmarkSortOrders",
+		"BenchmarkSort/n=1000$":   "BenchmarkSort_n_1000_",
+		"Benchmark(Pool|Alloc).*": "Benchmark_Pool_Alloc_._",
+	} {
+		if got := profileName(in); got != want {
+			t.Errorf("profileName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package benchmarks
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"golang-backend/pkg/ratelimit"
+)
+
+// limiterGoroutines are the RunParallel goroutine counts for the rate limiter benchmarks.
+var limiterGoroutines = []int{1, 8, 32}
+
+const (
+	// limiterRate is high enough that most calls find a token, so the benchmark measures
+	// the admission path rather than the rejection path.
+	limiterRate  = 1e8
+	limiterBurst = 1000
+)
+
+// chanBucket is the naive token bucket: a buffered channel refilled by a ticker goroutine.
+type chanBucket struct {
+	tokens chan struct{}
+	stop   chan struct{}
+}
+
+func newChanBucket(rate float64, burst int) *chanBucket {
+	const tick = time.Millisecond
+
+	c := &chanBucket{
+		tokens: make(chan struct{}, burst),
+		stop:   make(chan struct{}),
+	}
+	for range burst {
+		c.tokens <- struct{}{}
+	}
+
+	perTick := max(int(rate*tick.Seconds()), 1)
+	go func() {
+		t := time.NewTicker(tick)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				// Only Allow drains the channel, so the free space seen here can only
+				// grow; capping the sends at it keeps a full bucket from spinning on
+				// perTick failed sends every tick.
+				for range min(perTick, burst-len(c.tokens)) {
+					select {
+					case c.tokens <- struct{}{}:
+					default:
+					}
+				}
+			case <-c.stop:
+				return
+			}
+		}
+	}()
+	return c
+}
+
+func (c *chanBucket) Allow() bool {
+	select {
+	case <-c.tokens:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *chanBucket) Close() { close(c.stop) }
+
+func TestChanBucket_Burst(t *testing.T) {
+	c := newChanBucket(1, 3)
+	defer c.Close()
+
+	for i := range 3 {
+		if !c.Allow() {
+			t.Fatalf("Allow %d of burst 3 = false", i+1)
+		}
+	}
+	if c.Allow() {
+		t.Error("Allow after exhausting burst = true")
+	}
+}
+
+// BenchmarkRateLimiter compares the mutex and CAS token buckets from pkg/ratelimit and a
+// channel-of-tokens bucket under contention. Both pkg/ratelimit buckets read the clock on
+// every call; the channel bucket moves that cost to its refill goroutine, at the price of
+// tick-sized refill granularity and a goroutine per limiter.
+func BenchmarkRateLimiter(b *testing.B) {
+	impls := []struct {
+		name string
+		new  func(b *testing.B) (allow func() bool, closeFn func())
+	}{
+		{name: "mutex", new: func(b *testing.B) (func() bool, func()) {
+			l, err := ratelimit.NewMutex(limiterRate, limiterBurst)
+			if err != nil {
+				b.Fatal(err)
+			}
+			return l.Allow, func() {}
+		}},
+		{name: "atomic", new: func(b *testing.B) (func() bool, func()) {
+			l, err := ratelimit.NewAtomic(limiterRate, limiterBurst)
+			if err != nil {
+				b.Fatal(err)
+			}
+			return l.Allow, func() {}
+		}},
+		{name: "chan", new: func(b *testing.B) (func() bool, func()) {
+			c := newChanBucket(limiterRate, limiterBurst)
+			return c.Allow, c.Close
+		}},
+	}
+
+	for _, impl := range impls {
+		for _, n := range limiterGoroutines {
+			b.Run(fmt.Sprintf("%s/goroutines=%d", impl.name, n), func(b *testing.B) {
+				allow, closeFn := impl.new(b)
+				defer closeFn()
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				runWithGoroutines(b, n, func(pb *testing.PB) {
+					for pb.Next() {
+						sinkFound = allow()
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package benchmarks
+
+import "testing"
+
+// receiverElements is the slice length used by the receiver benchmarks.
+const receiverElements = 10_000
+
+var (
+	sinkTotal    float64
+	sinkChecksum int64
+)
+
+func newBigObjects(n int) []BigObject {
+	objs := make([]BigObject, n)
+	for i := range objs {
+		objs[i].ID = int64(i)
+		objs[i].Data[i%len(objs[i].Data)] = 1
+	}
+	return objs
+}
+
+func TestReceivers_SameResult(t *testing.T) {
+	o := Order{Price: 2.5, Qty: 4}
+	if o.TotalByValue() != 10 || o.TotalByPointer() != 10 {
+		t.Errorf("TotalByValue = %v, TotalByPointer = %v, want 10", o.TotalByValue(), o.TotalByPointer())
+	}
+
+	big := newBigObjects(1)[0]
+	big.ID = 5
+	if big.ChecksumByValue() != 6 || big.ChecksumByPointer() != 6 {
+		t.Errorf("ChecksumByValue = %d, ChecksumByPointer = %d, want 6", big.ChecksumByValue(), big.ChecksumByPointer())
+	}
+}
+
+func TestAllocs_Receivers(t *testing.T) {
+	o := &Order{Price: 1, Qty: 1}
+	big := new(BigObject)
+
+	assertAllocs(t, "Order.TotalByValue", 0, func() { sinkTotal = o.TotalByValue() })
+	assertAllocs(t, "Order.TotalByPointer", 0, func() { sinkTotal = o.TotalByPointer() })
+	assertAllocs(t, "BigObject.ChecksumByValue", 0, func() { sinkChecksum = big.ChecksumByValue() })
+	assertAllocs(t, "BigObject.ChecksumByPointer", 0, func() { sinkChecksum = big.ChecksumByPointer() })
+}
+
+// BenchmarkOrderTotalByValue calls a value-receiver method on 16-byte orders: copying is negligible.
+func BenchmarkOrderTotalByValue(b *testing.B) {
+	orders := BuildOrders(receiverElements, true)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		var total float64
+		for i := range orders {
+			total += orders[i].TotalByValue()
+		}
+		sinkTotal = total
+	}
+}
+
+// BenchmarkOrderTotalByPointer calls a pointer-receiver method on 16-byte orders.
+func BenchmarkOrderTotalByPointer(b *testing.B) {
+	orders := BuildOrders(receiverElements, true)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		var total float64
+		for i := range orders {
+			total += orders[i].TotalByPointer()
+		}
+		sinkTotal = total
+	}
+}
+
+// BenchmarkBigObjectChecksumByValue calls a value-receiver method on 8KB objects: every call copies 8KB.
+func BenchmarkBigObjectChecksumByValue(b *testing.B) {
+	objs := newBigObjects(receiverElements)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		var sum int64
+		for i := range objs {
+			sum += objs[i].ChecksumByValue()
+		}
+		sinkChecksum = sum
+	}
+}
+
+// BenchmarkBigObjectChecksumByPointer calls a pointer-receiver method on 8KB objects.
+func BenchmarkBigObjectChecksumByPointer(b *testing.B) {
+	objs := newBigObjects(receiverElements)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		var sum int64
+		for i := range objs {
+			sum += objs[i].ChecksumByPointer()
+		}
+		sinkChecksum = sum
+	}
+}
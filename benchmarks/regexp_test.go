@@ -0,0 +1,98 @@
+package benchmarks
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// orderIDPattern accepts "ORD-" followed by 1 to 9 ASCII digits.
+const orderIDPattern = `^ORD-[0-9]{1,9}$`
+
+// orderIDRegexp is compiled once at package init.
+var orderIDRegexp = regexp.MustCompile(orderIDPattern)
+
+// validOrderIDCompileEach recompiles the pattern on every call.
+func validOrderIDCompileEach(s string) bool {
+	return regexp.MustCompile(orderIDPattern).MatchString(s)
+}
+
+// validOrderIDCompiled uses the package-level compiled regexp.
+func validOrderIDCompiled(s string) bool {
+	return orderIDRegexp.MatchString(s)
+}
+
+// validOrderIDManual checks the prefix and parses the digits by hand.
+// strconv.ParseUint rejects signs, so only the length needs checking separately.
+// Rejected input allocates the *strconv.NumError that ParseUint returns.
+func validOrderIDManual(s string) bool {
+	const prefix = "ORD-"
+	if !strings.HasPrefix(s, prefix) {
+		return false
+	}
+	digits := s[len(prefix):]
+	if len(digits) < 1 || len(digits) > 9 {
+		return false
+	}
+	_, err := strconv.ParseUint(digits, 10, 32)
+	return err == nil
+}
+
+var orderIDValidators = []struct {
+	name  string
+	valid func(string) bool
+}{
+	{name: "compileEach", valid: validOrderIDCompileEach},
+	{name: "compiled", valid: validOrderIDCompiled},
+	{name: "manual", valid: validOrderIDManual},
+}
+
+func TestValidOrderID(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{in: "ORD-12345", want: true},
+		{in: "ORD-0", want: true},
+		{in: "ORD-000000000", want: true},
+		{in: "ORD-999999999", want: true},
+		{in: "ORD-1234567890", want: false}, // 10 digits
+		{in: "ORD-", want: false},
+		{in: "", want: false},
+		{in: "ORD", want: false},
+		{in: "ord-123", want: false},
+		{in: "XORD-123", want: false},
+		{in: "ORD-+123", want: false},
+		{in: "ORD--123", want: false},
+		{in: "ORD- 123", want: false},
+		{in: "ORD-123 ", want: false},
+		{in: "ORD-12a3", want: false},
+		{in: "ORD-1_000", want: false},
+		{in: "ORD-123\n", want: false},
+		{in: "ORD-١٢٣", want: false}, // non-ASCII digits
+	}
+
+	for _, v := range orderIDValidators {
+		for _, tt := range tests {
+			if got := v.valid(tt.in); got != tt.want {
+				t.Errorf("%s(%q) = %v, want %v", v.name, tt.in, got, tt.want)
+			}
+		}
+	}
+}
+
+// BenchmarkValidOrderID compares recompiling the regexp per call, a package-level compiled
+// regexp, and a hand-rolled parser, on a valid and an invalid ID.
+func BenchmarkValidOrderID(b *testing.B) {
+	for _, in := range []string{"ORD-12345", "ORD-12a45"} {
+		for _, v := range orderIDValidators {
+			b.Run(v.name+"/"+in, func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					sinkFound = v.valid(in)
+				}
+			})
+		}
+	}
+}
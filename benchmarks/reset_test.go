@@ -0,0 +1,113 @@
+package benchmarks
+
+import "testing"
+
+// dirtyBigObject fills every field of o, as a previous pool user would have.
+func dirtyBigObject(o *BigObject) {
+	o.ID = 42
+	o.Name = "previous user"
+	for i := range o.Data {
+		o.Data[i] = int64(i + 1)
+	}
+	o.IsActive = true
+}
+
+func TestBigObject_Reset(t *testing.T) {
+	var o BigObject
+	dirtyBigObject(&o)
+
+	o.Reset()
+
+	if o.ID != 0 {
+		t.Errorf("ID = %d, want 0", o.ID)
+	}
+	if o.Name != "" {
+		t.Errorf("Name = %q, want empty", o.Name)
+	}
+	for i, v := range o.Data {
+		if v != 0 {
+			t.Fatalf("Data[%d] = %d, want 0", i, v)
+		}
+	}
+	if o.IsActive {
+		t.Error("IsActive = true, want false")
+	}
+}
+
+// BenchmarkResetStructAssign zeroes the whole object with *o = BigObject{} (what Reset does).
+func BenchmarkResetStructAssign(b *testing.B) {
+	o := new(BigObject)
+	dirtyBigObject(o)
+	globalSinkPool = o // keep o on the heap so the zeroing stores cannot be elided
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		o.Reset()
+		o.ID++
+	}
+}
+
+// BenchmarkResetArrayAssign zeroes Data with an array assignment and the other fields individually.
+func BenchmarkResetArrayAssign(b *testing.B) {
+	o := new(BigObject)
+	dirtyBigObject(o)
+	globalSinkPool = o // keep o on the heap so the zeroing stores cannot be elided
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		o.ID = 0
+		o.Name = ""
+		o.Data = [1024]int64{}
+		o.IsActive = false
+		o.ID++
+	}
+}
+
+// BenchmarkResetClear zeroes Data with clear on a slice of the array.
+func BenchmarkResetClear(b *testing.B) {
+	o := new(BigObject)
+	dirtyBigObject(o)
+	globalSinkPool = o // keep o on the heap so the zeroing stores cannot be elided
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		o.ID = 0
+		o.Name = ""
+		clear(o.Data[:])
+		o.IsActive = false
+		o.ID++
+	}
+}
+
+// BenchmarkResetElementLoop zeroes Data one element at a time.
+func BenchmarkResetElementLoop(b *testing.B) {
+	o := new(BigObject)
+	dirtyBigObject(o)
+	globalSinkPool = o // keep o on the heap so the zeroing stores cannot be elided
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		o.ID = 0
+		o.Name = ""
+		for i := range o.Data {
+			o.Data[i] = 0
+		}
+		o.IsActive = false
+		o.ID++
+	}
+}
+
+// BenchmarkResetPartial clears only ID and IsActive, leaving 8KB of stale Data behind.
+// It is the fastest option and the unsafe one: the next Get sees the previous user's data.
+func BenchmarkResetPartial(b *testing.B) {
+	o := new(BigObject)
+	dirtyBigObject(o)
+	globalSinkPool = o // keep o on the heap so the zeroing stores cannot be elided
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		o.ID = 0
+		o.IsActive = false
+		o.ID++
+	}
+}
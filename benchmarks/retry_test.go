@@ -0,0 +1,91 @@
+package benchmarks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang-backend/pkg/retry"
+)
+
+// retryPolicy is a typical client policy. The benchmarks replace its sleeper so a retry
+// costs only the bookkeeping around it.
+var retryPolicy = retry.Policy{
+	MaxAttempts: 3,
+	BaseDelay:   10 * time.Millisecond,
+	MaxDelay:    time.Second,
+}
+
+func noSleep(ctx context.Context, _ time.Duration) error { return ctx.Err() }
+
+// callOrderService stands in for a remote call that succeeds.
+//
+//go:noinline
+func callOrderService(context.Context) error {
+	return nil
+}
+
+// flakyOrderService fails every other call, so each retry.Do call retries exactly once.
+type flakyOrderService struct {
+	calls int
+}
+
+//go:noinline
+func (s *flakyOrderService) call(context.Context) error {
+	s.calls++
+	if s.calls%2 == 1 {
+		return errInvalidOrder
+	}
+	return nil
+}
+
+func TestRetry_HappyPathAllocs(t *testing.T) {
+	ctx := context.Background()
+	if allocs := testing.AllocsPerRun(100, func() { sinkErr = retry.Do(ctx, retryPolicy, callOrderService) }); allocs != 0 {
+		t.Errorf("retry.Do of a succeeding call allocated %v times, want 0", allocs)
+	}
+}
+
+func TestRetry_FlakyServiceRetriesOnce(t *testing.T) {
+	var s flakyOrderService
+	p := retryPolicy
+	p.Sleep = noSleep
+	for range 10 {
+		if err := retry.Do(context.Background(), p, s.call); err != nil {
+			t.Fatalf("retry.Do = %v", err)
+		}
+	}
+	if s.calls != 20 {
+		t.Errorf("%d calls for 10 Do calls, want 20", s.calls)
+	}
+}
+
+// BenchmarkRetry measures the overhead retry.Do adds: a succeeding call made directly and
+// through Do, and a call that fails once and succeeds on its retry, with the backoff
+// sleep stubbed out.
+func BenchmarkRetry(b *testing.B) {
+	ctx := context.Background()
+
+	b.Run("direct", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkErr = callOrderService(ctx)
+		}
+	})
+	b.Run("retry.Do/success", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkErr = retry.Do(ctx, retryPolicy, callOrderService)
+		}
+	})
+	b.Run("retry.Do/one-retry", func(b *testing.B) {
+		var s flakyOrderService
+		call := s.call
+		p := retryPolicy
+		p.Sleep = noSleep
+		b.ReportAllocs()
+		for range b.N {
+			sinkErr = retry.Do(ctx, p, call)
+		}
+	})
+}
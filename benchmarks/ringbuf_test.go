@@ -0,0 +1,47 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"golang-backend/pkg/ringbuf"
+)
+
+// ringCapacity is shared by the ring buffer and the channel it is compared against.
+const ringCapacity = 1024
+
+// BenchmarkSPSCRing moves b.N *Order values from one producer to one consumer through a ringbuf.Ring.
+func BenchmarkSPSCRing(b *testing.B) {
+	r, err := ringbuf.New[Order](ringCapacity)
+	if err != nil {
+		b.Fatalf("ringbuf.New: %v", err)
+	}
+	o := &Order{Price: 1, Qty: 1}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	go func() {
+		for range b.N {
+			r.Push(o)
+		}
+	}()
+	for range b.N {
+		sink = r.Pop()
+	}
+}
+
+// BenchmarkSPSCChannel moves b.N *Order values through a buffered channel of the same capacity.
+func BenchmarkSPSCChannel(b *testing.B) {
+	ch := make(chan *Order, ringCapacity)
+	o := &Order{Price: 1, Qty: 1}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	go func() {
+		for range b.N {
+			ch <- o
+		}
+	}()
+	for range b.N {
+		sink = <-ch
+	}
+}
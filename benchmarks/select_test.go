@@ -0,0 +1,166 @@
+package benchmarks
+
+import "testing"
+
+// selectIdle is the number of never-ready channels the widest select waits on.
+const selectIdle = 15
+
+// selectChans is the harness every receiver reads from: ready is buffered and filled once
+// before each receive, and idle channels are unbuffered with no sender, so they are
+// locked and polled by select but never chosen. They are real channels rather than nil
+// ones because select drops nil cases up front.
+type selectChans struct {
+	ready chan int
+	idle  *[selectIdle]chan int
+}
+
+func newSelectChans() selectChans {
+	var idle [selectIdle]chan int
+	for i := range idle {
+		idle[i] = make(chan int)
+	}
+	return selectChans{ready: make(chan int, 1), idle: &idle}
+}
+
+// The receivers below take one value from c.ready. They report false if any other case,
+// or a default, was chosen instead.
+
+func recvPlain(c selectChans) (int, bool) {
+	return <-c.ready, true
+}
+
+func recvSelect2(c selectChans) (int, bool) {
+	select {
+	case v := <-c.ready:
+		return v, true
+	case <-c.idle[0]:
+	}
+	return 0, false
+}
+
+func recvSelect4(c selectChans) (int, bool) {
+	select {
+	case v := <-c.ready:
+		return v, true
+	case <-c.idle[0]:
+	case <-c.idle[1]:
+	case <-c.idle[2]:
+	}
+	return 0, false
+}
+
+func recvSelect8(c selectChans) (int, bool) {
+	select {
+	case v := <-c.ready:
+		return v, true
+	case <-c.idle[0]:
+	case <-c.idle[1]:
+	case <-c.idle[2]:
+	case <-c.idle[3]:
+	case <-c.idle[4]:
+	case <-c.idle[5]:
+	case <-c.idle[6]:
+	}
+	return 0, false
+}
+
+func recvSelect16(c selectChans) (int, bool) {
+	select {
+	case v := <-c.ready:
+		return v, true
+	case <-c.idle[0]:
+	case <-c.idle[1]:
+	case <-c.idle[2]:
+	case <-c.idle[3]:
+	case <-c.idle[4]:
+	case <-c.idle[5]:
+	case <-c.idle[6]:
+	case <-c.idle[7]:
+	case <-c.idle[8]:
+	case <-c.idle[9]:
+	case <-c.idle[10]:
+	case <-c.idle[11]:
+	case <-c.idle[12]:
+	case <-c.idle[13]:
+	case <-c.idle[14]:
+	}
+	return 0, false
+}
+
+// recvSelect1Default compiles to a non-blocking receive rather than a full select.
+func recvSelect1Default(c selectChans) (int, bool) {
+	select {
+	case v := <-c.ready:
+		return v, true
+	default:
+	}
+	return 0, false
+}
+
+// recvSelect4Default goes through the general select path, just without blocking.
+func recvSelect4Default(c selectChans) (int, bool) {
+	select {
+	case v := <-c.ready:
+		return v, true
+	case <-c.idle[0]:
+	case <-c.idle[1]:
+	case <-c.idle[2]:
+	default:
+	}
+	return 0, false
+}
+
+var selectReceivers = []struct {
+	name string
+	recv func(selectChans) (int, bool)
+}{
+	{name: "plain", recv: recvPlain},
+	{name: "cases=2", recv: recvSelect2},
+	{name: "cases=4", recv: recvSelect4},
+	{name: "cases=8", recv: recvSelect8},
+	{name: "cases=16", recv: recvSelect16},
+	{name: "cases=1+default", recv: recvSelect1Default},
+	{name: "cases=4+default", recv: recvSelect4Default},
+}
+
+func TestSelectReceivers_DeliverEveryMessage(t *testing.T) {
+	const n = 10_000
+	for _, r := range selectReceivers {
+		c := newSelectChans()
+		sum := 0
+		for i := range n {
+			c.ready <- i
+			v, ok := r.recv(c)
+			if !ok {
+				t.Fatalf("%s: message %d not received from the ready channel", r.name, i)
+			}
+			sum += v
+		}
+		if want := n * (n - 1) / 2; sum != want {
+			t.Errorf("%s: received values sum to %d, want %d", r.name, sum, want)
+		}
+		if len(c.ready) != 0 {
+			t.Errorf("%s: %d messages left unread", r.name, len(c.ready))
+		}
+	}
+}
+
+// BenchmarkSelectCases receives from one ready channel with a plain receive and with
+// selects of 2 to 16 cases whose other channels are never ready, with and without a
+// default. Every op also pays for the buffered send that readies the channel, which the
+// plain row shows on its own.
+func BenchmarkSelectCases(b *testing.B) {
+	for _, r := range selectReceivers {
+		b.Run(r.name, func(b *testing.B) {
+			c := newSelectChans()
+			b.ReportAllocs()
+			sum := 0
+			for i := range b.N {
+				c.ready <- i
+				v, _ := r.recv(c)
+				sum += v
+			}
+			sinkLen = sum
+		})
+	}
+}
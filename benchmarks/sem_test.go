@@ -0,0 +1,128 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"golang-backend/pkg/sem"
+)
+
+// spinSem is a weighted semaphore that spins on a CAS, yielding between attempts. It has
+// no queue, so it is neither fair nor cheap when waits are long; it is here as the
+// lock-free baseline.
+type spinSem struct {
+	avail atomic.Int64
+}
+
+func newSpinSem(capacity int64) *spinSem {
+	s := &spinSem{}
+	s.avail.Store(capacity)
+	return s
+}
+
+func (s *spinSem) Acquire(ctx context.Context, n int64) error {
+	for {
+		if cur := s.avail.Load(); cur >= n && s.avail.CompareAndSwap(cur, cur-n) {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		runtime.Gosched()
+	}
+}
+
+func (s *spinSem) Release(n int64) {
+	s.avail.Add(n)
+}
+
+var semImpls = []struct {
+	name string
+	new  func(capacity int64) (sem.Weighted, error)
+}{
+	{name: "chan", new: func(capacity int64) (sem.Weighted, error) { return sem.NewChan(capacity) }},
+	{name: "cond", new: func(capacity int64) (sem.Weighted, error) { return sem.NewCond(capacity) }},
+	{name: "spin", new: func(capacity int64) (sem.Weighted, error) { return newSpinSem(capacity), nil }},
+}
+
+func TestSemaphores_NeverExceedCapacity(t *testing.T) {
+	const (
+		capacity = 8
+		weight   = 3
+		workers  = 16
+		rounds   = 200
+	)
+	for _, impl := range semImpls {
+		s, err := impl.new(capacity)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var inUse, peak atomic.Int64
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Go(func() {
+				for range rounds {
+					if err := s.Acquire(context.Background(), weight); err != nil {
+						t.Error(err)
+						return
+					}
+					cur := inUse.Add(weight)
+					for p := peak.Load(); cur > p && !peak.CompareAndSwap(p, cur); p = peak.Load() {
+					}
+					runtime.Gosched()
+					inUse.Add(-weight)
+					s.Release(weight)
+				}
+			})
+		}
+		wg.Wait()
+
+		if got := peak.Load(); got > capacity {
+			t.Errorf("%s: %d units held at once, capacity %d", impl.name, got, capacity)
+		}
+	}
+}
+
+// semWorkloads are the weight and capacity combinations benchmarked.
+var semWorkloads = []struct {
+	name             string
+	weight, capacity int64
+	goroutines       []int
+}{
+	{name: "weight=1/cap=4", weight: 1, capacity: 4, goroutines: []int{1, 8, 64}},
+	{name: "weight=4/cap=8", weight: 4, capacity: 8, goroutines: []int{8, 64}},
+}
+
+// BenchmarkSemaphore measures acquire/release pairs on the channel, sync.Cond, and CAS
+// spinning semaphores as the number of goroutines grows past what the capacity admits.
+func BenchmarkSemaphore(b *testing.B) {
+	ctx := context.Background()
+	for _, w := range semWorkloads {
+		for _, impl := range semImpls {
+			for _, n := range w.goroutines {
+				b.Run(fmt.Sprintf("%s/%s/goroutines=%d", w.name, impl.name, n), func(b *testing.B) {
+					s, err := impl.new(w.capacity)
+					if err != nil {
+						b.Fatal(err)
+					}
+
+					b.ReportAllocs()
+					runWithGoroutines(b, n, func(pb *testing.PB) {
+						for pb.Next() {
+							if err := s.Acquire(ctx, w.weight); err != nil {
+								b.Error(err)
+								return
+							}
+							s.Release(w.weight)
+						}
+					})
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package benchmarks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+// bigObjectWireSize is the encoded size of BigObject's fixed-width part: ID, IsActive, and Data.
+const bigObjectWireSize = 8 + 1 + len(BigObject{}.Data)*8
+
+var errShortBigObject = errors.New("short BigObject encoding")
+
+// appendBigObjectWire appends the full manual binary encoding of o to dst:
+// ID, IsActive, a uvarint-prefixed Name, then every Data element, all little-endian.
+func appendBigObjectWire(dst []byte, o *BigObject) []byte {
+	dst = binary.LittleEndian.AppendUint64(dst, uint64(o.ID))
+	if o.IsActive {
+		dst = append(dst, 1)
+	} else {
+		dst = append(dst, 0)
+	}
+	dst = binary.AppendUvarint(dst, uint64(len(o.Name)))
+	dst = append(dst, o.Name...)
+	for _, v := range o.Data {
+		dst = binary.LittleEndian.AppendUint64(dst, uint64(v))
+	}
+	return dst
+}
+
+// decodeBigObjectWire decodes an encoding produced by appendBigObjectWire into o.
+func decodeBigObjectWire(src []byte, o *BigObject) error {
+	if len(src) < 9 {
+		return errShortBigObject
+	}
+	o.ID = int64(binary.LittleEndian.Uint64(src))
+	o.IsActive = src[8] == 1
+	src = src[9:]
+
+	n, w := binary.Uvarint(src)
+	if w <= 0 || uint64(len(src)-w) < n {
+		return errShortBigObject
+	}
+	src = src[w:]
+	o.Name = string(src[:n])
+	src = src[n:]
+
+	if len(src) < len(o.Data)*8 {
+		return errShortBigObject
+	}
+	for i := range o.Data {
+		o.Data[i] = int64(binary.LittleEndian.Uint64(src[i*8:]))
+	}
+	return nil
+}
+
+// gobStream holds a gob stream split into the first message, which carries the type
+// definition, and a value-only message that a primed decoder can read repeatedly.
+type gobStream struct {
+	first, value []byte
+}
+
+func newGobStream(tb testing.TB, o *BigObject) gobStream {
+	tb.Helper()
+
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(o); err != nil {
+		tb.Fatalf("gob first Encode: %v", err)
+	}
+	first := bytes.Clone(buf.Bytes())
+
+	buf.Reset()
+	if err := enc.Encode(o); err != nil {
+		tb.Fatalf("gob second Encode: %v", err)
+	}
+	return gobStream{first: first, value: bytes.Clone(buf.Bytes())}
+}
+
+// decoder returns a gob.Decoder that has consumed the type definition and yields value forever.
+func (s gobStream) decoder(tb testing.TB) *gob.Decoder {
+	tb.Helper()
+
+	dec := gob.NewDecoder(io.MultiReader(bytes.NewReader(s.first), &repeatReader{payload: s.value}))
+	var primed BigObject
+	if err := dec.Decode(&primed); err != nil {
+		tb.Fatalf("gob prime Decode: %v", err)
+	}
+	return dec
+}
+
+// serializeSample returns a BigObject with every field populated, including negative Data values.
+func serializeSample() BigObject {
+	var o BigObject
+	dirtyBigObject(&o)
+	o.Data[0] = -1
+	o.Data[len(o.Data)-1] = -1 << 62
+	return o
+}
+
+func TestBigObjectWire_RoundTrip(t *testing.T) {
+	for _, in := range []BigObject{{}, jsonSampleBigObject, serializeSample()} {
+		enc := appendBigObjectWire(nil, &in)
+		if want := bigObjectWireSize + len(in.Name) + 1; len(enc) != want {
+			t.Errorf("encoded %d bytes, want %d", len(enc), want)
+		}
+
+		var out BigObject
+		if err := decodeBigObjectWire(enc, &out); err != nil {
+			t.Fatalf("decodeBigObjectWire: %v", err)
+		}
+		if out != in {
+			t.Errorf("round trip mismatch for ID %d", in.ID)
+		}
+	}
+}
+
+func TestBigObjectWire_Short(t *testing.T) {
+	enc := appendBigObjectWire(nil, &jsonSampleBigObject)
+	for _, n := range []int{0, 8, 10, len(enc) - 1} {
+		var out BigObject
+		if err := decodeBigObjectWire(enc[:n], &out); !errors.Is(err, errShortBigObject) {
+			t.Errorf("decode of %d bytes: error = %v, want %v", n, err, errShortBigObject)
+		}
+	}
+}
+
+func TestBigObjectGob_RoundTrip(t *testing.T) {
+	in := serializeSample()
+	dec := newGobStream(t, &in).decoder(t)
+
+	for range 3 {
+		var out BigObject
+		if err := dec.Decode(&out); err != nil {
+			t.Fatalf("gob Decode: %v", err)
+		}
+		if out != in {
+			t.Fatal("gob round trip mismatch")
+		}
+	}
+}
+
+func TestBigObjectJSON_RoundTrip(t *testing.T) {
+	in := serializeSample()
+	enc, err := json.Marshal(&in)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	var out BigObject
+	if err := json.Unmarshal(enc, &out); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if out != in {
+		t.Error("json round trip mismatch")
+	}
+}
+
+// BenchmarkSerializeBigObject compares gob with a reused encoder, encoding/json, and the manual
+// binary codec. The wire-bytes metric is the encoded size of one BigObject.
+func BenchmarkSerializeBigObject(b *testing.B) {
+	o := jsonSampleBigObject
+
+	b.Run("gob", func(b *testing.B) {
+		var buf bytes.Buffer
+		enc := gob.NewEncoder(&buf)
+		if err := enc.Encode(&o); err != nil { // send the type definition up front
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			buf.Reset()
+			if err := enc.Encode(&o); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(buf.Len()), "wire-bytes")
+	})
+
+	b.Run("json", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			out, err := json.Marshal(&o)
+			if err != nil {
+				b.Fatal(err)
+			}
+			sinkBytes = out
+		}
+		b.ReportMetric(float64(len(sinkBytes)), "wire-bytes")
+	})
+
+	b.Run("manual", func(b *testing.B) {
+		dst := make([]byte, 0, bigObjectWireSize+64)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			dst = appendBigObjectWire(dst[:0], &o)
+		}
+		sinkBytes = dst
+		b.ReportMetric(float64(len(dst)), "wire-bytes")
+	})
+}
+
+// BenchmarkDeserializeBigObject measures the decode direction for the same three formats.
+func BenchmarkDeserializeBigObject(b *testing.B) {
+	o := jsonSampleBigObject
+
+	b.Run("gob", func(b *testing.B) {
+		dec := newGobStream(b, &o).decoder(b)
+		out := new(BigObject)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			if err := dec.Decode(out); err != nil {
+				b.Fatal(err)
+			}
+		}
+		globalSinkPool = out
+	})
+
+	b.Run("json", func(b *testing.B) {
+		payload, err := json.Marshal(&o)
+		if err != nil {
+			b.Fatal(err)
+		}
+		out := new(BigObject)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			if err := json.Unmarshal(payload, out); err != nil {
+				b.Fatal(err)
+			}
+		}
+		globalSinkPool = out
+	})
+
+	b.Run("manual", func(b *testing.B) {
+		payload := appendBigObjectWire(nil, &o)
+		out := new(BigObject)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			if err := decodeBigObjectWire(payload, out); err != nil {
+				b.Fatal(err)
+			}
+		}
+		globalSinkPool = out
+	})
+}
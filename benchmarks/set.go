@@ -0,0 +1,88 @@
+package benchmarks
+
+import "slices"
+
+// Set is a set of strings.
+type Set interface {
+	// Add inserts s, doing nothing if it is already a member.
+	Add(s string)
+	// Contains reports whether s is a member.
+	Contains(s string) bool
+	// Len returns the number of members.
+	Len() int
+}
+
+// StructSet is a map[string]struct{} set. The empty struct values take no space.
+type StructSet map[string]struct{}
+
+// NewStructSet returns a StructSet sized for and holding members.
+func NewStructSet(members ...string) StructSet {
+	s := make(StructSet, len(members))
+	for _, m := range members {
+		s.Add(m)
+	}
+	return s
+}
+
+// Add implements Set.
+func (s StructSet) Add(m string) { s[m] = struct{}{} }
+
+// Contains implements Set.
+func (s StructSet) Contains(m string) bool {
+	_, ok := s[m]
+	return ok
+}
+
+// Len implements Set.
+func (s StructSet) Len() int { return len(s) }
+
+// BoolSet is a map[string]bool set. It reads naturally as s[m], at the cost of a byte per entry.
+type BoolSet map[string]bool
+
+// NewBoolSet returns a BoolSet sized for and holding members.
+func NewBoolSet(members ...string) BoolSet {
+	s := make(BoolSet, len(members))
+	for _, m := range members {
+		s.Add(m)
+	}
+	return s
+}
+
+// Add implements Set.
+func (s BoolSet) Add(m string) { s[m] = true }
+
+// Contains implements Set.
+func (s BoolSet) Contains(m string) bool { return s[m] }
+
+// Len implements Set.
+func (s BoolSet) Len() int { return len(s) }
+
+// SortedSliceSet keeps its members in a sorted slice and looks them up by binary search.
+// Add shifts the tail of the slice, so build large sets with NewSortedSliceSet instead.
+type SortedSliceSet struct {
+	members []string
+}
+
+// NewSortedSliceSet returns a SortedSliceSet holding members, sorting and deduplicating a copy.
+func NewSortedSliceSet(members ...string) *SortedSliceSet {
+	sorted := slices.Clone(members)
+	slices.Sort(sorted)
+	return &SortedSliceSet{members: slices.Compact(sorted)}
+}
+
+// Add implements Set.
+func (s *SortedSliceSet) Add(m string) {
+	i, found := slices.BinarySearch(s.members, m)
+	if !found {
+		s.members = slices.Insert(s.members, i, m)
+	}
+}
+
+// Contains implements Set.
+func (s *SortedSliceSet) Contains(m string) bool {
+	_, found := slices.BinarySearch(s.members, m)
+	return found
+}
+
+// Len implements Set.
+func (s *SortedSliceSet) Len() int { return len(s.members) }
@@ -0,0 +1,133 @@
+package benchmarks
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+)
+
+var setSizes = []int{10, 1_000, 100_000}
+
+// setImpls builds each Set implementation from an initial member list.
+var setImpls = []struct {
+	name  string
+	build func(members ...string) Set
+}{
+	{name: "mapStruct", build: func(m ...string) Set { return NewStructSet(m...) }},
+	{name: "mapBool", build: func(m ...string) Set { return NewBoolSet(m...) }},
+	{name: "sortedSlice", build: func(m ...string) Set { return NewSortedSliceSet(m...) }},
+}
+
+// setKey returns the i-th key. The benchmarks make even keys members and odd keys misses,
+// so hits and misses have the same length and prefix.
+func setKey(i int) string {
+	return "sku-" + strconv.Itoa(i)
+}
+
+func TestSets_SameMembership(t *testing.T) {
+	const (
+		ops      = 20_000
+		universe = 2_000
+	)
+
+	r := rand.New(rand.NewPCG(3, 3))
+	sets := make([]Set, len(setImpls))
+	for i, impl := range setImpls {
+		sets[i] = impl.build()
+	}
+
+	for op := range ops {
+		key := setKey(r.IntN(universe))
+		if r.IntN(2) == 0 {
+			for _, s := range sets {
+				s.Add(key)
+			}
+			continue
+		}
+
+		want := sets[0].Contains(key)
+		for i, s := range sets[1:] {
+			if got := s.Contains(key); got != want {
+				t.Fatalf("op %d: %s.Contains(%q) = %v, %s says %v",
+					op, setImpls[i+1].name, key, got, setImpls[0].name, want)
+			}
+		}
+	}
+
+	for i, s := range sets {
+		if s.Len() != sets[0].Len() {
+			t.Errorf("%s.Len() = %d, %s.Len() = %d", setImpls[i].name, s.Len(), setImpls[0].name, sets[0].Len())
+		}
+	}
+	if sets[0].Len() == 0 || sets[0].Len() == universe {
+		t.Fatalf("Len %d: the sequence did not exercise both hits and misses", sets[0].Len())
+	}
+}
+
+func TestSets_BuildDeduplicates(t *testing.T) {
+	for _, impl := range setImpls {
+		s := impl.build("b", "a", "b", "c", "a")
+		if s.Len() != 3 {
+			t.Errorf("%s: Len = %d, want 3", impl.name, s.Len())
+		}
+		for _, m := range []string{"a", "b", "c"} {
+			if !s.Contains(m) {
+				t.Errorf("%s: missing %q", impl.name, m)
+			}
+		}
+		if s.Contains("d") {
+			t.Errorf("%s: contains non-member", impl.name)
+		}
+	}
+}
+
+// setMembersAndQueries returns n members and 2n queries, half of them misses, in random order.
+func setMembersAndQueries(n int) (members, queries []string) {
+	members = make([]string, n)
+	queries = make([]string, 0, 2*n)
+	for i := range n {
+		members[i] = setKey(2 * i)
+		queries = append(queries, members[i], setKey(2*i+1))
+	}
+
+	r := rand.New(rand.NewPCG(uint64(n), 9))
+	r.Shuffle(len(members), func(i, j int) { members[i], members[j] = members[j], members[i] })
+	r.Shuffle(len(queries), func(i, j int) { queries[i], queries[j] = queries[j], queries[i] })
+	return members, queries
+}
+
+// BenchmarkSetBuild measures building a set of n members from an unsorted list. The maps
+// insert one member at a time; the sorted slice sorts a copy once.
+func BenchmarkSetBuild(b *testing.B) {
+	for _, n := range setSizes {
+		members, _ := setMembersAndQueries(n)
+		for _, impl := range setImpls {
+			b.Run(fmt.Sprintf("%s/n=%d", impl.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					if impl.build(members...).Len() != n {
+						b.Fatal("wrong size")
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkSetContains measures lookups with a 50% miss rate; each op is one Contains call.
+func BenchmarkSetContains(b *testing.B) {
+	for _, n := range setSizes {
+		members, queries := setMembersAndQueries(n)
+		for _, impl := range setImpls {
+			b.Run(fmt.Sprintf("%s/n=%d", impl.name, n), func(b *testing.B) {
+				s := impl.build(members...)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := range b.N {
+					sinkFound = s.Contains(queries[i%len(queries)])
+				}
+			})
+		}
+	}
+}
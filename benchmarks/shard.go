@@ -0,0 +1,29 @@
+package benchmarks
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+// shardSeed is chosen once per process, so a key maps to the same shard for the life of the
+// process but not across processes.
+var shardSeed = maphash.MakeSeed()
+
+// ShardFor returns the shard in [0, n) that owns key. n must be positive.
+func ShardFor(key string, n int) int {
+	return reduceShard(maphash.String(shardSeed, key), n)
+}
+
+// ShardForID returns the shard in [0, n) that owns id. n must be positive.
+// The id is mixed before reducing, so ids sharing a stride with n (every 16th id with
+// 16 shards, say) still spread across all shards instead of landing on one.
+func ShardForID(id int64, n int) int {
+	return reduceShard(uint64(id)*0x9e3779b97f4a7c15, n)
+}
+
+// reduceShard maps h onto [0, n) with a multiply-high instead of a division. It keeps the
+// top bits of h, which is where both maphash and the multiplicative mix have the most entropy.
+func reduceShard(h uint64, n int) int {
+	hi, _ := bits.Mul64(h, uint64(n))
+	return int(hi)
+}
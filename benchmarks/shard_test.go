@@ -0,0 +1,140 @@
+package benchmarks
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+)
+
+const (
+	shardKeys       = 1_000_000
+	shardsUnderTest = 16
+)
+
+// shardFNV hashes key with FNV-1a from hash/fnv.
+func shardFNV(key string, n int) int {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return int(h.Sum64() % uint64(n))
+}
+
+// shardModulo is plain modulo on the raw id.
+func shardModulo(id int64, n int) int {
+	return int(uint64(id) % uint64(n))
+}
+
+var stringSharders = []struct {
+	name  string
+	shard func(key string, n int) int
+}{
+	{name: "fnv", shard: shardFNV},
+	{name: "maphash", shard: ShardFor},
+}
+
+var idSharders = []struct {
+	name  string
+	shard func(id int64, n int) int
+}{
+	{name: "modulo", shard: shardModulo},
+	{name: "mixed", shard: ShardForID},
+}
+
+// shardTestKeys returns n random ids and the same ids formatted as string keys.
+func shardTestKeys(n int) ([]int64, []string) {
+	r := rand.New(rand.NewPCG(11, 11))
+	ids := make([]int64, n)
+	keys := make([]string, n)
+	for i := range ids {
+		ids[i] = r.Int64()
+		keys[i] = "order-" + strconv.FormatInt(ids[i], 10)
+	}
+	return ids, keys
+}
+
+// shardLoadRatio returns the largest shard's load divided by the smallest's, or +Inf if a
+// shard received nothing.
+func shardLoadRatio[K any](keys []K, n int, shard func(K, int) int) float64 {
+	loads := make([]int, n)
+	for _, k := range keys {
+		loads[shard(k, n)]++
+	}
+	lo, hi := loads[0], loads[0]
+	for _, l := range loads[1:] {
+		lo, hi = min(lo, l), max(hi, l)
+	}
+	return float64(hi) / float64(lo)
+}
+
+func TestShardFor_Uniform(t *testing.T) {
+	ids, keys := shardTestKeys(shardKeys)
+
+	for _, n := range []int{shardsUnderTest, 7} {
+		for _, s := range stringSharders {
+			if r := shardLoadRatio(keys, n, s.shard); r >= 1.5 {
+				t.Errorf("%s over %d shards: max/min load %.3f, want < 1.5", s.name, n, r)
+			}
+		}
+		for _, s := range idSharders {
+			if r := shardLoadRatio(ids, n, s.shard); r >= 1.5 {
+				t.Errorf("%s over %d shards: max/min load %.3f, want < 1.5", s.name, n, r)
+			}
+		}
+	}
+}
+
+// TestShardForID_StridedIDs covers the case plain modulo gets wrong: ids that are all
+// multiples of the shard count land on a single shard.
+func TestShardForID_StridedIDs(t *testing.T) {
+	ids := make([]int64, 100_000)
+	for i := range ids {
+		ids[i] = int64(i) * shardsUnderTest
+	}
+
+	if r := shardLoadRatio(ids, shardsUnderTest, ShardForID); r >= 1.5 {
+		t.Errorf("ShardForID on strided ids: max/min load %.3f, want < 1.5", r)
+	}
+}
+
+func TestShardFor_InRangeAndStable(t *testing.T) {
+	ids, keys := shardTestKeys(1_000)
+	for _, n := range []int{1, 3, shardsUnderTest} {
+		for i := range ids {
+			s, k := ShardForID(ids[i], n), ShardFor(keys[i], n)
+			if s < 0 || s >= n || k < 0 || k >= n {
+				t.Fatalf("n=%d: ShardForID = %d, ShardFor = %d, want [0, %d)", n, s, k, n)
+			}
+			if s != ShardForID(ids[i], n) || k != ShardFor(keys[i], n) {
+				t.Fatalf("n=%d: shard changed between calls for %q", n, keys[i])
+			}
+		}
+	}
+}
+
+var sinkShard int
+
+// BenchmarkShardSelect measures the per-key cost of each shard function over random keys.
+// The max/min metric is the load ratio between the fullest and emptiest of 16 shards over
+// 1M keys; 1.0 is perfectly even.
+func BenchmarkShardSelect(b *testing.B) {
+	ids, keys := shardTestKeys(shardKeys)
+
+	for _, s := range stringSharders {
+		b.Run("string/"+s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				sinkShard = s.shard(keys[i%len(keys)], shardsUnderTest)
+			}
+			b.ReportMetric(shardLoadRatio(keys, shardsUnderTest, s.shard), "max/min")
+		})
+	}
+	for _, s := range idSharders {
+		b.Run("int/"+s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				sinkShard = s.shard(ids[i%len(ids)], shardsUnderTest)
+			}
+			b.ReportMetric(shardLoadRatio(ids, shardsUnderTest, s.shard), "max/min")
+		})
+	}
+}
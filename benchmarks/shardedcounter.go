@@ -0,0 +1,55 @@
+package benchmarks
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// ShardedCounter spreads increments across cache-line padded shards so concurrent writers
+// rarely touch the same line. Each Inc picks a shard with ShardForID from the calling
+// goroutine's stack address: stable for a goroutine, distinct between goroutines, and free to compute.
+type ShardedCounter struct {
+	slots []paddedSlot
+}
+
+// NewShardedCounter creates a ShardedCounter with the given number of shards.
+// Values below 1 use runtime.GOMAXPROCS(0).
+func NewShardedCounter(shards int) *ShardedCounter {
+	if shards < 1 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+	return &ShardedCounter{
+		slots: make([]paddedSlot, shards),
+	}
+}
+
+// goroutineHint returns a best-effort hint for spreading goroutines across shards, taken
+// from the caller's stack address. It is not a goroutine identity: it changes with call
+// depth and when the stack grows and moves, so consecutive calls from one goroutine may
+// pick different shards. Callers must only rely on it to spread load, never for affinity.
+func goroutineHint() uint64 {
+	var marker byte
+	// Goroutine stacks are at least 2KB apart, so the low bits carry no information.
+	return uint64(uintptr(unsafe.Pointer(&marker)) >> 11)
+}
+
+// Inc adds one to a shard chosen from the caller's goroutineHint, so concurrent callers
+// usually, but not always, land on different shards.
+func (c *ShardedCounter) Inc() {
+	c.slots[ShardForID(int64(goroutineHint()), len(c.slots))].n.Add(1)
+}
+
+// Value returns the total across all shards. It is safe to call concurrently with Inc,
+// in which case the result reflects some interleaving of in-flight increments.
+func (c *ShardedCounter) Value() int64 {
+	var total int64
+	for i := range c.slots {
+		total += c.slots[i].n.Load()
+	}
+	return total
+}
+
+// Len returns the number of shards.
+func (c *ShardedCounter) Len() int {
+	return len(c.slots)
+}
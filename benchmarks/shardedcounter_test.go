@@ -0,0 +1,48 @@
+package benchmarks
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestShardedCounter_Stress(t *testing.T) {
+	const (
+		goroutines = 100
+		perWorker  = 1000
+	)
+
+	c := NewShardedCounter(8)
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Go(func() {
+			for range perWorker {
+				c.Inc()
+			}
+		})
+	}
+	wg.Wait()
+
+	if got, want := c.Value(), int64(goroutines*perWorker); got != want {
+		t.Errorf("Value = %d, want %d", got, want)
+	}
+}
+
+func TestNewShardedCounter_Shards(t *testing.T) {
+	if got := NewShardedCounter(3).Len(); got != 3 {
+		t.Errorf("NewShardedCounter(3).Len = %d, want 3", got)
+	}
+	for _, n := range []int{0, -1} {
+		if got, want := NewShardedCounter(n).Len(), runtime.GOMAXPROCS(0); got != want {
+			t.Errorf("NewShardedCounter(%d).Len = %d, want GOMAXPROCS %d", n, got, want)
+		}
+	}
+}
+
+// TestGoroutineHint_SameAtSameDepth checks only what the hint promises: two calls from one
+// frame, with no stack growth between them, see the same stack address.
+func TestGoroutineHint_SameAtSameDepth(t *testing.T) {
+	if a, b := goroutineHint(), goroutineHint(); a != b {
+		t.Errorf("goroutineHint changed between calls in one frame: %#x, %#x", a, b)
+	}
+}
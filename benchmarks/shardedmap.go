@@ -0,0 +1,68 @@
+package benchmarks
+
+import "sync"
+
+// shardCount is the number of independently locked shards in a ShardedMap.
+const shardCount = 16
+
+// ShardedMap is a concurrent map[int64]*Order split into shards, each guarded
+// by its own RWMutex, so writers to different shards never contend.
+type ShardedMap struct {
+	shards [shardCount]orderShard
+}
+
+type orderShard struct {
+	mu sync.RWMutex
+	m  map[int64]*Order
+}
+
+// NewShardedMap creates an empty ShardedMap.
+func NewShardedMap() *ShardedMap {
+	sm := &ShardedMap{}
+	for i := range sm.shards {
+		sm.shards[i].m = make(map[int64]*Order)
+	}
+	return sm
+}
+
+// shard returns the shard owning id.
+func (sm *ShardedMap) shard(id int64) *orderShard {
+	return &sm.shards[ShardForID(id, shardCount)]
+}
+
+// Get returns the order stored under id.
+func (sm *ShardedMap) Get(id int64) (*Order, bool) {
+	s := sm.shard(id)
+	s.mu.RLock()
+	o, ok := s.m[id]
+	s.mu.RUnlock()
+	return o, ok
+}
+
+// Set stores o under id, replacing any existing entry.
+func (sm *ShardedMap) Set(id int64, o *Order) {
+	s := sm.shard(id)
+	s.mu.Lock()
+	s.m[id] = o
+	s.mu.Unlock()
+}
+
+// Delete removes the entry stored under id, if any.
+func (sm *ShardedMap) Delete(id int64) {
+	s := sm.shard(id)
+	s.mu.Lock()
+	delete(s.m, id)
+	s.mu.Unlock()
+}
+
+// Len returns the total number of entries across all shards.
+func (sm *ShardedMap) Len() int {
+	var n int
+	for i := range sm.shards {
+		s := &sm.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
@@ -0,0 +1,192 @@
+package benchmarks
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestShardedMap_SetGetDelete(t *testing.T) {
+	sm := NewShardedMap()
+
+	if _, ok := sm.Get(1); ok {
+		t.Fatal("Get on empty map reported a hit")
+	}
+
+	o := &Order{Price: 10, Qty: 1}
+	sm.Set(1, o)
+	if got, ok := sm.Get(1); !ok || got != o {
+		t.Fatalf("Get(1) = %p, %v, want %p, true", got, ok, o)
+	}
+
+	replacement := &Order{Price: 20, Qty: 2}
+	sm.Set(1, replacement)
+	if got, _ := sm.Get(1); got != replacement {
+		t.Errorf("Get(1) after overwrite = %p, want %p", got, replacement)
+	}
+
+	sm.Delete(1)
+	if _, ok := sm.Get(1); ok {
+		t.Error("Get(1) after Delete reported a hit")
+	}
+	sm.Delete(1) // deleting a missing key is a no-op
+}
+
+func TestShardedMap_NegativeKeys(t *testing.T) {
+	sm := NewShardedMap()
+
+	for id := int64(-20); id < 20; id++ {
+		sm.Set(id, &Order{Qty: id})
+	}
+	for id := int64(-20); id < 20; id++ {
+		if o, ok := sm.Get(id); !ok || o.Qty != id {
+			t.Fatalf("Get(%d) = %v, %v", id, o, ok)
+		}
+	}
+	if sm.Len() != 40 {
+		t.Errorf("Len = %d, want 40", sm.Len())
+	}
+}
+
+func TestShardedMap_Concurrent(t *testing.T) {
+	sm := NewShardedMap()
+
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Go(func() {
+			for i := range 1000 {
+				id := int64(w*1000 + i)
+				sm.Set(id, &Order{Qty: id})
+				if _, ok := sm.Get(id); !ok {
+					t.Errorf("Get(%d) missed after Set", id)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if sm.Len() != 8000 {
+		t.Errorf("Len = %d, want 8000", sm.Len())
+	}
+}
+
+// orderStore is the common surface of the concurrent map implementations under benchmark.
+type orderStore interface {
+	Get(id int64) (*Order, bool)
+	Set(id int64, o *Order)
+	Delete(id int64)
+}
+
+type rwMutexMap struct {
+	mu sync.RWMutex
+	m  map[int64]*Order
+}
+
+func (m *rwMutexMap) Get(id int64) (*Order, bool) {
+	m.mu.RLock()
+	o, ok := m.m[id]
+	m.mu.RUnlock()
+	return o, ok
+}
+
+func (m *rwMutexMap) Set(id int64, o *Order) {
+	m.mu.Lock()
+	m.m[id] = o
+	m.mu.Unlock()
+}
+
+func (m *rwMutexMap) Delete(id int64) {
+	m.mu.Lock()
+	delete(m.m, id)
+	m.mu.Unlock()
+}
+
+type syncMap struct {
+	m sync.Map
+}
+
+func (m *syncMap) Get(id int64) (*Order, bool) {
+	v, ok := m.m.Load(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*Order), true
+}
+
+func (m *syncMap) Set(id int64, o *Order) {
+	m.m.Store(id, o)
+}
+
+func (m *syncMap) Delete(id int64) {
+	m.m.Delete(id)
+}
+
+// storeKeys is the key space the concurrent map benchmarks operate on.
+const storeKeys = 1024
+
+var storeImpls = []struct {
+	name string
+	new  func() orderStore
+}{
+	{name: "syncmap", new: func() orderStore { return &syncMap{} }},
+	{name: "rwmutex", new: func() orderStore { return &rwMutexMap{m: make(map[int64]*Order)} }},
+	{name: "sharded", new: func() orderStore { return NewShardedMap() }},
+}
+
+// storeWorkloads expresses each read/write mix as writes per 100 operations.
+var storeWorkloads = []struct {
+	name         string
+	writesPer100 int
+}{
+	{name: "read99", writesPer100: 1},
+	{name: "read50", writesPer100: 50},
+	{name: "read10", writesPer100: 90},
+}
+
+// BenchmarkConcurrentMap compares sync.Map, map+RWMutex, and ShardedMap across read/write mixes.
+// Each goroutine draws keys from its own random stream so goroutines do not walk the key space
+// in lockstep. Writes alternate between inserting a fresh key and deleting it again, so the
+// write path exercises map growth and removal rather than only overwriting existing entries.
+func BenchmarkConcurrentMap(b *testing.B) {
+	orders := make([]Order, storeKeys)
+
+	for _, w := range storeWorkloads {
+		for _, impl := range storeImpls {
+			b.Run(fmt.Sprintf("%s/%s", w.name, impl.name), func(b *testing.B) {
+				s := impl.new()
+				for i := range orders {
+					s.Set(int64(i), &orders[i])
+				}
+
+				var goroutines atomic.Uint64
+				b.ReportAllocs()
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					g := goroutines.Add(1)
+					r := rand.New(rand.NewPCG(g, g))
+
+					// Keys above storeKeys are private to this goroutine.
+					base := storeKeys + int64(g)<<32
+					var seq int64
+					inserted := false
+					for pb.Next() {
+						if r.IntN(100) < w.writesPer100 {
+							id := base + seq
+							if inserted {
+								s.Delete(id)
+								seq++
+							} else {
+								s.Set(id, &orders[seq%storeKeys])
+							}
+							inserted = !inserted
+						} else {
+							s.Get(r.Int64N(storeKeys))
+						}
+					}
+				})
+			})
+		}
+	}
+}
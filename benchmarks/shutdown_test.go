@@ -0,0 +1,68 @@
+package benchmarks
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"golang-backend/pkg/shutdown"
+)
+
+// mutexGuard is the accepting flag guarded by a mutex, the obvious alternative to the
+// atomic inside shutdown.Manager.
+type mutexGuard struct {
+	mu        sync.Mutex
+	accepting bool
+}
+
+func (g *mutexGuard) Accepting() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.accepting
+}
+
+// rwMutexGuard takes only a read lock per check, so readers do not exclude each other but
+// still write the lock's reader count.
+type rwMutexGuard struct {
+	mu        sync.RWMutex
+	accepting bool
+}
+
+func (g *rwMutexGuard) Accepting() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.accepting
+}
+
+// BenchmarkAcceptingGuard measures the per-request check a handler makes before taking
+// work during a graceful shutdown: shutdown.Manager's atomic load against a bool behind a
+// Mutex or an RWMutex, from one goroutine and from every P.
+func BenchmarkAcceptingGuard(b *testing.B) {
+	guards := []struct {
+		name      string
+		accepting func() bool
+	}{
+		{name: "atomic", accepting: shutdown.New(time.Second).Accepting},
+		{name: "mutex", accepting: (&mutexGuard{accepting: true}).Accepting},
+		{name: "rwmutex", accepting: (&rwMutexGuard{accepting: true}).Accepting},
+	}
+	for _, g := range guards {
+		b.Run(g.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sinkFound = g.accepting()
+			}
+		})
+		b.Run(g.name+"/parallel", func(b *testing.B) {
+			runWithProcs(b, func(b *testing.B) {
+				b.RunParallel(func(pb *testing.PB) {
+					ok := false
+					for pb.Next() {
+						ok = g.accepting()
+					}
+					sinkFound = ok
+				})
+			})
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package benchmarks
+
+// BuildOrders returns n orders built by appending.
+// When prealloc is true the slice is created with capacity n, so append never
+// has to grow the backing array; otherwise it starts nil and grows by doubling.
+func BuildOrders(n int, prealloc bool) []Order {
+	var orders []Order
+	if prealloc {
+		orders = make([]Order, 0, n)
+	}
+
+	for i := range n {
+		orders = append(orders, Order{
+			Price: float64(i),
+			Qty:   int64(i),
+		})
+	}
+
+	return orders
+}
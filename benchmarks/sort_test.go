@@ -0,0 +1,138 @@
+package benchmarks
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// sortSizes are the slice lengths used by the sorting benchmarks.
+var sortSizes = []int{8, 64, 1_000, 100_000}
+
+// insertionSortMax is the largest unsorted input given to the quadratic insertion sort;
+// beyond it a single op takes seconds.
+const insertionSortMax = 1_000
+
+// insertionSortByPrice sorts orders by ascending Price. It is stable: orders with equal
+// prices keep their relative order.
+func insertionSortByPrice(orders []Order) {
+	for i := 1; i < len(orders); i++ {
+		o := orders[i]
+		j := i
+		for ; j > 0 && orders[j-1].Price > o.Price; j-- {
+			orders[j] = orders[j-1]
+		}
+		orders[j] = o
+	}
+}
+
+// sortSliceByPrice sorts with sort.Slice, going through reflection-based swaps. Not stable.
+func sortSliceByPrice(orders []Order) {
+	sort.Slice(orders, func(i, j int) bool { return orders[i].Price < orders[j].Price })
+}
+
+// sortFuncByPrice sorts with the generic slices.SortFunc. Not stable.
+func sortFuncByPrice(orders []Order) {
+	slices.SortFunc(orders, func(a, b Order) int { return cmp.Compare(a.Price, b.Price) })
+}
+
+var sorters = []struct {
+	name   string
+	sort   func([]Order)
+	stable bool
+}{
+	{name: "sort.Slice", sort: sortSliceByPrice},
+	{name: "slices.SortFunc", sort: sortFuncByPrice},
+	{name: "insertion", sort: insertionSortByPrice, stable: true},
+}
+
+// sortInputs builds the random, already-sorted, and reverse-sorted variants of n orders.
+// Qty records each order's original position so stability can be checked.
+func sortInputs(n int) map[string][]Order {
+	r := rand.New(rand.NewPCG(uint64(n), 42))
+	random := make([]Order, n)
+	for i := range random {
+		random[i] = Order{Price: float64(r.IntN(n/2 + 1)), Qty: int64(i)}
+	}
+
+	sorted := slices.Clone(random)
+	slices.SortStableFunc(sorted, func(a, b Order) int { return cmp.Compare(a.Price, b.Price) })
+
+	reversed := slices.Clone(sorted)
+	slices.Reverse(reversed)
+
+	return map[string][]Order{"random": random, "sorted": sorted, "reversed": reversed}
+}
+
+func TestSorters_MatchReference(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 8, 64, 1_000} {
+		for inputName, input := range sortInputs(n) {
+			want := slices.Clone(input)
+			slices.SortStableFunc(want, func(a, b Order) int { return cmp.Compare(a.Price, b.Price) })
+
+			for _, s := range sorters {
+				got := slices.Clone(input)
+				s.sort(got)
+
+				for i := range got {
+					if got[i].Price != want[i].Price {
+						t.Fatalf("%s n=%d %s: index %d has price %v, want %v", s.name, n, inputName, i, got[i].Price, want[i].Price)
+					}
+				}
+				if s.stable && !slices.Equal(got, want) {
+					t.Errorf("%s n=%d %s: equal-price orders were reordered", s.name, n, inputName)
+				}
+			}
+		}
+	}
+}
+
+// TestInsertionSort_Stable pins the stability guarantee on an input made only of ties.
+// sort.Slice and slices.SortFunc make no such guarantee, so callers needing it must use
+// sort.SliceStable or slices.SortStableFunc.
+func TestInsertionSort_Stable(t *testing.T) {
+	orders := make([]Order, 50)
+	for i := range orders {
+		orders[i] = Order{Price: float64(i % 3), Qty: int64(i)}
+	}
+	insertionSortByPrice(orders)
+
+	for i := 1; i < len(orders); i++ {
+		if orders[i-1].Price == orders[i].Price && orders[i-1].Qty > orders[i].Qty {
+			t.Fatalf("orders %d and %d with price %v reordered", orders[i-1].Qty, orders[i].Qty, orders[i].Price)
+		}
+	}
+}
+
+// BenchmarkSortOrders compares sort.Slice, slices.SortFunc, and insertion sort on random,
+// sorted, and reverse-sorted inputs. The insertion sort is skipped on unsorted inputs above
+// insertionSortMax, where its quadratic cost makes the comparison pointless.
+func BenchmarkSortOrders(b *testing.B) {
+	for _, n := range sortSizes {
+		inputs := sortInputs(n)
+		for _, inputName := range []string{"random", "sorted", "reversed"} {
+			input := inputs[inputName]
+			for _, s := range sorters {
+				b.Run(fmt.Sprintf("%s/n=%d/%s", s.name, n, inputName), func(b *testing.B) {
+					if s.name == "insertion" && inputName != "sorted" && n > insertionSortMax {
+						b.Skipf("quadratic on %s input of %d orders", inputName, n)
+					}
+
+					work := make([]Order, n)
+					b.ReportAllocs()
+					b.ResetTimer()
+					for range b.N {
+						b.StopTimer()
+						copy(work, input)
+						b.StartTimer()
+						s.sort(work)
+					}
+					sinkOrders = work
+				})
+			}
+		}
+	}
+}
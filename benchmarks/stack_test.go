@@ -0,0 +1,127 @@
+package benchmarks
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// stackDepths are the recursion depths; at 4KB per frame, 1000 frames need a ~4MB stack.
+var stackDepths = []int{10, 100, 1000}
+
+// recurseFrames recurses depth times, each frame holding a 4KB local array.
+//
+//go:noinline
+func recurseFrames(depth int) int {
+	var buf [4096]byte
+	buf[depth%len(buf)] = byte(depth)
+	if depth <= 1 {
+		return int(buf[depth%len(buf)])
+	}
+	return int(buf[depth%len(buf)]) + recurseFrames(depth-1)
+}
+
+// iterateFrames computes recurseFrames(depth) in a loop, reusing one 4KB array.
+//
+//go:noinline
+func iterateFrames(depth int) int {
+	var buf [4096]byte
+	total := 0
+	for d := depth; d >= 1; d-- {
+		buf[d%len(buf)] = byte(d)
+		total += int(buf[d%len(buf)])
+	}
+	return total
+}
+
+func TestRecurseFrames_MatchesIterative(t *testing.T) {
+	for _, depth := range []int{1, 2, 255, 256, 257, 1000, 5000} {
+		want := 0
+		for d := 1; d <= depth; d++ {
+			want += d % 256
+		}
+
+		if got := recurseFrames(depth); got != want {
+			t.Errorf("recurseFrames(%d) = %d, want %d", depth, got, want)
+		}
+		if got := iterateFrames(depth); got != want {
+			t.Errorf("iterateFrames(%d) = %d, want %d", depth, got, want)
+		}
+	}
+}
+
+// BenchmarkStackGrowthRecursion compares deep recursion with the iterative equivalent. The
+// warm cases reuse the benchmark goroutine, whose stack has already grown after the first
+// op; the fresh cases run each op on a new goroutine, which starts with a minimal stack and
+// pays for every doubling copy.
+func BenchmarkStackGrowthRecursion(b *testing.B) {
+	impls := []struct {
+		name string
+		fn   func(depth int) int
+	}{
+		{name: "recursive", fn: recurseFrames},
+		{name: "iterative", fn: iterateFrames},
+	}
+
+	for _, depth := range stackDepths {
+		for _, impl := range impls {
+			b.Run(fmt.Sprintf("%s/depth=%d/warm", impl.name, depth), func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					sinkGoroutineResult = int64(impl.fn(depth))
+				}
+			})
+
+			b.Run(fmt.Sprintf("%s/depth=%d/fresh", impl.name, depth), func(b *testing.B) {
+				benchmarkSpawnPerOp(b, func(int64) int64 { return int64(impl.fn(depth)) })
+			})
+		}
+	}
+}
+
+// stackBytesPerGoroutine parks n goroutines that have each run fn and returns the growth in
+// stack memory per goroutine.
+func stackBytesPerGoroutine(n int, fn func(int64) int64) float64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	release := make(chan struct{})
+	var started, exited sync.WaitGroup
+	for i := range n {
+		started.Add(1)
+		exited.Go(func() {
+			sinkGoroutineResult = fn(int64(i))
+			started.Done()
+			<-release
+		})
+	}
+	started.Wait()
+	runtime.ReadMemStats(&after)
+	close(release)
+	exited.Wait()
+
+	return float64(after.StackInuse-before.StackInuse) / float64(n)
+}
+
+// BenchmarkStackGrowthSpawn spawns a goroutine per op whose first call needs either a small
+// frame or a BigObject-sized one, forcing an immediate stack grow. stack-B/goroutine is the
+// stack memory each such goroutine holds while parked, measured over 1000 goroutines.
+func BenchmarkStackGrowthSpawn(b *testing.B) {
+	frames := []struct {
+		name string
+		fn   func(int64) int64
+	}{
+		{name: "smallFrame", fn: smallFrameWork},
+		{name: "bigFrame", fn: bigFrameWork},
+	}
+
+	for _, f := range frames {
+		b.Run(f.name, func(b *testing.B) {
+			benchmarkSpawnPerOp(b, f.fn)
+			b.StopTimer()
+			b.ReportMetric(stackBytesPerGoroutine(1000, f.fn), "stack-B/goroutine")
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package benchmarks
+
+import (
+	"iter"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// orderStream is a source of orders exposed three ways: as a slice for an index loop, as
+// an iter.Seq for range-over-func, and as a channel fed by a generator goroutine.
+type orderStream struct {
+	orders []Order
+}
+
+// Slice returns the orders themselves.
+func (s orderStream) Slice() []Order { return s.orders }
+
+// All returns an iterator over the orders. It stops as soon as yield returns false.
+func (s orderStream) All() iter.Seq[Order] {
+	return func(yield func(Order) bool) {
+		for _, o := range s.orders {
+			if !yield(o) {
+				return
+			}
+		}
+	}
+}
+
+// Chan starts a goroutine sending the orders on an unbuffered channel, which is closed
+// when they run out. The consumer must call stop when done, even after draining the
+// channel; stop ends the generator early if needed and waits for it to exit.
+func (s orderStream) Chan() (ch <-chan Order, stop func()) {
+	out := make(chan Order)
+	done := make(chan struct{})
+	exited := make(chan struct{})
+
+	go func() {
+		defer close(exited)
+		defer close(out)
+		for _, o := range s.orders {
+			select {
+			case out <- o:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return out, func() {
+		once.Do(func() { close(done) })
+		<-exited
+	}
+}
+
+// The consumers below sum each order's notional, taking at most limit orders; a negative
+// limit takes them all.
+
+func sumSlice(s orderStream, limit int) float64 {
+	orders := s.Slice()
+	if limit >= 0 {
+		orders = orders[:min(limit, len(orders))]
+	}
+	var total float64
+	for i := range orders {
+		total += orderValue(orders[i])
+	}
+	return total
+}
+
+func sumSeq(s orderStream, limit int) float64 {
+	var total float64
+	n := 0
+	for o := range s.All() {
+		if n == limit {
+			break
+		}
+		total += orderValue(o)
+		n++
+	}
+	return total
+}
+
+func sumChan(s orderStream, limit int) float64 {
+	ch, stop := s.Chan()
+	defer stop()
+
+	var total float64
+	n := 0
+	for o := range ch {
+		if n == limit {
+			break
+		}
+		total += orderValue(o)
+		n++
+	}
+	return total
+}
+
+var streamConsumers = []struct {
+	name string
+	sum  func(s orderStream, limit int) float64
+}{
+	{name: "slice", sum: sumSlice},
+	{name: "seq", sum: sumSeq},
+	{name: "chan", sum: sumChan},
+}
+
+// waitForGoroutines waits up to a second for the goroutine count to drop to want.
+func waitForGoroutines(t *testing.T, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > want {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines running, want %d", runtime.NumGoroutine(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestOrderStream_SameSequence(t *testing.T) {
+	s := orderStream{orders: sampleOrders(1000)}
+
+	if got := slices.Collect(s.All()); !slices.Equal(got, s.orders) {
+		t.Errorf("All yielded %d orders, not the %d in the slice", len(got), len(s.orders))
+	}
+
+	ch, stop := s.Chan()
+	var got []Order
+	for o := range ch {
+		got = append(got, o)
+	}
+	stop()
+	if !slices.Equal(got, s.orders) {
+		t.Errorf("Chan sent %d orders, not the %d in the slice", len(got), len(s.orders))
+	}
+
+	for _, limit := range []int{-1, 0, 10, 1000, 5000} {
+		want := streamConsumers[0].sum(s, limit)
+		for _, c := range streamConsumers[1:] {
+			if got := c.sum(s, limit); got != want {
+				t.Errorf("limit=%d: %s sum = %v, slice sum = %v", limit, c.name, got, want)
+			}
+		}
+	}
+}
+
+func TestOrderStream_EarlyStopEndsGenerator(t *testing.T) {
+	s := orderStream{orders: sampleOrders(1000)}
+	before := runtime.NumGoroutine()
+
+	for range 100 {
+		sumChan(s, 10)
+	}
+	waitForGoroutines(t, before)
+
+	// stop is idempotent and safe after the generator has finished on its own.
+	ch, stop := s.Chan()
+	for range ch {
+	}
+	stop()
+	stop()
+	waitForGoroutines(t, before)
+}
+
+// BenchmarkOrderStream sums 1M orders consumed by index loop, range-over-func, and channel,
+// and takes just the first 10, where the channel pays to start and stop its generator.
+func BenchmarkOrderStream(b *testing.B) {
+	s := orderStream{orders: sampleOrders(1_000_000)}
+	for _, mode := range []struct {
+		name  string
+		limit int
+	}{{name: "all", limit: -1}, {name: "first=10", limit: 10}} {
+		for _, c := range streamConsumers {
+			b.Run(mode.name+"/"+c.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					sinkTotal = c.sum(s, mode.limit)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package benchmarks
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// counterGoroutines are the goroutine counts used by the shared counter benchmarks.
+var counterGoroutines = []int{1, 4, 16}
+
+// counter is a shared int64 incremented concurrently.
+type counter interface {
+	Inc()
+	Value() int64
+}
+
+type mutexCounter struct {
+	mu sync.Mutex
+	n  int64
+}
+
+func (c *mutexCounter) Inc() {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}
+
+func (c *mutexCounter) Value() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+type rwMutexCounter struct {
+	mu sync.RWMutex
+	n  int64
+}
+
+func (c *rwMutexCounter) Inc() {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}
+
+func (c *rwMutexCounter) Value() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.n
+}
+
+type atomicCounter struct {
+	n int64
+}
+
+func (c *atomicCounter) Inc() {
+	atomic.AddInt64(&c.n, 1)
+}
+
+func (c *atomicCounter) Value() int64 {
+	return atomic.LoadInt64(&c.n)
+}
+
+// chanCounter owns n in a single goroutine fed over one channel.
+// Reads travel on the same channel as increments: with a separate read
+// channel, a Value request could overtake increments still sitting in the
+// buffer and observe a stale count.
+type chanCounter struct {
+	ops  chan chan int64
+	done chan struct{}
+}
+
+func newChanCounter() *chanCounter {
+	c := &chanCounter{
+		ops:  make(chan chan int64, 1024),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(c.done)
+
+		var n int64
+		for reply := range c.ops {
+			if reply == nil {
+				n++
+				continue
+			}
+			reply <- n
+		}
+	}()
+
+	return c
+}
+
+func (c *chanCounter) Inc() {
+	c.ops <- nil
+}
+
+func (c *chanCounter) Value() int64 {
+	reply := make(chan int64)
+	c.ops <- reply
+	return <-reply
+}
+
+// Close stops the owning goroutine. No Inc or Value may be called afterwards.
+func (c *chanCounter) Close() {
+	close(c.ops)
+	<-c.done
+}
+
+var counterImpls = []struct {
+	name string
+	new  func() (counter, func())
+}{
+	{name: "mutex", new: func() (counter, func()) { return &mutexCounter{}, func() {} }},
+	{name: "rwmutex", new: func() (counter, func()) { return &rwMutexCounter{}, func() {} }},
+	{name: "atomic", new: func() (counter, func()) { return &atomicCounter{}, func() {} }},
+	{name: "sharded", new: func() (counter, func()) { return NewShardedCounter(0), func() {} }},
+	{name: "channel", new: func() (counter, func()) {
+		c := newChanCounter()
+		return c, c.Close
+	}},
+}
+
+// TestCounters ensures every approach yields the exact expected count.
+func TestCounters(t *testing.T) {
+	const (
+		goroutines = 16
+		perWorker  = 1000
+	)
+
+	for _, impl := range counterImpls {
+		t.Run(impl.name, func(t *testing.T) {
+			c, closeFn := impl.new()
+			defer closeFn()
+
+			var wg sync.WaitGroup
+			for range goroutines {
+				wg.Go(func() {
+					for range perWorker {
+						c.Inc()
+					}
+				})
+			}
+			wg.Wait()
+
+			if got, want := c.Value(), int64(goroutines*perWorker); got != want {
+				t.Errorf("Value = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+// runWithGoroutines runs body under RunParallel with at least n goroutines.
+// GOMAXPROCS is capped at n, and the parallelism multiplier covers the rest,
+// so n is exact whenever it divides evenly across the available procs.
+func runWithGoroutines(b *testing.B, n int, body func(pb *testing.PB)) {
+	procs := min(n, runtime.GOMAXPROCS(0))
+	prev := runtime.GOMAXPROCS(procs)
+	defer runtime.GOMAXPROCS(prev)
+
+	b.SetParallelism((n + procs - 1) / procs)
+	b.RunParallel(body)
+}
+
+// BenchmarkSharedCounter compares mutex, RWMutex, atomic, and channel-owned counters under contention.
+func BenchmarkSharedCounter(b *testing.B) {
+	for _, impl := range counterImpls {
+		for _, n := range counterGoroutines {
+			b.Run(fmt.Sprintf("%s/goroutines=%d", impl.name, n), func(b *testing.B) {
+				c, closeFn := impl.new()
+				defer closeFn()
+
+				b.ReportAllocs()
+				runWithGoroutines(b, n, func(pb *testing.PB) {
+					for pb.Next() {
+						c.Inc()
+					}
+				})
+			})
+		}
+	}
+}
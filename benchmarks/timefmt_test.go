@@ -0,0 +1,96 @@
+package benchmarks
+
+import (
+	"testing"
+	"time"
+
+	"golang-backend/pkg/bytespool"
+	"golang-backend/pkg/cachedclock"
+)
+
+// timestampLayout is the layout every variant formats with.
+const timestampLayout = time.RFC3339Nano
+
+// newTimestampPool pools buffers large enough for one timestampLayout timestamp.
+func newTimestampPool(tb testing.TB) *bytespool.Pool {
+	tb.Helper()
+
+	p, err := bytespool.New(len(timestampLayout)+16, 256)
+	if err != nil {
+		tb.Fatalf("bytespool.New: %v", err)
+	}
+	return p
+}
+
+// newStartedClock returns a running cachedclock.Clock, stopped when the test ends.
+func newStartedClock(tb testing.TB) *cachedclock.Clock {
+	tb.Helper()
+
+	c, err := cachedclock.New(timestampLayout)
+	if err != nil {
+		tb.Fatalf("cachedclock.New: %v", err)
+	}
+	c.Start()
+	tb.Cleanup(c.Stop)
+	return c
+}
+
+func TestTimestampVariants_Agree(t *testing.T) {
+	p := newTimestampPool(t)
+	c := newStartedClock(t)
+
+	now := time.Now()
+	buf := p.Get()
+	*buf = now.AppendFormat((*buf)[:0], timestampLayout)
+	if got, want := string(*buf), now.Format(timestampLayout); got != want {
+		t.Errorf("AppendFormat = %q, Format = %q", got, want)
+	}
+	p.Put(buf)
+
+	cached, err := time.Parse(timestampLayout, c.String())
+	if err != nil {
+		t.Fatalf("cached timestamp %q does not parse: %v", c.String(), err)
+	}
+	if lag := time.Since(cached); lag < 0 || lag > cachedclock.Granularity {
+		t.Errorf("cached timestamp lags by %v, want within %v", lag, cachedclock.Granularity)
+	}
+}
+
+// BenchmarkTimestamp compares formatting time.Now with Format, with AppendFormat into a
+// pooled buffer, and reading a cachedclock string refreshed once per second, sequentially
+// and from every P.
+func BenchmarkTimestamp(b *testing.B) {
+	p := newTimestampPool(b)
+	c := newStartedClock(b)
+
+	cases := []struct {
+		name string
+		fn   func()
+	}{
+		{name: "Format", fn: func() { sinkString = time.Now().Format(timestampLayout) }},
+		{name: "AppendFormat-pooled", fn: func() {
+			buf := p.Get()
+			*buf = time.Now().AppendFormat((*buf)[:0], timestampLayout)
+			sinkLen = len(*buf)
+			p.Put(buf)
+		}},
+		{name: "cachedclock", fn: func() { sinkString = c.String() }},
+	}
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				tc.fn()
+			}
+		})
+		b.Run(tc.name+"/parallel", func(b *testing.B) {
+			runWithProcs(b, func(b *testing.B) {
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						tc.fn()
+					}
+				})
+			})
+		})
+	}
+}
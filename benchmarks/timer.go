@@ -0,0 +1,27 @@
+package benchmarks
+
+import "time"
+
+// WaitWithTimeout receives from ch, giving up after d. It reuses t instead of allocating a
+// timer per call as time.After does; t must be stopped (or expired) when passed in, and is
+// left stopped on return.
+//
+// Since Go 1.23, Reset and Stop discard a stale expiry, so the drain after a failed Stop is
+// only needed for modules built with older semantics (go < 1.23 in go.mod or
+// GODEBUG=asynctimerchan=1). It is non-blocking, so it is harmless otherwise.
+func WaitWithTimeout[T any](ch <-chan T, t *time.Timer, d time.Duration) (T, bool) {
+	t.Reset(d)
+	select {
+	case v := <-ch:
+		if !t.Stop() {
+			select {
+			case <-t.C:
+			default:
+			}
+		}
+		return v, true
+	case <-t.C:
+		var zero T
+		return zero, false
+	}
+}
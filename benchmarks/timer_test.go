@@ -0,0 +1,120 @@
+package benchmarks
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newStoppedTimer returns a timer in the state WaitWithTimeout expects.
+func newStoppedTimer() *time.Timer {
+	t := time.NewTimer(time.Hour)
+	t.Stop()
+	return t
+}
+
+func TestWaitWithTimeout_Fires(t *testing.T) {
+	ch := make(chan Order)
+	timer := newStoppedTimer()
+
+	if o, ok := WaitWithTimeout(ch, timer, 5*time.Millisecond); ok {
+		t.Fatalf("WaitWithTimeout on empty channel = %+v, true", o)
+	}
+}
+
+func TestWaitWithTimeout_ReceivesFirst(t *testing.T) {
+	ch := make(chan Order, 1)
+	timer := newStoppedTimer()
+
+	for i := range 100 {
+		ch <- Order{Qty: int64(i)}
+		o, ok := WaitWithTimeout(ch, timer, time.Second)
+		if !ok || o.Qty != int64(i) {
+			t.Fatalf("iteration %d: got %+v, %v, want Qty %d, true", i, o, ok, i)
+		}
+	}
+}
+
+// TestWaitWithTimeout_NoStaleExpiry checks that an expiry left over from an earlier use
+// does not end the next wait early, which is the bug drain-before-Reset guards against.
+func TestWaitWithTimeout_NoStaleExpiry(t *testing.T) {
+	const d = 20 * time.Millisecond
+
+	ch := make(chan Order, 1)
+	checkFullWait := func(timer *time.Timer) {
+		t.Helper()
+
+		start := time.Now()
+		if _, ok := WaitWithTimeout(ch, timer, d); ok {
+			t.Fatal("WaitWithTimeout on empty channel reported a value")
+		}
+		if elapsed := time.Since(start); elapsed < d {
+			t.Errorf("wait ended after %v, want at least %v (stale expiry)", elapsed, d)
+		}
+	}
+
+	// A timer that expired without anyone receiving from it.
+	expired := time.NewTimer(time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	checkFullWait(expired)
+
+	// A value and the expiry race: receive first, then let the timer's deadline pass.
+	raced := newStoppedTimer()
+	ch <- Order{}
+	if _, ok := WaitWithTimeout(ch, raced, time.Microsecond); !ok {
+		// The timer may win the select; the value is still queued for the next wait.
+		<-ch
+	}
+	time.Sleep(5 * time.Millisecond)
+	checkFullWait(raced)
+}
+
+// BenchmarkSelectTimeout measures a receive loop guarded by a timeout when the value always
+// arrives first: time.After per iteration, a reused timer, and context.WithTimeout.
+func BenchmarkSelectTimeout(b *testing.B) {
+	const d = time.Second
+	ch := make(chan Order, 1)
+	o := jsonSampleOrder
+
+	b.Run("time.After", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			ch <- o
+			select {
+			case v := <-ch:
+				sinkWireOrder = v
+			case <-time.After(d):
+				b.Fatal("timed out")
+			}
+		}
+	})
+
+	b.Run("reusedTimer", func(b *testing.B) {
+		timer := newStoppedTimer()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			ch <- o
+			v, ok := WaitWithTimeout(ch, timer, d)
+			if !ok {
+				b.Fatal("timed out")
+			}
+			sinkWireOrder = v
+		}
+	})
+
+	b.Run("context.WithTimeout", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			ch <- o
+			ctx, cancel := context.WithTimeout(context.Background(), d)
+			select {
+			case v := <-ch:
+				sinkWireOrder = v
+			case <-ctx.Done():
+				b.Fatal("timed out")
+			}
+			cancel()
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package benchmarks
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang-backend/pkg/ttlcache"
+)
+
+const (
+	ttlEntries = 10_000
+	cacheTTL   = time.Minute
+)
+
+// benchClock is a fake clock cheap enough to read on every Get.
+type benchClock struct {
+	nanos atomic.Int64
+}
+
+func newBenchClock() *benchClock {
+	c := &benchClock{}
+	c.nanos.Store(time.Unix(1_700_000_000, 0).UnixNano())
+	return c
+}
+
+func (c *benchClock) Now() time.Time          { return time.Unix(0, c.nanos.Load()) }
+func (c *benchClock) Advance(d time.Duration) { c.nanos.Add(int64(d)) }
+
+// ttlOrderCache is the surface shared by ttlcache.Lazy and ttlcache.Active.
+type ttlOrderCache interface {
+	Get(key int64) (*Order, bool)
+	Set(key int64, value *Order)
+	Len() int
+}
+
+// ttlCacheImpls builds each cache on clock. The Active sweeper interval is long enough
+// that it never fires during a benchmark; benchmarks that need sweeping call Sweep.
+var ttlCacheImpls = []struct {
+	name string
+	new  func(b *testing.B, clock *benchClock) ttlOrderCache
+}{
+	{name: "lazy", new: func(b *testing.B, clock *benchClock) ttlOrderCache {
+		c, err := ttlcache.NewLazy[int64, Order](cacheTTL, ttlcache.WithClock(clock.Now))
+		if err != nil {
+			b.Fatal(err)
+		}
+		return c
+	}},
+	{name: "active", new: func(b *testing.B, clock *benchClock) ttlOrderCache {
+		c, err := ttlcache.NewActive[int64, Order](b.Context(), cacheTTL, time.Hour, ttlcache.WithClock(clock.Now))
+		if err != nil {
+			b.Fatal(err)
+		}
+		return c
+	}},
+}
+
+var ttlOrders = BuildOrderPointers(ttlEntries)
+
+// BenchmarkTTLCacheGet reads every key in turn after a fill in which expiredPct of the
+// entries were set one TTL earlier. The lazy cache deletes each expired entry on its first
+// read, so its steady state is a plain miss; the active cache keeps re-checking them until
+// it sweeps, which never happens here.
+func BenchmarkTTLCacheGet(b *testing.B) {
+	for _, expiredPct := range []int{0, 50, 90} {
+		for _, impl := range ttlCacheImpls {
+			b.Run(fmt.Sprintf("%s/expired=%d%%", impl.name, expiredPct), func(b *testing.B) {
+				clock := newBenchClock()
+				c := impl.new(b, clock)
+				expired := ttlEntries * expiredPct / 100
+				for i := range ttlEntries {
+					if i == expired {
+						clock.Advance(cacheTTL)
+					}
+					c.Set(int64(i), ttlOrders[i])
+				}
+				if expired == ttlEntries {
+					clock.Advance(cacheTTL)
+				}
+
+				hits := 0
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := range b.N {
+					if _, ok := c.Get(int64(i % ttlEntries)); ok {
+						hits++
+					}
+				}
+				b.ReportMetric(float64(hits)/float64(b.N), "hit-ratio")
+			})
+		}
+	}
+}
+
+// BenchmarkTTLCacheRetention sets a fresh key per op and never reads it back, advancing the
+// clock so each entry expires after ttlEntries ops. The active cache is swept every
+// ttlEntries/10 ops; the lazy cache never removes anything. retained is the number of
+// entries still stored at the end.
+func BenchmarkTTLCacheRetention(b *testing.B) {
+	const sweepEvery = ttlEntries / 10
+	step := cacheTTL / ttlEntries
+
+	for _, impl := range ttlCacheImpls {
+		b.Run(impl.name, func(b *testing.B) {
+			clock := newBenchClock()
+			c := impl.new(b, clock)
+			sweeper, _ := c.(interface{ Sweep() int })
+
+			b.ReportAllocs()
+			for i := range b.N {
+				c.Set(int64(i), ttlOrders[i%ttlEntries])
+				clock.Advance(step)
+				if sweeper != nil && i%sweepEvery == 0 {
+					sweeper.Sweep()
+				}
+			}
+			b.ReportMetric(float64(c.Len()), "retained")
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package benchmarks
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+// routeCounts counts messages per handler: *Order, *SmallObject, *BigObject, unknown.
+type routeCounts [4]int
+
+const (
+	routeOrder = iota
+	routeSmall
+	routeBig
+	routeUnknown
+)
+
+// The handlers stand in for per-type message processing; noinline keeps every router
+// paying for the same calls.
+
+//go:noinline
+func handleOrderMsg(o *Order, c *routeCounts) {
+	c[routeOrder]++
+	o.Qty++
+}
+
+//go:noinline
+func handleSmallMsg(o *SmallObject, c *routeCounts) {
+	c[routeSmall]++
+	o.ID++
+}
+
+//go:noinline
+func handleBigMsg(o *BigObject, c *routeCounts) {
+	c[routeBig]++
+	o.ID++
+}
+
+// routeAssert tries each type in turn with a comma-ok assertion, so a message of the
+// last type checked pays for every earlier miss.
+func routeAssert(m any, c *routeCounts) {
+	if o, ok := m.(*Order); ok {
+		handleOrderMsg(o, c)
+	} else if o, ok := m.(*SmallObject); ok {
+		handleSmallMsg(o, c)
+	} else if o, ok := m.(*BigObject); ok {
+		handleBigMsg(o, c)
+	} else {
+		c[routeUnknown]++
+	}
+}
+
+// routeSwitch picks the handler with a type switch.
+func routeSwitch(m any, c *routeCounts) {
+	switch o := m.(type) {
+	case *Order:
+		handleOrderMsg(o, c)
+	case *SmallObject:
+		handleSmallMsg(o, c)
+	case *BigObject:
+		handleBigMsg(o, c)
+	default:
+		c[routeUnknown]++
+	}
+}
+
+// reflectRoutes maps each message type to a handler taking any, the shape of a router
+// whose handlers are registered at runtime. Each handler still asserts its own type.
+var reflectRoutes = map[reflect.Type]func(any, *routeCounts){
+	reflect.TypeFor[*Order]():       func(m any, c *routeCounts) { handleOrderMsg(m.(*Order), c) },
+	reflect.TypeFor[*SmallObject](): func(m any, c *routeCounts) { handleSmallMsg(m.(*SmallObject), c) },
+	reflect.TypeFor[*BigObject]():   func(m any, c *routeCounts) { handleBigMsg(m.(*BigObject), c) },
+}
+
+// routeReflect looks the handler up by reflect.TypeOf.
+func routeReflect(m any, c *routeCounts) {
+	if h, ok := reflectRoutes[reflect.TypeOf(m)]; ok {
+		h(m, c)
+		return
+	}
+	c[routeUnknown]++
+}
+
+var messageRouters = []struct {
+	name  string
+	route func(m any, c *routeCounts)
+}{
+	{name: "assert", route: routeAssert},
+	{name: "switch", route: routeSwitch},
+	{name: "reflect-map", route: routeReflect},
+}
+
+// messageMix is a type distribution as weights for *Order, *SmallObject, and *BigObject.
+type messageMix struct {
+	name    string
+	weights [3]int
+}
+
+var messageMixes = []messageMix{
+	{name: "uniform", weights: [3]int{1, 1, 1}},
+	// Order is checked first by routeAssert and BigObject last, so the two skews show its
+	// best and worst case.
+	{name: "95pct-order", weights: [3]int{95, 3, 2}},
+	{name: "95pct-big", weights: [3]int{2, 3, 95}},
+}
+
+// routeMessages returns n messages drawn from mix in random order, along with the count
+// of each type. Messages of a type share a few objects, so a million of them does not
+// need a million 8KB BigObjects.
+func routeMessages(n int, mix messageMix, rng *rand.Rand) ([]any, routeCounts) {
+	const distinct = 16
+	pool := make([][]any, 3)
+	for i := range distinct {
+		pool[routeOrder] = append(pool[routeOrder], &Order{Price: float64(i)})
+		pool[routeSmall] = append(pool[routeSmall], &SmallObject{ID: int64(i)})
+		pool[routeBig] = append(pool[routeBig], &BigObject{ID: int64(i)})
+	}
+
+	total := mix.weights[0] + mix.weights[1] + mix.weights[2]
+	msgs := make([]any, n)
+	var counts routeCounts
+	for i := range msgs {
+		kind, r := 0, rng.IntN(total)
+		for r >= mix.weights[kind] {
+			r -= mix.weights[kind]
+			kind++
+		}
+		msgs[i] = pool[kind][rng.IntN(distinct)]
+		counts[kind]++
+	}
+	return msgs, counts
+}
+
+func TestMessageRouters_CountsMatchInput(t *testing.T) {
+	rng := rand.New(rand.NewPCG(8, 2))
+	for _, mix := range messageMixes {
+		msgs, want := routeMessages(10_000, mix, rng)
+		// An unroutable message must reach the fallback rather than a handler.
+		msgs = append(msgs, "not a message", SmallObject{})
+		want[routeUnknown] += 2
+
+		for _, r := range messageRouters {
+			var got routeCounts
+			for _, m := range msgs {
+				r.route(m, &got)
+			}
+			if got != want {
+				t.Errorf("%s/%s: counts = %v, want %v", mix.name, r.name, got, want)
+			}
+		}
+	}
+}
+
+func TestRouteMessages_FollowsMix(t *testing.T) {
+	rng := rand.New(rand.NewPCG(8, 2))
+	_, counts := routeMessages(100_000, messageMixes[1], rng)
+	if share := float64(counts[routeOrder]) / 100_000; share < 0.94 || share > 0.96 {
+		t.Errorf("95pct-order mix gave %.3f orders, want about 0.95", share)
+	}
+}
+
+// routeMessageCount is the number of messages routed per op.
+const routeMessageCount = 1_000_000
+
+// BenchmarkMessageRouting routes 1M mixed messages with chained type assertions, a type
+// switch, and a reflect.TypeOf handler map, for a uniform mix and mixes dominated by the
+// first and the last type the assertion chain checks.
+func BenchmarkMessageRouting(b *testing.B) {
+	rng := rand.New(rand.NewPCG(8, 2))
+	for _, mix := range messageMixes {
+		msgs, _ := routeMessages(routeMessageCount, mix, rng)
+		for _, r := range messageRouters {
+			b.Run(mix.name+"/"+r.name, func(b *testing.B) {
+				b.ReportAllocs()
+				var c routeCounts
+				for range b.N {
+					for _, m := range msgs {
+						r.route(m, &c)
+					}
+				}
+				sinkLen = c[routeOrder]
+				b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*routeMessageCount), "ns/msg")
+			})
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package benchmarks
+
+import (
+	"io"
+	"testing"
+
+	"golang-backend/pkg/unsafeconv"
+)
+
+// BenchmarkBytesKeyLookup looks up a map[string]*Order with a []byte key: via a copying helper,
+// via the compiler-optimized m[string(b)], and via unsafeconv.B2S.
+func BenchmarkBytesKeyLookup(b *testing.B) {
+	orders := make([]Order, 1024)
+	m := make(map[string]*Order, len(orders))
+	for i := range orders {
+		m[formatOrderKey(int64(i))] = &orders[i]
+	}
+	key := []byte(formatOrderKey(512))
+
+	b.Run("copy", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkOrder = m[keyFromBytes(key)]
+		}
+	})
+
+	b.Run("inline", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkOrder = m[string(key)]
+		}
+	})
+
+	b.Run("unsafe", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkOrder = m[unsafeconv.B2S(key)]
+		}
+	})
+}
+
+// connWriter is package-level so the compiler cannot devirtualize Write and prove the
+// argument does not escape.
+var connWriter io.Writer = &countingWriter{}
+
+// BenchmarkStringToWriter writes a string to an io.Writer, as when framing a response onto a
+// connection: []byte(s) copies because the slice escapes through the interface call.
+func BenchmarkStringToWriter(b *testing.B) {
+	s := formatOrderKey(12345)
+	w := connWriter
+
+	b.Run("copy", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := w.Write([]byte(s)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("unsafe", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := w.Write(unsafeconv.S2B(s)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// appending into a reused buffer is the safe way to avoid the copy allocation.
+	b.Run("appendBuffer", func(b *testing.B) {
+		buf := make([]byte, 0, 64)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			buf = append(buf[:0], s...)
+			if _, err := w.Write(buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package benchmarks
+
+import (
+	"fmt"
+	"testing"
+)
+
+// retainedOrders is where keepOrders stores its arguments.
+var retainedOrders []*Order
+
+// keepOrders retains its variadic slice, so the compiler must heap-allocate the backing
+// array at every call site that passes at least one argument.
+//
+//go:noinline
+func keepOrders(orders ...*Order) {
+	retainedOrders = orders
+}
+
+var sinkQty int64
+
+func TestSumQty(t *testing.T) {
+	a, b, c := &Order{Qty: 1}, &Order{Qty: 2}, &Order{Qty: 4}
+
+	if got := SumQty(); got != 0 {
+		t.Errorf("SumQty() = %d, want 0", got)
+	}
+	if got := SumQty(a, b, c); got != 7 {
+		t.Errorf("SumQty(a, b, c) = %d, want 7", got)
+	}
+	if got := SumQtySlice([]*Order{a, b, c}); got != 7 {
+		t.Errorf("SumQtySlice = %d, want 7", got)
+	}
+	if got := SumQty([]*Order{a, c}...); got != 5 {
+		t.Errorf("SumQty(slice...) = %d, want 5", got)
+	}
+}
+
+// TestAllocs_Variadic records which call shapes allocate with the current compiler.
+// A failure here means escape analysis changed, not necessarily that something broke.
+func TestAllocs_Variadic(t *testing.T) {
+	orders := BuildOrderPointers(10)
+	a, b, c := orders[0], orders[1], orders[2]
+
+	tests := []struct {
+		name string
+		want float64
+		fn   func()
+	}{
+		{name: "SumQty()", want: 0, fn: func() { sinkQty = SumQty() }},
+		{name: "SumQty(a)", want: 0, fn: func() { sinkQty = SumQty(a) }},
+		{name: "SumQty(a, b, c)", want: 0, fn: func() { sinkQty = SumQty(a, b, c) }},
+		{name: "SumQty(orders...)", want: 0, fn: func() { sinkQty = SumQty(orders...) }},
+		{name: "SumQtySlice(orders)", want: 0, fn: func() { sinkQty = SumQtySlice(orders) }},
+		{name: "keepOrders()", want: 0, fn: func() { keepOrders() }},
+		{name: "keepOrders(a)", want: 1, fn: func() { keepOrders(a) }},
+		{name: "keepOrders(a, b, c)", want: 1, fn: func() { keepOrders(a, b, c) }},
+		{name: "keepOrders(orders...)", want: 0, fn: func() { keepOrders(orders...) }},
+	}
+
+	for _, tt := range tests {
+		assertAllocs(t, tt.name, tt.want, tt.fn)
+	}
+}
+
+// BenchmarkVariadic calls SumQty with 0, 1, 3, and 10 arguments, a pre-built slice, and the
+// retaining keepOrders, whose variadic slice escapes.
+func BenchmarkVariadic(b *testing.B) {
+	o := BuildOrderPointers(10)
+
+	b.Run("SumQty/args=0", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkQty = SumQty()
+		}
+	})
+
+	b.Run("SumQty/args=1", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkQty = SumQty(o[0])
+		}
+	})
+
+	b.Run("SumQty/args=3", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkQty = SumQty(o[0], o[1], o[2])
+		}
+	})
+
+	b.Run("SumQty/args=10", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkQty = SumQty(o[0], o[1], o[2], o[3], o[4], o[5], o[6], o[7], o[8], o[9])
+		}
+	})
+
+	b.Run(fmt.Sprintf("SumQtySlice/len=%d", len(o)), func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sinkQty = SumQtySlice(o)
+		}
+	})
+
+	b.Run("keepOrders/args=0", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			keepOrders()
+		}
+	})
+
+	b.Run("keepOrders/args=3", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			keepOrders(o[0], o[1], o[2])
+		}
+	})
+}
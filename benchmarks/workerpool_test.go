@@ -0,0 +1,80 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"golang-backend/pkg/workerpool"
+)
+
+// workerPoolTasks is the number of small tasks processed per benchmark op.
+const workerPoolTasks = 100_000
+
+var workerPoolSizes = []int{4, 16, 64}
+
+// smallTask does a constant amount of work on a SmallObject built from id.
+func smallTask(id int64, sum *atomic.Int64) {
+	o := SmallObject{ID: id, Type: int(id % 7)}
+	sum.Add(o.ID * int64(o.Type))
+}
+
+// BenchmarkTasksGoroutinePerTask spawns one goroutine per task.
+func BenchmarkTasksGoroutinePerTask(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		var sum atomic.Int64
+		var wg sync.WaitGroup
+		for i := range workerPoolTasks {
+			wg.Go(func() {
+				smallTask(int64(i), &sum)
+			})
+		}
+		wg.Wait()
+	}
+}
+
+// BenchmarkTasksBoundedSpawn spawns one goroutine per task, but at most limit at a time (errgroup SetLimit style).
+func BenchmarkTasksBoundedSpawn(b *testing.B) {
+	for _, limit := range workerPoolSizes {
+		b.Run(fmt.Sprintf("limit=%d", limit), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				var sum atomic.Int64
+				var wg sync.WaitGroup
+				sem := make(chan struct{}, limit)
+				for i := range workerPoolTasks {
+					sem <- struct{}{}
+					wg.Go(func() {
+						defer func() { <-sem }()
+						smallTask(int64(i), &sum)
+					})
+				}
+				wg.Wait()
+			}
+		})
+	}
+}
+
+// BenchmarkTasksWorkerPool submits every task to a fixed set of long-lived workers.
+func BenchmarkTasksWorkerPool(b *testing.B) {
+	for _, workers := range workerPoolSizes {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				var sum atomic.Int64
+				p := workerpool.New(workers)
+				for i := range workerPoolTasks {
+					if err := p.Submit(func() { smallTask(int64(i), &sum) }); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if err := p.Shutdown(context.Background()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Command benchdiff compares two sets of go test -bench results and fails when any
+// benchmark regressed by more than a threshold.
+//
+// Compare two saved outputs:
+//
+//	go test -run '^$' -bench . -benchmem ./benchmarks > old.txt
+//	# change the code
+//	go test -run '^$' -bench . -benchmem ./benchmarks > new.txt
+//	benchdiff -threshold 5 old.txt new.txt
+//
+// Or run the same benchmarks twice back to back, which measures run-to-run noise and is a
+// good way to choose a threshold for the machine:
+//
+//	benchdiff -bench 'BenchmarkSortOrders' -pkg ./benchmarks
+//
+// When a benchmark appears several times in one input (from -count), its values are averaged.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"text/tabwriter"
+
+	"golang-backend/pkg/benchparse"
+)
+
+// compared are the metrics benchdiff checks, all of which are worse when higher.
+var compared = []string{benchparse.NsPerOp, benchparse.BytesPerOp, benchparse.AllocsPerOp}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run is main without the process exit: 0 when nothing regressed, 1 on a regression,
+// and 2 on a usage or input error.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("benchdiff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	threshold := fs.Float64("threshold", 10, "fail when a metric grows by more than this `percent`")
+	bench := fs.String("bench", "", "run go test -bench with this `regexp` twice instead of reading files")
+	pkg := fs.String("pkg", "./...", "`package` to benchmark with -bench")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: benchdiff [-threshold percent] old.txt new.txt")
+		fmt.Fprintln(stderr, "       benchdiff [-threshold percent] -bench regexp [-pkg package]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var oldOut, newOut []byte
+	var err error
+	switch {
+	case *bench != "" && fs.NArg() == 0:
+		if oldOut, err = runBenchmarks(*bench, *pkg, stderr); err == nil {
+			newOut, err = runBenchmarks(*bench, *pkg, stderr)
+		}
+	case *bench == "" && fs.NArg() == 2:
+		if oldOut, err = os.ReadFile(fs.Arg(0)); err == nil {
+			newOut, err = os.ReadFile(fs.Arg(1))
+		}
+	default:
+		fs.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "benchdiff:", err)
+		return 2
+	}
+
+	oldSet, err := load(oldOut)
+	if err != nil {
+		fmt.Fprintln(stderr, "benchdiff: old:", err)
+		return 2
+	}
+	newSet, err := load(newOut)
+	if err != nil {
+		fmt.Fprintln(stderr, "benchdiff: new:", err)
+		return 2
+	}
+
+	if regressed := report(stdout, stderr, oldSet, newSet, *threshold); regressed > 0 {
+		fmt.Fprintf(stderr, "benchdiff: %d metric(s) regressed by more than %g%%\n", regressed, *threshold)
+		return 1
+	}
+	return 0
+}
+
+// runBenchmarks runs the benchmarks matching pattern in pkg, returning their output.
+func runBenchmarks(pattern, pkg string, stderr io.Writer) ([]byte, error) {
+	cmd := exec.Command("go", "test", "-run", "^$", "-bench", pattern, "-benchmem", pkg)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go test: %w", err)
+	}
+	return out, nil
+}
+
+// resultSet holds the averaged metrics per benchmark, with names in first-seen order.
+type resultSet struct {
+	names   []string
+	metrics map[string]map[string]float64
+}
+
+// load parses out and averages repeated runs of the same benchmark and GOMAXPROCS.
+func load(out []byte) (resultSet, error) {
+	results, err := benchparse.Parse(bytes.NewReader(out))
+	if err != nil {
+		return resultSet{}, err
+	}
+	if len(results) == 0 {
+		return resultSet{}, errors.New("no benchmark results")
+	}
+
+	set := resultSet{metrics: make(map[string]map[string]float64)}
+	counts := make(map[string]map[string]int)
+	for _, r := range results {
+		name := r.Name
+		if r.Procs != 1 {
+			name = fmt.Sprintf("%s-%d", r.Name, r.Procs)
+		}
+		if _, ok := set.metrics[name]; !ok {
+			set.names = append(set.names, name)
+			set.metrics[name] = make(map[string]float64)
+			counts[name] = make(map[string]int)
+		}
+		for unit, v := range r.Metrics {
+			set.metrics[name][unit] += v
+			counts[name][unit]++
+		}
+	}
+	for name, m := range set.metrics {
+		for unit := range m {
+			m[unit] /= float64(counts[name][unit])
+		}
+	}
+	return set, nil
+}
+
+// delta returns the percentage change from before to after. Growth from zero is +Inf.
+func delta(before, after float64) float64 {
+	if before == 0 {
+		if after == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return (after - before) / before * 100
+}
+
+// report writes the comparison table and returns the number of metrics that grew by more
+// than threshold percent. Benchmarks present in only one set are listed on stderr.
+func report(stdout, stderr io.Writer, oldSet, newSet resultSet, threshold float64) int {
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "benchmark\tmetric\told\tnew\tdelta\t")
+
+	regressed := 0
+	for _, name := range oldSet.names {
+		after, ok := newSet.metrics[name]
+		if !ok {
+			fmt.Fprintf(stderr, "benchdiff: %s missing from new results\n", name)
+			continue
+		}
+		before := oldSet.metrics[name]
+		for _, unit := range compared {
+			b, okOld := before[unit]
+			a, okNew := after[unit]
+			if !okOld || !okNew {
+				continue
+			}
+
+			d := delta(b, a)
+			mark := ""
+			if d > threshold {
+				mark = "  REGRESSED"
+				regressed++
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%.4g\t%.4g\t%+.2f%%%s\t\n", name, unit, b, a, d, mark)
+		}
+	}
+	for _, name := range newSet.names {
+		if _, ok := oldSet.metrics[name]; !ok {
+			fmt.Fprintf(stderr, "benchdiff: %s missing from old results\n", name)
+		}
+	}
+
+	tw.Flush()
+	return regressed
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeResults(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const baseline = `BenchmarkA-8	1000	100 ns/op	16 B/op	1 allocs/op
+BenchmarkB/sub-8	1000	200 ns/op	0 B/op	0 allocs/op
+`
+
+func TestRun_NoRegression(t *testing.T) {
+	oldPath := writeResults(t, "old.txt", baseline)
+	newPath := writeResults(t, "new.txt", `BenchmarkA-8	1000	104 ns/op	16 B/op	1 allocs/op
+BenchmarkB/sub-8	1000	150 ns/op	0 B/op	0 allocs/op
+`)
+
+	var stdout, stderr strings.Builder
+	if code := run([]string{"-threshold", "5", oldPath, newPath}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, want 0; stderr:\n%s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "-25.00%") || !strings.Contains(stdout.String(), "+4.00%") {
+		t.Errorf("table missing expected deltas:\n%s", stdout.String())
+	}
+}
+
+func TestRun_Regression(t *testing.T) {
+	oldPath := writeResults(t, "old.txt", baseline)
+	newPath := writeResults(t, "new.txt", `BenchmarkA-8	1000	100 ns/op	16 B/op	1 allocs/op
+BenchmarkB/sub-8	1000	200 ns/op	8 B/op	1 allocs/op
+`)
+
+	var stdout, stderr strings.Builder
+	if code := run([]string{oldPath, newPath}, &stdout, &stderr); code != 1 {
+		t.Fatalf("exit code %d, want 1", code)
+	}
+	if got := strings.Count(stdout.String(), "REGRESSED"); got != 2 {
+		t.Errorf("%d metrics marked REGRESSED, want 2 (B/op and allocs/op from zero):\n%s", got, stdout.String())
+	}
+}
+
+func TestRun_AveragesRepeatedRuns(t *testing.T) {
+	oldPath := writeResults(t, "old.txt", "BenchmarkA	10	100 ns/op\nBenchmarkA	10	300 ns/op\n")
+	newPath := writeResults(t, "new.txt", "BenchmarkA	10	210 ns/op\n")
+
+	var stdout, stderr strings.Builder
+	if code := run([]string{"-threshold", "5", oldPath, newPath}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, want 0 against the 200 ns/op average:\n%s", code, stdout.String())
+	}
+}
+
+func TestRun_UsageErrors(t *testing.T) {
+	empty := writeResults(t, "empty.txt", "PASS\n")
+	full := writeResults(t, "full.txt", baseline)
+
+	for _, args := range [][]string{
+		{},
+		{full},
+		{"-bench", ".", full},
+		{full, filepath.Join(t.TempDir(), "missing.txt")},
+		{empty, full},
+	} {
+		var stdout, stderr strings.Builder
+		if code := run(args, &stdout, &stderr); code != 2 {
+			t.Errorf("run(%q) = %d, want 2", args, code)
+		}
+	}
+}
+
+func TestDelta(t *testing.T) {
+	for _, tt := range []struct{ before, after, want float64 }{
+		{100, 110, 10},
+		{100, 50, -50},
+		{0, 0, 0},
+		{0, 1, math.Inf(1)},
+	} {
+		if got := delta(tt.before, tt.after); got != tt.want {
+			t.Errorf("delta(%v, %v) = %v, want %v", tt.before, tt.after, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Command gcdemo shows how GOGC and the soft memory limit change how often the garbage
+// collector runs. It allocates BigObjects at a fixed rate, retaining a sliding window of
+// them as the live heap. It does that for a while under each of three settings and prints
+// the collector's work for each:
+//
+//	go run ./cmd/gcdemo -duration 3s -rate 20000
+//
+// The settings are the default GOGC=100, a GOGC=400 that trades memory for fewer cycles,
+// and GOGC=off with a memory limit. The last is what the old "memory ballast" trick
+// approximated: the heap may grow freely up to the limit, and the collector runs only as
+// it gets close.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"golang-backend/benchmarks"
+	"golang-backend/pkg/gcstats"
+)
+
+// gcConfig is one collector setting to measure.
+type gcConfig struct {
+	name    string
+	percent int   // for debug.SetGCPercent; negative turns the collector off
+	limit   int64 // for debug.SetMemoryLimit; math.MaxInt64 means no limit
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run is main without the process exit: 0 on success and 2 on a usage error.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gcdemo", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	duration := fs.Duration("duration", 2*time.Second, "how long to allocate under each configuration")
+	rate := fs.Int("rate", 20_000, "BigObjects (8KB each) to allocate per second")
+	live := fs.Int("live", 1024, "number of the most recent BigObjects kept reachable")
+	limitMiB := fs.Int64("limit", 64, "soft memory limit in `MiB` for the GOGC=off configuration")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 || *duration <= 0 || *rate <= 0 || *live <= 0 || *limitMiB <= 0 {
+		fs.Usage()
+		return 2
+	}
+
+	configs := []gcConfig{
+		{name: "GOGC=100", percent: 100, limit: math.MaxInt64},
+		{name: "GOGC=400", percent: 400, limit: math.MaxInt64},
+		{name: fmt.Sprintf("GOGC=off limit=%dMiB", *limitMiB), percent: -1, limit: *limitMiB << 20},
+	}
+
+	fmt.Fprintf(stdout, "allocating %d BigObjects/s for %v per configuration, %d live\n\n", *rate, *duration, *live)
+	runs := make([]gcstats.Run, 0, len(configs))
+	for _, c := range configs {
+		runs = append(runs, gcstats.Run{Name: c.name, Delta: measure(c, *duration, *rate, *live)})
+	}
+	if err := gcstats.WriteTable(stdout, runs); err != nil {
+		fmt.Fprintln(stderr, "gcdemo:", err)
+		return 1
+	}
+	return 0
+}
+
+// measure applies c, allocates for d, and restores the previous settings. It collects
+// before starting so each configuration begins from the same heap.
+func measure(c gcConfig, d time.Duration, rate, live int) gcstats.Delta {
+	prevPercent := debug.SetGCPercent(c.percent)
+	prevLimit := debug.SetMemoryLimit(c.limit)
+	defer func() {
+		debug.SetGCPercent(prevPercent)
+		debug.SetMemoryLimit(prevLimit)
+	}()
+
+	runtime.GC()
+	before := gcstats.Read()
+	allocate(d, rate, live)
+	return gcstats.Read().Sub(before)
+}
+
+// allocate creates rate BigObjects per second for d, keeping the last live of them
+// reachable. It checks the clock every millisecond and catches up on what is due, so the
+// rate holds even when a tick is late.
+func allocate(d time.Duration, rate, live int) {
+	window := make([]*benchmarks.BigObject, live)
+	ticker := time.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+
+	start := time.Now()
+	allocated := 0
+	for now := start; now.Sub(start) < d; now = <-ticker.C {
+		due := int(now.Sub(start).Seconds() * float64(rate))
+		for ; allocated < due; allocated++ {
+			window[allocated%live] = &benchmarks.BigObject{ID: int64(allocated)}
+		}
+	}
+	runtime.KeepAlive(window)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestRun_PrintsEveryConfig(t *testing.T) {
+	// SetGCPercent has no read-only form, so read it by setting and restoring it. A
+	// negative SetMemoryLimit only reads.
+	prevPercent := debug.SetGCPercent(100)
+	debug.SetGCPercent(prevPercent)
+	prevLimit := debug.SetMemoryLimit(-1)
+
+	var stdout, stderr strings.Builder
+	if code := run([]string{"-duration", "20ms", "-rate", "2000", "-live", "16", "-limit", "32"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, want 0; stderr:\n%s", code, stderr.String())
+	}
+	for _, name := range []string{"GOGC=100", "GOGC=400", "GOGC=off limit=32MiB"} {
+		if !strings.Contains(stdout.String(), "\n"+name+" ") {
+			t.Errorf("no row for %s:\n%s", name, stdout.String())
+		}
+	}
+
+	// run must have restored both settings.
+	if got := debug.SetGCPercent(prevPercent); got != prevPercent {
+		t.Errorf("GC percent left at %d, want %d", got, prevPercent)
+	}
+	if got := debug.SetMemoryLimit(prevLimit); got != prevLimit {
+		t.Errorf("memory limit left at %d, want %d", got, prevLimit)
+	}
+}
+
+func TestRun_UsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-duration", "0s"},
+		{"-rate", "-1"},
+		{"-live", "0"},
+		{"-limit", "0"},
+		{"-nosuchflag"},
+		{"extra"},
+	} {
+		var stdout, stderr strings.Builder
+		if code := run(args, &stdout, &stderr); code != 2 {
+			t.Errorf("run(%q) = %d, want 2", args, code)
+		}
+	}
+}
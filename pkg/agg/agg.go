@@ -0,0 +1,126 @@
+// Package agg provides Sum, Max, and MinBy written three ways: over a []float64, over a
+// slice of the Valuer interface, and generically over any slice with a key function.
+//
+// The three variants return identical results for the same values. They exist to compare
+// what each calling convention costs: the float variants need the values extracted up
+// front, the Valuer variants need a slice of interfaces and a dynamic call per element, and
+// the generic variants call a func value per element.
+package agg
+
+// Valuer is implemented by types that aggregate by a single float64.
+type Valuer interface {
+	Value() float64
+}
+
+// Sum returns the sum of xs, or 0 if xs is empty.
+func Sum(xs []float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum
+}
+
+// Max returns the largest of xs. It reports false if xs is empty.
+func Max(xs []float64) (float64, bool) {
+	if len(xs) == 0 {
+		return 0, false
+	}
+	m := xs[0]
+	for _, x := range xs[1:] {
+		if x > m {
+			m = x
+		}
+	}
+	return m, true
+}
+
+// MinBy returns the index of the first smallest element of xs, or -1 if xs is empty.
+func MinBy(xs []float64) int {
+	if len(xs) == 0 {
+		return -1
+	}
+	idx := 0
+	for i, x := range xs[1:] {
+		if x < xs[idx] {
+			idx = i + 1
+		}
+	}
+	return idx
+}
+
+// SumValues returns the sum of the values in vs, or 0 if vs is empty.
+func SumValues(vs []Valuer) float64 {
+	var sum float64
+	for _, v := range vs {
+		sum += v.Value()
+	}
+	return sum
+}
+
+// MaxValues returns the largest value in vs. It reports false if vs is empty.
+func MaxValues(vs []Valuer) (float64, bool) {
+	if len(vs) == 0 {
+		return 0, false
+	}
+	m := vs[0].Value()
+	for _, v := range vs[1:] {
+		if x := v.Value(); x > m {
+			m = x
+		}
+	}
+	return m, true
+}
+
+// MinByValues returns the index of the first element of vs with the smallest value, or -1
+// if vs is empty.
+func MinByValues(vs []Valuer) int {
+	if len(vs) == 0 {
+		return -1
+	}
+	idx, m := 0, vs[0].Value()
+	for i, v := range vs[1:] {
+		if x := v.Value(); x < m {
+			idx, m = i+1, x
+		}
+	}
+	return idx
+}
+
+// SumFunc returns the sum of f over xs, or 0 if xs is empty.
+func SumFunc[T any](xs []T, f func(T) float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += f(x)
+	}
+	return sum
+}
+
+// MaxFunc returns the largest value of f over xs. It reports false if xs is empty.
+func MaxFunc[T any](xs []T, f func(T) float64) (float64, bool) {
+	if len(xs) == 0 {
+		return 0, false
+	}
+	m := f(xs[0])
+	for _, x := range xs[1:] {
+		if v := f(x); v > m {
+			m = v
+		}
+	}
+	return m, true
+}
+
+// MinByFunc returns the index of the first element of xs with the smallest value of f, or
+// -1 if xs is empty.
+func MinByFunc[T any](xs []T, f func(T) float64) int {
+	if len(xs) == 0 {
+		return -1
+	}
+	idx, m := 0, f(xs[0])
+	for i, x := range xs[1:] {
+		if v := f(x); v < m {
+			idx, m = i+1, v
+		}
+	}
+	return idx
+}
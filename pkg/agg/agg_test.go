@@ -0,0 +1,91 @@
+package agg
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// item is a Valuer with a pointer receiver, like the orders callers aggregate.
+type item struct {
+	v float64
+}
+
+func (it *item) Value() float64 { return it.v }
+
+func itemValue(it item) float64 { return it.v }
+
+// results is what every variant computes over one input.
+type results struct {
+	sum   float64
+	max   float64
+	maxOK bool
+	minBy int
+}
+
+// allVariants runs the float, Valuer, and generic variants over the same values.
+func allVariants(values []float64) map[string]results {
+	items := make([]item, len(values))
+	valuers := make([]Valuer, len(values))
+	for i, v := range values {
+		items[i] = item{v: v}
+		valuers[i] = &items[i]
+	}
+
+	out := make(map[string]results, 3)
+	var r results
+	r.sum = Sum(values)
+	r.max, r.maxOK = Max(values)
+	r.minBy = MinBy(values)
+	out["float64"] = r
+
+	r.sum = SumValues(valuers)
+	r.max, r.maxOK = MaxValues(valuers)
+	r.minBy = MinByValues(valuers)
+	out["valuer"] = r
+
+	r.sum = SumFunc(items, itemValue)
+	r.max, r.maxOK = MaxFunc(items, itemValue)
+	r.minBy = MinByFunc(items, itemValue)
+	out["generic"] = r
+	return out
+}
+
+func TestVariants_EdgeCases(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   results
+	}{
+		{name: "nil", values: nil, want: results{minBy: -1}},
+		{name: "empty", values: []float64{}, want: results{minBy: -1}},
+		{name: "single", values: []float64{-2.5}, want: results{sum: -2.5, max: -2.5, maxOK: true}},
+		{name: "all negative", values: []float64{-3, -1, -2}, want: results{sum: -6, max: -1, maxOK: true, minBy: 0}},
+		{name: "tied minimum", values: []float64{4, 1, 7, 1}, want: results{sum: 13, max: 7, maxOK: true, minBy: 1}},
+	}
+	for _, tt := range tests {
+		for variant, got := range allVariants(tt.values) {
+			if got != tt.want {
+				t.Errorf("%s/%s: got %+v, want %+v", tt.name, variant, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestVariants_Agree(t *testing.T) {
+	rng := rand.New(rand.NewPCG(6, 8))
+	for _, n := range []int{2, 3, 100, 10_000} {
+		values := make([]float64, n)
+		for i := range values {
+			// A coarse grid makes ties common.
+			values[i] = float64(rng.IntN(200)-100) / 8
+		}
+
+		all := allVariants(values)
+		want := all["float64"]
+		for variant, got := range all {
+			if got != want {
+				t.Errorf("n=%d: %s = %+v, float64 = %+v", n, variant, got, want)
+			}
+		}
+	}
+}
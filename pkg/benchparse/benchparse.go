@@ -0,0 +1,85 @@
+// Package benchparse parses the text output of go test -bench.
+package benchparse
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Standard metric units written by the testing package.
+const (
+	NsPerOp     = "ns/op"
+	BytesPerOp  = "B/op"
+	AllocsPerOp = "allocs/op"
+)
+
+// Result is one benchmark result line.
+type Result struct {
+	// Name is the full benchmark name, including sub-benchmarks, without the -GOMAXPROCS suffix.
+	Name string
+	// Procs is the GOMAXPROCS suffix, or 1 when the name has none.
+	Procs int
+	// Iterations is b.N for the run.
+	Iterations int64
+	// Metrics maps each unit on the line, standard or custom, to its value.
+	// A unit the line did not report, such as allocs/op without -benchmem, is absent.
+	Metrics map[string]float64
+}
+
+// ParseLine parses a single result line such as
+//
+//	BenchmarkEncode/json-8   1000000   1234 ns/op   512 B/op   3 allocs/op
+//
+// It reports false for anything else, including the bare name lines printed with -v.
+func ParseLine(line string) (Result, bool) {
+	fields := strings.Fields(line)
+	// A name, an iteration count, and at least one value/unit pair.
+	if len(fields) < 4 || len(fields)%2 != 0 || !strings.HasPrefix(fields[0], "Benchmark") {
+		return Result{}, false
+	}
+
+	iterations, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || iterations <= 0 {
+		return Result{}, false
+	}
+
+	r := Result{Iterations: iterations, Metrics: make(map[string]float64, (len(fields)-2)/2)}
+	r.Name, r.Procs = splitProcs(fields[0])
+	for i := 2; i < len(fields); i += 2 {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return Result{}, false
+		}
+		r.Metrics[fields[i+1]] = v
+	}
+	return r, true
+}
+
+// splitProcs strips a trailing -N from name. A sub-benchmark whose own name ends in -digits
+// is indistinguishable from the suffix, the same ambiguity the standard tools accept.
+func splitProcs(name string) (string, int) {
+	i := strings.LastIndexByte(name, '-')
+	if i < 0 || i < strings.LastIndexByte(name, '/') {
+		return name, 1
+	}
+	procs, err := strconv.Atoi(name[i+1:])
+	if err != nil || procs <= 0 {
+		return name, 1
+	}
+	return name[:i], procs
+}
+
+// Parse reads go test -bench output and returns every result line in order, skipping
+// headers, logs, and PASS/ok lines.
+func Parse(r io.Reader) ([]Result, error) {
+	var results []Result
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if res, ok := ParseLine(sc.Text()); ok {
+			results = append(results, res)
+		}
+	}
+	return results, sc.Err()
+}
@@ -0,0 +1,110 @@
+package benchparse
+
+import (
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Result
+		ok   bool
+	}{
+		{
+			name: "full benchmem line",
+			line: "BenchmarkEncode-8   \t 1000000\t      1234 ns/op\t     512 B/op\t       3 allocs/op",
+			want: Result{Name: "BenchmarkEncode", Procs: 8, Iterations: 1000000,
+				Metrics: map[string]float64{NsPerOp: 1234, BytesPerOp: 512, AllocsPerOp: 3}},
+			ok: true,
+		},
+		{
+			name: "sub-benchmarks with slashes and procs suffix",
+			line: "BenchmarkSortOrders/slices.SortFunc/n=1000/random-16   5000   240518 ns/op",
+			want: Result{Name: "BenchmarkSortOrders/slices.SortFunc/n=1000/random", Procs: 16, Iterations: 5000,
+				Metrics: map[string]float64{NsPerOp: 240518}},
+			ok: true,
+		},
+		{
+			name: "no procs suffix when GOMAXPROCS is 1",
+			line: "BenchmarkDispatch/switch   300000000   3.9 ns/op",
+			want: Result{Name: "BenchmarkDispatch/switch", Procs: 1, Iterations: 300000000,
+				Metrics: map[string]float64{NsPerOp: 3.9}},
+			ok: true,
+		},
+		{
+			name: "dash inside an earlier path element is not a suffix",
+			line: "BenchmarkMapHint/size-64/lookup   1000   10 ns/op",
+			want: Result{Name: "BenchmarkMapHint/size-64/lookup", Procs: 1, Iterations: 1000,
+				Metrics: map[string]float64{NsPerOp: 10}},
+			ok: true,
+		},
+		{
+			name: "missing allocs columns",
+			line: "BenchmarkNoMem-4   2000   5000 ns/op",
+			want: Result{Name: "BenchmarkNoMem", Procs: 4, Iterations: 2000,
+				Metrics: map[string]float64{NsPerOp: 5000}},
+			ok: true,
+		},
+		{
+			name: "scientific notation and custom metrics",
+			line: "BenchmarkGCPressure-2   5   2.33163231e+08 ns/op   9.472e+08 heap-alloc-bytes/op   1.5e-05 num-GC/op",
+			want: Result{Name: "BenchmarkGCPressure", Procs: 2, Iterations: 5,
+				Metrics: map[string]float64{NsPerOp: 2.33163231e8, "heap-alloc-bytes/op": 9.472e8, "num-GC/op": 1.5e-5}},
+			ok: true,
+		},
+		{name: "bare name line printed by -v", line: "BenchmarkEncode"},
+		{name: "header", line: "goos: linux"},
+		{name: "package summary", line: "ok  \tgolang-backend/benchmarks\t12.3s"},
+		{name: "PASS", line: "PASS"},
+		{name: "non-numeric iterations", line: "BenchmarkX-8   lots   12 ns/op"},
+		{name: "non-numeric value", line: "BenchmarkX-8   100   fast ns/op"},
+		{name: "dangling value without unit", line: "BenchmarkX-8   100   12 ns/op   7"},
+		{name: "log line starting with Benchmark", line: "BenchmarkX: skipped on this platform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseLine(tt.line)
+			if ok != tt.ok {
+				t.Fatalf("ParseLine(%q) ok = %v, want %v", tt.line, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if got.Name != tt.want.Name || got.Procs != tt.want.Procs || got.Iterations != tt.want.Iterations ||
+				!maps.Equal(got.Metrics, tt.want.Metrics) {
+				t.Errorf("ParseLine(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_SkipsNonResultLines(t *testing.T) {
+	out := `goos: linux
+goarch: amd64
+pkg: golang-backend/benchmarks
+cpu: Some CPU
+BenchmarkA-8   	100	  10.5 ns/op	   0 B/op	   0 allocs/op
+--- BENCH: BenchmarkB-8
+    b_test.go:12: log output
+BenchmarkB/sub-8	200	  20 ns/op
+PASS
+ok  	golang-backend/benchmarks	1.234s
+`
+	got, err := Parse(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	var names []string
+	for _, r := range got {
+		names = append(names, r.Name)
+	}
+	if want := []string{"BenchmarkA", "BenchmarkB/sub"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("parsed %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,90 @@
+// Package bloom provides a Bloom filter: a fixed-size bit set that answers "definitely not
+// present" or "maybe present" for a key, in a small fraction of the memory a set of the
+// keys themselves would take.
+package bloom
+
+import (
+	"errors"
+	"hash/maphash"
+	"math"
+	"math/bits"
+)
+
+// ErrInvalidConfig is returned when the expected key count is not positive or the
+// false-positive rate is not strictly between 0 and 1.
+var ErrInvalidConfig = errors.New("bloom: need n > 0 and 0 < fpRate < 1")
+
+// Filter is a Bloom filter sized for an expected number of keys and false-positive rate.
+// It never reports an added key as absent; a key never added is reported as present with
+// about the configured probability, rising once more keys than expected have been added.
+//
+// A Filter is not safe for concurrent use.
+type Filter struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    int    // bits set per key
+	seed maphash.Seed
+}
+
+// New creates a Filter for n keys with the target false-positive rate fpRate, using the
+// usual optimum of m = -n·ln(p)/ln(2)² bits and k = (m/n)·ln(2) hashes.
+func New(n int, fpRate float64) (*Filter, error) {
+	if n <= 0 || !(fpRate > 0 && fpRate < 1) {
+		return nil, ErrInvalidConfig
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	m = max(m, 64)
+	k := max(int(math.Round(float64(m)/float64(n)*math.Ln2)), 1)
+	return &Filter{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+		seed: maphash.MakeSeed(),
+	}, nil
+}
+
+// hashes derives the two hashes the k bit positions are built from (Kirsch and
+// Mitzenmacher's double hashing), so each key is hashed by maphash only once. The second
+// hash is forced odd so the sequence h1 + i·h2 does not repeat early.
+func (f *Filter) hashes(key []byte) (h1, h2 uint64) {
+	h1 = maphash.Bytes(f.seed, key)
+	h2 = bits.RotateLeft64(h1, 32)*0x9e3779b97f4a7c15 | 1
+	return h1, h2
+}
+
+// index maps h onto [0, m) with a multiply-high instead of a division.
+func (f *Filter) index(h uint64) uint64 {
+	hi, _ := bits.Mul64(h, f.m)
+	return hi
+}
+
+// Add records key in the filter.
+func (f *Filter) Add(key []byte) {
+	h1, h2 := f.hashes(key)
+	for i := range f.k {
+		b := f.index(h1 + uint64(i)*h2)
+		f.bits[b/64] |= 1 << (b % 64)
+	}
+}
+
+// MayContain reports whether key may have been added. False means it definitely was not.
+func (f *Filter) MayContain(key []byte) bool {
+	h1, h2 := f.hashes(key)
+	for i := range f.k {
+		b := f.index(h1 + uint64(i)*h2)
+		if f.bits[b/64]&(1<<(b%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SizeBytes returns the memory held by the filter's bit set.
+func (f *Filter) SizeBytes() int {
+	return len(f.bits) * 8
+}
+
+// HashCount returns the number of bits set per key.
+func (f *Filter) HashCount() int {
+	return f.k
+}
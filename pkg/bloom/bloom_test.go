@@ -0,0 +1,114 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// corpusKey writes key number i into buf, as an 8-byte id behind a short prefix.
+func corpusKey(buf []byte, i int) []byte {
+	buf = append(buf[:0], "order:"...)
+	return binary.BigEndian.AppendUint64(buf, uint64(i))
+}
+
+func newFilter(t *testing.T, n int, fpRate float64) *Filter {
+	t.Helper()
+	f, err := New(n, fpRate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestNew_RejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		n      int
+		fpRate float64
+	}{
+		{0, 0.01}, {-1, 0.01}, {100, 0}, {100, 1}, {100, -0.5}, {100, 2}, {100, math.NaN()},
+	}
+	for _, tt := range tests {
+		if f, err := New(tt.n, tt.fpRate); err != ErrInvalidConfig || f != nil {
+			t.Errorf("New(%d, %v) = %v, %v; want nil, ErrInvalidConfig", tt.n, tt.fpRate, f, err)
+		}
+	}
+}
+
+func TestNew_Sizing(t *testing.T) {
+	tests := []struct {
+		n         int
+		fpRate    float64
+		wantBytes int
+		wantK     int
+	}{
+		// 1M keys at 1%: 9,585,059 bits in 149,767 words, 7 hashes.
+		{n: 1_000_000, fpRate: 0.01, wantBytes: 149_767 * 8, wantK: 7},
+		// 0.1% costs half as many bits again, 14,377,588, and 10 hashes.
+		{n: 1_000_000, fpRate: 0.001, wantBytes: 224_650 * 8, wantK: 10},
+		// Tiny filters still get a whole word.
+		{n: 1, fpRate: 0.5, wantBytes: 8, wantK: 44},
+	}
+	for _, tt := range tests {
+		f := newFilter(t, tt.n, tt.fpRate)
+		if f.SizeBytes() != tt.wantBytes || f.HashCount() != tt.wantK {
+			t.Errorf("New(%d, %v): %d bytes, %d hashes; want %d and %d",
+				tt.n, tt.fpRate, f.SizeBytes(), f.HashCount(), tt.wantBytes, tt.wantK)
+		}
+	}
+}
+
+func TestMayContain_EmptyFilter(t *testing.T) {
+	f := newFilter(t, 1000, 0.01)
+	var buf []byte
+	for i := range 1000 {
+		if f.MayContain(corpusKey(buf, i)) {
+			t.Fatalf("empty filter reports key %d present", i)
+		}
+	}
+	if f.MayContain(nil) {
+		t.Error("empty filter reports the empty key present")
+	}
+}
+
+func TestMayContain_NoFalseNegativesAndBoundedFalsePositives(t *testing.T) {
+	const n = 1_000_000
+	for _, target := range []float64{0.01, 0.001} {
+		f := newFilter(t, n, target)
+		var buf []byte
+		for i := range n {
+			f.Add(corpusKey(buf, i))
+		}
+		for i := range n {
+			if !f.MayContain(corpusKey(buf, i)) {
+				t.Fatalf("target %v: added key %d reported absent", target, i)
+			}
+		}
+
+		falsePositives := 0
+		for i := n; i < 2*n; i++ {
+			if f.MayContain(corpusKey(buf, i)) {
+				falsePositives++
+			}
+		}
+		if rate := float64(falsePositives) / n; rate > 2*target {
+			t.Errorf("target %v: false-positive rate %.5f over %d keys never added, want at most %v", target, rate, n, 2*target)
+		}
+	}
+}
+
+func TestAdd_EmptyKey(t *testing.T) {
+	f := newFilter(t, 10, 0.01)
+	f.Add(nil)
+	if !f.MayContain([]byte{}) {
+		t.Error("empty key added but reported absent")
+	}
+}
+
+func TestAddMayContain_DoNotAllocate(t *testing.T) {
+	f := newFilter(t, 1000, 0.01)
+	key := corpusKey(nil, 42)
+	if allocs := testing.AllocsPerRun(100, func() { f.Add(key); f.MayContain(key) }); allocs != 0 {
+		t.Errorf("Add and MayContain allocated %v times, want 0", allocs)
+	}
+}
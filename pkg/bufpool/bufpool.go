@@ -0,0 +1,28 @@
+// Package bufpool provides a shared pool of reusable bytes.Buffer values.
+package bufpool
+
+import (
+	"bytes"
+
+	"golang-backend/pkg/pool"
+)
+
+// MaxRetainedSize is the largest buffer capacity Put keeps for reuse.
+// Retaining huge buffers after a one-off large payload would pin that memory forever.
+const MaxRetainedSize = 64 << 10
+
+var buffers = pool.New(pool.WithReset((*bytes.Buffer).Reset))
+
+// Get returns an empty buffer from the pool.
+func Get() *bytes.Buffer {
+	return buffers.Get()
+}
+
+// Put resets buf and returns it to the pool. The caller must not use buf afterwards.
+// Buffers that have grown beyond MaxRetainedSize are dropped for the GC.
+func Put(buf *bytes.Buffer) {
+	if buf == nil || buf.Cap() > MaxRetainedSize {
+		return
+	}
+	buffers.Put(buf)
+}
@@ -0,0 +1,43 @@
+package bufpool
+
+import "testing"
+
+func TestGet_ReturnsEmptyBuffer(t *testing.T) {
+	for i := range 100 {
+		buf := Get()
+		if buf.Len() != 0 {
+			t.Fatalf("iteration %d: Get returned buffer with %d stale bytes %q", i, buf.Len(), buf.String())
+		}
+		buf.WriteString("previous payload")
+		Put(buf)
+	}
+}
+
+func TestPut_ResetsBuffer(t *testing.T) {
+	buf := Get()
+	buf.WriteString("previous payload")
+
+	Put(buf)
+
+	// Inspecting buf after Put is only done here to prove the reset ran.
+	if buf.Len() != 0 {
+		t.Errorf("Len after Put = %d, want 0", buf.Len())
+	}
+}
+
+func TestPut_DropsOversizedBuffer(t *testing.T) {
+	buf := Get()
+	buf.Grow(MaxRetainedSize + 1)
+	buf.WriteString("oversized")
+
+	Put(buf)
+
+	// Dropped buffers are left untouched rather than reset.
+	if buf.Len() == 0 {
+		t.Error("oversized buffer was reset and retained, want it dropped")
+	}
+}
+
+func TestPut_Nil(t *testing.T) {
+	Put(nil)
+}
@@ -0,0 +1,74 @@
+// Package byteconv parses numbers straight from byte slices, so a parser reading from a
+// buffer does not allocate a string per field just to call strconv.
+//
+// Results and errors match strconv's for the same input: errors are *strconv.NumError
+// values wrapping strconv.ErrSyntax or strconv.ErrRange, and out-of-range integers are
+// clamped the same way. Only the error path allocates.
+package byteconv
+
+import (
+	"math"
+	"strconv"
+
+	"golang-backend/pkg/unsafeconv"
+)
+
+// ParseInt64 interprets b as a base-10 integer with an optional sign, like
+// strconv.ParseInt(string(b), 10, 64).
+func ParseInt64(b []byte) (int64, error) {
+	s := b
+	neg := false
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if len(s) == 0 {
+		return 0, numError(b, strconv.ErrSyntax)
+	}
+
+	// Accumulate the magnitude as in strconv.ParseUint: every byte must be a digit, and
+	// a magnitude past 2^64-1 is out of range before any later byte is looked at.
+	var n uint64
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, numError(b, strconv.ErrSyntax)
+		}
+		if n >= math.MaxUint64/10+1 {
+			return clamp(neg), numError(b, strconv.ErrRange)
+		}
+		n1 := n*10 + uint64(c-'0')
+		if n1 < n {
+			return clamp(neg), numError(b, strconv.ErrRange)
+		}
+		n = n1
+	}
+
+	const cutoff = uint64(1) << 63
+	if !neg && n >= cutoff || neg && n > cutoff {
+		return clamp(neg), numError(b, strconv.ErrRange)
+	}
+	if neg {
+		return -int64(n), nil
+	}
+	return int64(n), nil
+}
+
+// clamp returns the int64 an out-of-range input of the given sign saturates to.
+func clamp(neg bool) int64 {
+	if neg {
+		return math.MinInt64
+	}
+	return math.MaxInt64
+}
+
+func numError(b []byte, err error) error {
+	return &strconv.NumError{Func: "ParseInt", Num: string(b), Err: err}
+}
+
+// ParseFloat interprets b as a float64 exactly as strconv.ParseFloat(string(b), 64) does,
+// by handing strconv a string that aliases b instead of a copy. strconv does not retain
+// its input except in the error, where it makes its own copy, so b may be reused as soon
+// as ParseFloat returns.
+func ParseFloat(b []byte) (float64, error) {
+	return strconv.ParseFloat(unsafeconv.B2S(b), 64)
+}
@@ -0,0 +1,140 @@
+package byteconv
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+)
+
+func TestParseInt64(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr error
+	}{
+		{in: "0", want: 0},
+		{in: "42", want: 42},
+		{in: "+42", want: 42},
+		{in: "-42", want: -42},
+		{in: "-0", want: 0},
+		{in: "0007", want: 7},
+		{in: "-0000000000000000000000000000009", want: -9},
+		{in: "9223372036854775807", want: math.MaxInt64},
+		{in: "-9223372036854775808", want: math.MinInt64},
+		{in: "9223372036854775808", want: math.MaxInt64, wantErr: strconv.ErrRange},
+		{in: "-9223372036854775809", want: math.MinInt64, wantErr: strconv.ErrRange},
+		{in: "18446744073709551615", want: math.MaxInt64, wantErr: strconv.ErrRange},
+		{in: "18446744073709551616", want: math.MaxInt64, wantErr: strconv.ErrRange},
+		{in: "-99999999999999999999999", want: math.MinInt64, wantErr: strconv.ErrRange},
+		// strconv reports the overflow before reaching the stray byte.
+		{in: "99999999999999999999x", want: math.MaxInt64, wantErr: strconv.ErrRange},
+		{in: "", wantErr: strconv.ErrSyntax},
+		{in: "+", wantErr: strconv.ErrSyntax},
+		{in: "-", wantErr: strconv.ErrSyntax},
+		{in: "--1", wantErr: strconv.ErrSyntax},
+		{in: " 1", wantErr: strconv.ErrSyntax},
+		{in: "1 ", wantErr: strconv.ErrSyntax},
+		{in: "1_000", wantErr: strconv.ErrSyntax},
+		{in: "0x10", wantErr: strconv.ErrSyntax},
+		{in: "1.5", wantErr: strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		got, err := ParseInt64([]byte(tt.in))
+		if got != tt.want || !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+			t.Errorf("ParseInt64(%q) = %d, %v; want %d, %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// sameResult reports whether two parse results agree: the same value and, for errors, the
+// same *strconv.NumError fields.
+func sameResult[T comparable](got T, gotErr error, want T, wantErr error) bool {
+	if got != want || (gotErr == nil) != (wantErr == nil) {
+		return false
+	}
+	if gotErr == nil {
+		return true
+	}
+	var g, w *strconv.NumError
+	return errors.As(gotErr, &g) && errors.As(wantErr, &w) && *g == *w
+}
+
+// randomNumber returns a string that is usually a valid number but is sometimes too long,
+// signed oddly, or carries a stray byte, so every path of a parser is exercised.
+func randomNumber(rng *rand.Rand, alphabet string, maxLen int) string {
+	b := make([]byte, 0, maxLen+2)
+	switch rng.IntN(4) {
+	case 0:
+		b = append(b, '-')
+	case 1:
+		b = append(b, '+')
+	}
+	for range rng.IntN(maxLen + 1) {
+		b = append(b, alphabet[rng.IntN(len(alphabet))])
+	}
+	if rng.IntN(20) == 0 {
+		b = append(b, "x _."[rng.IntN(4)])
+	}
+	return string(b)
+}
+
+func TestParseInt64_AgreesWithStrconv(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 79))
+	for range 200_000 {
+		s := randomNumber(rng, "0123456789", 22)
+		got, gotErr := ParseInt64([]byte(s))
+		want, wantErr := strconv.ParseInt(s, 10, 64)
+		if !sameResult(got, gotErr, want, wantErr) {
+			t.Fatalf("ParseInt64(%q) = %d, %v; strconv = %d, %v", s, got, gotErr, want, wantErr)
+		}
+	}
+}
+
+func TestParseFloat_AgreesWithStrconv(t *testing.T) {
+	rng := rand.New(rand.NewPCG(2, 79))
+	special := []string{"", "+", "-", ".", "1e", "1e400", "-1e400", "1e-400", "NaN", "inf", "-Inf", "0x1p-2", "1_000.5", "00012.50"}
+	for _, s := range special {
+		got, gotErr := ParseFloat([]byte(s))
+		want, wantErr := strconv.ParseFloat(s, 64)
+		if !sameResult(math.Float64bits(got), gotErr, math.Float64bits(want), wantErr) {
+			t.Errorf("ParseFloat(%q) = %v, %v; strconv = %v, %v", s, got, gotErr, want, wantErr)
+		}
+	}
+	for range 200_000 {
+		s := randomNumber(rng, "0123456789.e", 24)
+		got, gotErr := ParseFloat([]byte(s))
+		want, wantErr := strconv.ParseFloat(s, 64)
+		if !sameResult(math.Float64bits(got), gotErr, math.Float64bits(want), wantErr) {
+			t.Fatalf("ParseFloat(%q) = %v, %v; strconv = %v, %v", s, got, gotErr, want, wantErr)
+		}
+	}
+}
+
+func TestParseFloat_ErrorDoesNotAliasInput(t *testing.T) {
+	b := []byte("12.5x")
+	_, err := ParseFloat(b)
+	copy(b, "99999")
+
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) || numErr.Num != "12.5x" {
+		t.Errorf("error = %v; want its input kept as %q after the buffer changed", err, "12.5x")
+	}
+}
+
+func TestAllocs_ValidInput(t *testing.T) {
+	ints := [][]byte{[]byte("0"), []byte("-9223372036854775808"), []byte("+123456")}
+	floats := [][]byte{[]byte("0"), []byte("-1234.5678"), []byte("6.02214076e23")}
+
+	for _, b := range ints {
+		if allocs := testing.AllocsPerRun(100, func() { ParseInt64(b) }); allocs != 0 {
+			t.Errorf("ParseInt64(%q) allocated %v times, want 0", b, allocs)
+		}
+	}
+	for _, b := range floats {
+		if allocs := testing.AllocsPerRun(100, func() { ParseFloat(b) }); allocs != 0 {
+			t.Errorf("ParseFloat(%q) allocated %v times, want 0", b, allocs)
+		}
+	}
+}
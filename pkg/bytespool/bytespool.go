@@ -0,0 +1,51 @@
+// Package bytespool pools reusable byte slices without the per-Put allocation
+// of storing a []byte directly in a sync.Pool.
+package bytespool
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrInvalidSize is returned when the pool sizes are non-positive or inconsistent.
+var ErrInvalidSize = errors.New("bytespool: size must be positive and not exceed max size")
+
+// Pool hands out *[]byte values. A pointer fits in an interface's data word,
+// so Put does not allocate, unlike putting a []byte (a 3-word header) directly.
+type Pool struct {
+	p       sync.Pool
+	maxSize int
+}
+
+// New creates a Pool whose fresh slices have capacity size.
+// Slices that have grown beyond maxSize are discarded on Put rather than retained.
+func New(size, maxSize int) (*Pool, error) {
+	if size <= 0 || maxSize < size {
+		return nil, ErrInvalidSize
+	}
+
+	p := &Pool{
+		maxSize: maxSize,
+	}
+	p.p.New = func() any {
+		b := make([]byte, 0, size)
+		return &b
+	}
+
+	return p, nil
+}
+
+// Get returns an empty slice with at least the pool's configured capacity.
+func (p *Pool) Get() *[]byte {
+	return p.p.Get().(*[]byte)
+}
+
+// Put truncates b and returns it to the pool. The caller must not use b afterwards.
+// A nil b or a slice whose capacity exceeds the max size is dropped.
+func (p *Pool) Put(b *[]byte) {
+	if b == nil || cap(*b) > p.maxSize {
+		return
+	}
+	*b = (*b)[:0]
+	p.p.Put(b)
+}
@@ -0,0 +1,79 @@
+package bytespool
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew_InvalidSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		size, maxSize int
+	}{
+		{name: "zero size", size: 0, maxSize: 10},
+		{name: "negative size", size: -1, maxSize: 10},
+		{name: "max below size", size: 10, maxSize: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.size, tt.maxSize); !errors.Is(err, ErrInvalidSize) {
+				t.Errorf("New(%d, %d) error = %v, want %v", tt.size, tt.maxSize, err, ErrInvalidSize)
+			}
+		})
+	}
+}
+
+func TestPool_GetReturnsEmptySliceWithCapacity(t *testing.T) {
+	p, err := New(64, 128)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	for i := range 100 {
+		b := p.Get()
+		if len(*b) != 0 || cap(*b) < 64 {
+			t.Fatalf("iteration %d: Get returned len=%d cap=%d, want len=0 cap>=64", i, len(*b), cap(*b))
+		}
+		*b = append(*b, "previous payload"...)
+		p.Put(b)
+	}
+}
+
+func TestPool_PutDiscardThreshold(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		retained bool
+	}{
+		{name: "below max", capacity: 100, retained: true},
+		{name: "at max", capacity: 128, retained: true},
+		{name: "above max", capacity: 129, retained: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(64, 128)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			b := make([]byte, 5, tt.capacity)
+			p.Put(&b)
+
+			// Retained slices are truncated on Put; dropped ones are left untouched.
+			if retained := len(b) == 0; retained != tt.retained {
+				t.Errorf("cap %d: retained = %v, want %v", tt.capacity, retained, tt.retained)
+			}
+		})
+	}
+}
+
+func TestPool_PutNil(t *testing.T) {
+	p, err := New(64, 128)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	p.Put(nil)
+}
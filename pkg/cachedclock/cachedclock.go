@@ -0,0 +1,75 @@
+// Package cachedclock keeps a formatted timestamp refreshed once per second, so hot paths
+// such as log lines read a ready-made string instead of formatting time.Now on every call.
+package cachedclock
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"golang-backend/pkg/internal/refresh"
+)
+
+// ErrInvalidConfig is returned when the layout is empty.
+var ErrInvalidConfig = errors.New("cachedclock: layout must not be empty")
+
+// Granularity is how often the cached string is refreshed; it lags real time by up to this much.
+const Granularity = time.Second
+
+// Option configures a Clock.
+type Option = refresh.Option
+
+// WithClock replaces time.Now as the source of the times the timestamp is formatted from.
+func WithClock(now func() time.Time) Option {
+	return refresh.WithClock(now)
+}
+
+// WithTicker replaces time.NewTicker for the once-per-Granularity refresh. newTicker returns
+// the tick channel and a function that stops it.
+func WithTicker(newTicker func(d time.Duration) (<-chan time.Time, func())) Option {
+	return refresh.WithTicker(newTicker)
+}
+
+// Clock caches the current time formatted with a layout. String is a single atomic load.
+type Clock struct {
+	layout  string
+	current atomic.Pointer[string]
+	loop    *refresh.Loop
+}
+
+// New creates a Clock formatting with layout. The cached string is set immediately, but only
+// refreshed while the Clock is started. Layouts finer than a second, such as
+// time.RFC3339Nano, still show the time of the last refresh.
+func New(layout string, opts ...Option) (*Clock, error) {
+	if layout == "" {
+		return nil, ErrInvalidConfig
+	}
+
+	c := &Clock{layout: layout}
+	c.loop = refresh.New(Granularity, c.refresh, opts...)
+	c.refresh()
+	return c, nil
+}
+
+// refresh formats the current time and publishes it.
+func (c *Clock) refresh() {
+	s := c.loop.Now().Format(c.layout)
+	c.current.Store(&s)
+}
+
+// String returns the timestamp as of the last refresh.
+func (c *Clock) String() string {
+	return *c.current.Load()
+}
+
+// Start refreshes the timestamp now and then every Granularity on a background goroutine.
+// Starting a running Clock does nothing.
+func (c *Clock) Start() {
+	c.loop.Start()
+}
+
+// Stop ends the refresh goroutine and waits for it to exit. The cached string keeps its
+// last value. Stopping a Clock that is not running does nothing; it can be started again.
+func (c *Clock) Stop() {
+	c.loop.Stop()
+}
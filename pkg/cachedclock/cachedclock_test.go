@@ -0,0 +1,99 @@
+package cachedclock
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golang-backend/pkg/internal/refresh/refreshtest"
+)
+
+func TestNew_RejectsEmptyLayout(t *testing.T) {
+	if _, err := New(""); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("New(\"\") error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestClock_RefreshesOnTick(t *testing.T) {
+	var now refreshtest.Now
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	now.Set(start)
+	ticker := refreshtest.NewTicker()
+
+	c, err := New(time.RFC3339, WithClock(now.Now), WithTicker(ticker.New))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := c.String(), "2024-01-02T03:04:05Z"; got != want {
+		t.Fatalf("String after New = %q, want %q", got, want)
+	}
+
+	c.Start()
+	defer c.Stop()
+
+	now.Set(start.Add(time.Second))
+	if got, want := c.String(), "2024-01-02T03:04:05Z"; got != want {
+		t.Errorf("String before the tick = %q, want the cached %q", got, want)
+	}
+	ticker.Tick()
+	if got, want := c.String(), "2024-01-02T03:04:06Z"; got != want {
+		t.Errorf("String after the tick = %q, want %q", got, want)
+	}
+}
+
+func TestClock_WithinGranularityOfRealTime(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits for a real tick")
+	}
+
+	c, err := New(time.RFC3339Nano)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Start()
+	defer c.Stop()
+
+	check := func(when string) {
+		t.Helper()
+		cached, err := time.Parse(time.RFC3339Nano, c.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Allow some scheduling delay on top of the refresh period.
+		if lag := time.Since(cached); lag < 0 || lag > Granularity+250*time.Millisecond {
+			t.Errorf("%s: cached time lags real time by %v, want within %v", when, lag, Granularity)
+		}
+	}
+	check("after Start")
+	time.Sleep(Granularity + 100*time.Millisecond)
+	check("after one period")
+}
+
+func TestStop_KeepsLastValue(t *testing.T) {
+	var now refreshtest.Now
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	now.Set(start)
+	ticker := refreshtest.NewTicker()
+
+	c, err := New(time.RFC3339, WithClock(now.Now), WithTicker(ticker.New))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Start()
+	c.Stop()
+	if got := ticker.Stopped(); got != 1 {
+		t.Errorf("ticker stopped %d times, want 1", got)
+	}
+
+	now.Set(start.Add(time.Hour))
+	if got, want := c.String(), "2024-01-02T03:04:05Z"; got != want {
+		t.Errorf("String after Stop = %q, want the last refreshed %q", got, want)
+	}
+
+	// Starting again refreshes immediately.
+	c.Start()
+	defer c.Stop()
+	if got, want := c.String(), "2024-01-02T04:04:05Z"; got != want {
+		t.Errorf("String after restarting = %q, want %q", got, want)
+	}
+}
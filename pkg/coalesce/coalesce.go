@@ -0,0 +1,89 @@
+// Package coalesce collapses concurrent calls for the same key into a single execution,
+// in the style of golang.org/x/sync/singleflight, using only the standard library.
+package coalesce
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPanicked is returned to callers that were waiting on a fn that panicked or called
+// runtime.Goexit. The caller that ran fn sees the panic itself.
+var ErrPanicked = errors.New("coalesce: fn panicked")
+
+// call is one in-flight or completed execution of fn.
+type call[V any] struct {
+	wg   sync.WaitGroup
+	val  *V
+	err  error
+	dups int // callers that joined instead of running fn; guarded by Group.mu
+}
+
+// Group runs at most one fn per key at a time. The zero value is ready to use.
+type Group[V any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[V]
+}
+
+// Do runs fn and returns its results unless a call for key is already in flight, in which
+// case it waits for that call and returns the same results. shared reports whether the
+// results went to more than one caller.
+//
+// If fn panics the panic is re-raised in the goroutine that ran it, and every waiting
+// caller receives an error wrapping ErrPanicked.
+func (g *Group[V]) Do(key string, fn func() (*V, error)) (v *V, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call[V])
+	}
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, true, c.err
+	}
+
+	c := &call[V]{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	g.run(c, key, fn)
+	return c.val, c.dups > 0, c.err
+}
+
+// run executes fn for c and releases its waiters however fn returns.
+func (g *Group[V]) run(c *call[V], key string, fn func() (*V, error)) {
+	returned := false
+	var recovered any
+	defer func() {
+		if !returned {
+			// recover returns nil for runtime.Goexit, which must be left to unwind.
+			recovered = recover()
+			c.val, c.err = nil, fmt.Errorf("%w: %v", ErrPanicked, recovered)
+		}
+
+		g.mu.Lock()
+		if g.calls[key] == c { // Forget may already have removed or replaced it
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		c.wg.Done()
+
+		if recovered != nil {
+			panic(recovered)
+		}
+	}()
+
+	c.val, c.err = fn()
+	returned = true
+}
+
+// Forget drops the in-flight call for key, if any, so the next Do for key runs fn again
+// rather than joining it. Callers already waiting still receive the dropped call's results.
+func (g *Group[V]) Forget(key string) {
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+}
@@ -0,0 +1,219 @@
+package coalesce
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type result struct {
+	n int
+}
+
+// waitForDups blocks until n callers have joined the in-flight call for key.
+func waitForDups(t *testing.T, g *Group[result], key string, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		g.mu.Lock()
+		c := g.calls[key]
+		joined := c != nil && c.dups == n
+		g.mu.Unlock()
+		if joined {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d callers never joined the call for %q", n, key)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDo_Coalesces(t *testing.T) {
+	const callers = 100
+
+	var g Group[result]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	want := &result{n: 42}
+
+	results := make([]*result, callers)
+	shared := make([]bool, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Go(func() {
+			v, s, err := g.Do("k", func() (*result, error) {
+				calls.Add(1)
+				<-release
+				return want, nil
+			})
+			if err != nil {
+				t.Errorf("Do: %v", err)
+			}
+			results[i], shared[i] = v, s
+		})
+	}
+
+	waitForDups(t, &g, "k", callers-1)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fn ran %d times, want 1", n)
+	}
+	for i := range callers {
+		if results[i] != want || !shared[i] {
+			t.Fatalf("caller %d got (%p, shared=%v), want (%p, true)", i, results[i], shared[i], want)
+		}
+	}
+}
+
+func TestDo_ErrorReachesAllWaiters(t *testing.T) {
+	const callers = 10
+
+	var g Group[result]
+	errBackend := errors.New("backend down")
+	release := make(chan struct{})
+
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Go(func() {
+			_, _, errs[i] = g.Do("k", func() (*result, error) {
+				<-release
+				return nil, errBackend
+			})
+		})
+	}
+
+	waitForDups(t, &g, "k", callers-1)
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if !errors.Is(err, errBackend) {
+			t.Errorf("caller %d: error = %v, want %v", i, err, errBackend)
+		}
+	}
+}
+
+func TestDo_DistinctKeysRunConcurrently(t *testing.T) {
+	var g Group[result]
+	aStarted, bStarted := make(chan struct{}), make(chan struct{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			g.Do("a", func() (*result, error) {
+				close(aStarted)
+				<-bStarted // deadlocks if distinct keys were serialized
+				return &result{}, nil
+			})
+		})
+		wg.Go(func() {
+			g.Do("b", func() (*result, error) {
+				close(bStarted)
+				<-aStarted
+				return &result{}, nil
+			})
+		})
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("calls for distinct keys did not run concurrently")
+	}
+}
+
+func TestDo_PanicReleasesWaiters(t *testing.T) {
+	const waiters = 5
+
+	var g Group[result]
+	release := make(chan struct{})
+
+	recovered := make(chan any, 1)
+	go func() {
+		defer func() { recovered <- recover() }()
+		g.Do("k", func() (*result, error) {
+			<-release
+			panic("boom")
+		})
+	}()
+	waitForDups(t, &g, "k", 0)
+
+	errs := make([]error, waiters)
+	var wg sync.WaitGroup
+	for i := range waiters {
+		wg.Go(func() {
+			_, _, errs[i] = g.Do("k", func() (*result, error) {
+				t.Error("waiter ran fn")
+				return nil, nil
+			})
+		})
+	}
+	waitForDups(t, &g, "k", waiters)
+	close(release)
+
+	finished := make(chan struct{})
+	go func() { wg.Wait(); close(finished) }()
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiters still blocked after fn panicked")
+	}
+
+	if r := <-recovered; r != "boom" {
+		t.Errorf("running caller recovered %v, want boom", r)
+	}
+	for i, err := range errs {
+		if !errors.Is(err, ErrPanicked) {
+			t.Errorf("waiter %d: error = %v, want %v", i, err, ErrPanicked)
+		}
+	}
+
+	if v, _, err := g.Do("k", func() (*result, error) { return &result{n: 1}, nil }); err != nil || v.n != 1 {
+		t.Errorf("Do after panic = (%v, %v), want a fresh call", v, err)
+	}
+}
+
+func TestForget(t *testing.T) {
+	var g Group[result]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	first := &result{n: 1}
+
+	done := make(chan *result)
+	go func() {
+		v, _, _ := g.Do("k", func() (*result, error) {
+			calls.Add(1)
+			<-release
+			return first, nil
+		})
+		done <- v
+	}()
+	waitForDups(t, &g, "k", 0)
+
+	g.Forget("k")
+	second, shared, err := g.Do("k", func() (*result, error) {
+		calls.Add(1)
+		return &result{n: 2}, nil
+	})
+	if err != nil || second.n != 2 || shared {
+		t.Errorf("Do after Forget = (%v, shared=%v, %v), want a fresh unshared call", second, shared, err)
+	}
+
+	close(release)
+	if v := <-done; v != first {
+		t.Errorf("forgotten call returned %p, want %p", v, first)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("fn ran %d times, want 2", n)
+	}
+}
@@ -0,0 +1,80 @@
+// Package coarseclock keeps the current time in an atomic refreshed once per millisecond,
+// so code that timestamps every request reads a word instead of calling time.Now.
+package coarseclock
+
+import (
+	"sync/atomic"
+	"time"
+
+	"golang-backend/pkg/internal/refresh"
+)
+
+// Granularity is how often the time is refreshed; Now lags real time by up to this much,
+// plus however long the refresh goroutine waits to be scheduled.
+const Granularity = time.Millisecond
+
+// Option configures a Clock.
+type Option = refresh.Option
+
+// WithClock replaces time.Now as the time source New takes the base from and each
+// refresh samples. Clock.Now still never goes backwards if now does.
+func WithClock(now func() time.Time) Option {
+	return refresh.WithClock(now)
+}
+
+// WithTicker replaces time.NewTicker for the per-millisecond refresh. newTicker returns
+// the tick channel and a function that stops it.
+func WithTicker(newTicker func(d time.Duration) (<-chan time.Time, func())) Option {
+	return refresh.WithTicker(newTicker)
+}
+
+// Clock caches the current time. Now is a single atomic load plus an addition.
+//
+// The cache holds an offset from a base time read when the Clock was created rather than
+// the time itself, so the Times it returns carry time.Now's monotonic reading and
+// time.Since and Time.Sub on them are unaffected by wall clock adjustments.
+type Clock struct {
+	base   time.Time
+	offset atomic.Int64 // nanoseconds since base, never decreasing
+	loop   *refresh.Loop
+}
+
+// New creates a Clock set to the current time. It is only refreshed while started.
+func New(opts ...Option) *Clock {
+	c := &Clock{}
+	c.loop = refresh.New(Granularity, c.refresh, opts...)
+	c.base = c.loop.Now()
+	return c
+}
+
+// refresh publishes the current offset from base. The loop never runs two refreshes at
+// once, so a plain load and store keep the offset from moving backwards if the time
+// source does.
+func (c *Clock) refresh() {
+	d := int64(c.loop.Now().Sub(c.base))
+	if d > c.offset.Load() {
+		c.offset.Store(d)
+	}
+}
+
+// Now returns the time as of the last refresh. Successive calls never go backwards.
+func (c *Clock) Now() time.Time {
+	return c.base.Add(time.Duration(c.offset.Load()))
+}
+
+// Since returns the time elapsed since t as of the last refresh, like time.Since.
+func (c *Clock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Start refreshes the time now and then every Granularity on a background goroutine.
+// Starting a running Clock does nothing.
+func (c *Clock) Start() {
+	c.loop.Start()
+}
+
+// Stop ends the refresh goroutine and waits for it to exit. Now keeps returning the last
+// refreshed time. Stopping a Clock that is not running does nothing; it can be started again.
+func (c *Clock) Stop() {
+	c.loop.Stop()
+}
@@ -0,0 +1,133 @@
+package coarseclock
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"golang-backend/pkg/internal/refresh/refreshtest"
+)
+
+func TestClock_RefreshesOnTick(t *testing.T) {
+	var now refreshtest.Now
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	now.Set(start)
+	ticker := refreshtest.NewTicker()
+
+	c := New(WithClock(now.Now), WithTicker(ticker.New))
+	if got := c.Now(); !got.Equal(start) {
+		t.Fatalf("Now after New = %v, want %v", got, start)
+	}
+
+	c.Start()
+	defer c.Stop()
+
+	now.Set(start.Add(5 * time.Millisecond))
+	if got := c.Now(); !got.Equal(start) {
+		t.Errorf("Now before the tick = %v, want the cached %v", got, start)
+	}
+	ticker.Tick()
+	if got, want := c.Now(), start.Add(5*time.Millisecond); !got.Equal(want) {
+		t.Errorf("Now after the tick = %v, want %v", got, want)
+	}
+	if got, want := c.Since(start), 5*time.Millisecond; got != want {
+		t.Errorf("Since(start) = %v, want %v", got, want)
+	}
+}
+
+func TestClock_NeverGoesBackwards(t *testing.T) {
+	var now refreshtest.Now
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	now.Set(start)
+	ticker := refreshtest.NewTicker()
+
+	c := New(WithClock(now.Now), WithTicker(ticker.New))
+	c.Start()
+	defer c.Stop()
+
+	now.Set(start.Add(time.Second))
+	ticker.Tick()
+	now.Set(start.Add(time.Millisecond)) // the time source steps back
+	ticker.Tick()
+	if got, want := c.Now(), start.Add(time.Second); !got.Equal(want) {
+		t.Errorf("Now after the source stepped back = %v, want it held at %v", got, want)
+	}
+}
+
+func TestClock_MonotonicReads(t *testing.T) {
+	c := New()
+	c.Start()
+	defer c.Stop()
+
+	// Readers on several goroutines each check their own reads never decrease while the
+	// updater runs underneath them.
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			prev := c.Now()
+			for range 50_000 {
+				cur := c.Now()
+				if cur.Before(prev) {
+					t.Errorf("Now went backwards: %v then %v", prev, cur)
+					return
+				}
+				prev = cur
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestClock_WithinGranularityOfRealTime(t *testing.T) {
+	if testing.Short() {
+		t.Skip("samples the real clock for a while")
+	}
+
+	c := New()
+	c.Start()
+	defer c.Stop()
+
+	// Allow some scheduling delay on top of the refresh period: on a busy machine the
+	// updater may not run exactly every millisecond.
+	const slack = 250 * time.Millisecond
+	var worst time.Duration
+	deadline := time.Now().Add(50 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		lag := time.Since(c.Now())
+		if lag < 0 || lag > Granularity+slack {
+			t.Fatalf("coarse time lags real time by %v, want within %v", lag, Granularity)
+		}
+		worst = max(worst, lag)
+		time.Sleep(100 * time.Microsecond)
+	}
+	t.Logf("worst lag over 50ms: %v", worst)
+}
+
+func TestClock_Advances(t *testing.T) {
+	c := New()
+	c.Start()
+	defer c.Stop()
+
+	start := c.Now()
+	deadline := time.Now().Add(time.Second)
+	for c.Since(start) < 10*time.Millisecond {
+		if time.Now().After(deadline) {
+			t.Fatalf("coarse time advanced %v in a second of real time", c.Since(start))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestStop_FreezesTime(t *testing.T) {
+	c := New()
+	c.Start()
+	time.Sleep(5 * time.Millisecond)
+	c.Stop()
+
+	// With the updater gone the time stays where it was.
+	frozen := c.Now()
+	time.Sleep(5 * time.Millisecond)
+	if got := c.Now(); !got.Equal(frozen) {
+		t.Errorf("Now moved from %v to %v after Stop", frozen, got)
+	}
+}
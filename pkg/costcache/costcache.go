@@ -0,0 +1,135 @@
+// Package costcache provides a least-recently-used cache bounded by the total cost of its
+// entries, such as their size in bytes, rather than by how many there are.
+package costcache
+
+import (
+	"container/list"
+	"errors"
+)
+
+// ErrInvalidSize is returned when a cache is created with a non-positive maximum cost.
+var ErrInvalidSize = errors.New("costcache: max cost must be positive")
+
+// Cache maps keys to *V values, each with a cost given when it is stored, and keeps the
+// sum of the costs at or below MaxCost. Storing an entry that pushes the total over the
+// budget evicts least recently read or written entries until it fits again.
+//
+// A Cache is not safe for concurrent use; even Get reorders the recency list, so callers
+// sharing one across goroutines must guard every method with a sync.Mutex.
+type Cache[K comparable, V any] struct {
+	maxCost int64
+	cost    int64
+	ll      *list.List // front is most recently used; elements hold *entry[K, V]
+	items   map[K]*list.Element
+	config[K, V]
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value *V
+	cost  int64
+}
+
+// config holds the optional settings of a Cache.
+type config[K comparable, V any] struct {
+	onEvict func(key K, value *V)
+}
+
+// Option configures a Cache.
+type Option[K comparable, V any] func(*config[K, V])
+
+// WithOnEvict registers fn to be called with each entry evicted to bring the total cost
+// back within budget, least recently used first. It runs synchronously inside Set and is
+// not called when Set replaces an existing key's value.
+func WithOnEvict[K comparable, V any](fn func(key K, value *V)) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.onEvict = fn
+	}
+}
+
+// New creates a Cache whose entries' costs sum to at most maxCost.
+func New[K comparable, V any](maxCost int64, opts ...Option[K, V]) (*Cache[K, V], error) {
+	if maxCost <= 0 {
+		return nil, ErrInvalidSize
+	}
+
+	c := &Cache[K, V]{
+		maxCost: maxCost,
+		ll:      list.New(),
+		items:   make(map[K]*list.Element),
+	}
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c, nil
+}
+
+// Get returns the value for key and marks it most recently used.
+func (c *Cache[K, V]) Get(key K) (*V, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*entry[K, V]).value, true
+}
+
+// Set stores value under key with the given cost, marks it most recently used, and evicts
+// least recently used entries until the total cost is within budget. Replacing a key's
+// value replaces its cost too. Negative costs count as 0.
+//
+// An entry costing more than MaxCost on its own can never fit: Set stores nothing, drops
+// any value already held for key so Get cannot return a stale one, and reports false.
+func (c *Cache[K, V]) Set(key K, value *V, cost int64) bool {
+	cost = max(cost, 0)
+	el, exists := c.items[key]
+	if cost > c.maxCost {
+		if exists {
+			c.remove(el)
+		}
+		return false
+	}
+
+	if exists {
+		e := el.Value.(*entry[K, V])
+		c.cost += cost - e.cost
+		e.value, e.cost = value, cost
+		c.ll.MoveToFront(el)
+	} else {
+		c.items[key] = c.ll.PushFront(&entry[K, V]{key: key, value: value, cost: cost})
+		c.cost += cost
+	}
+
+	// The entry just stored is at the front and fits on its own, so eviction stops
+	// before reaching it.
+	for c.cost > c.maxCost {
+		e := c.remove(c.ll.Back())
+		if c.onEvict != nil {
+			c.onEvict(e.key, e.value)
+		}
+	}
+	return true
+}
+
+// remove unlinks el and subtracts its cost.
+func (c *Cache[K, V]) remove(el *list.Element) *entry[K, V] {
+	e := c.ll.Remove(el).(*entry[K, V])
+	delete(c.items, e.key)
+	c.cost -= e.cost
+	return e
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	return c.ll.Len()
+}
+
+// Cost returns the total cost of the entries in the cache.
+func (c *Cache[K, V]) Cost() int64 {
+	return c.cost
+}
+
+// MaxCost returns the budget the total cost is kept within.
+func (c *Cache[K, V]) MaxCost() int64 {
+	return c.maxCost
+}
@@ -0,0 +1,179 @@
+package costcache
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type item struct {
+	n int
+}
+
+func newCache(t *testing.T, maxCost int64, opts ...Option[string, item]) *Cache[string, item] {
+	t.Helper()
+
+	c, err := New(maxCost, opts...)
+	if err != nil {
+		t.Fatalf("New(%d): %v", maxCost, err)
+	}
+	return c
+}
+
+// evictions returns an option recording evicted keys into keys.
+func evictions(keys *[]string) Option[string, item] {
+	return WithOnEvict(func(key string, _ *item) {
+		*keys = append(*keys, key)
+	})
+}
+
+func TestNew_InvalidSize(t *testing.T) {
+	for _, maxCost := range []int64{0, -1} {
+		if _, err := New[string, item](maxCost); !errors.Is(err, ErrInvalidSize) {
+			t.Errorf("New(%d) error = %v, want %v", maxCost, err, ErrInvalidSize)
+		}
+	}
+}
+
+func TestCache_GetSet(t *testing.T) {
+	c := newCache(t, 10)
+	a := &item{n: 1}
+	if !c.Set("a", a, 4) {
+		t.Fatal("Set(a, cost 4) into budget 10 = false")
+	}
+
+	if got, ok := c.Get("a"); !ok || got != a {
+		t.Errorf("Get(a) = %p, %v, want %p, true", got, ok, a)
+	}
+	if got, ok := c.Get("missing"); ok || got != nil {
+		t.Errorf("Get(missing) = %p, %v, want nil, false", got, ok)
+	}
+	if c.Len() != 1 || c.Cost() != 4 || c.MaxCost() != 10 {
+		t.Errorf("Len = %d, Cost = %d, MaxCost = %d, want 1, 4, 10", c.Len(), c.Cost(), c.MaxCost())
+	}
+}
+
+func TestCache_EvictsUntilUnderBudget(t *testing.T) {
+	var evicted []string
+	c := newCache(t, 10, evictions(&evicted))
+
+	c.Set("a", &item{}, 3)
+	c.Set("b", &item{}, 3)
+	c.Set("c", &item{}, 3)
+	c.Get("a") // recency, oldest first: b, c, a
+
+	c.Set("d", &item{}, 1) // total 10, fits exactly
+	if len(evicted) != 0 || c.Cost() != 10 {
+		t.Fatalf("evicted %v at cost %d, want nothing evicted at 10", evicted, c.Cost())
+	}
+
+	// Cost 7 needs 8 freed from the oldest entries: b, c, then a.
+	c.Set("e", &item{}, 7)
+	if want := []string{"b", "c", "a"}; !slices.Equal(evicted, want) {
+		t.Errorf("evicted %v, want %v in least recently used order", evicted, want)
+	}
+	if c.Len() != 2 || c.Cost() != 8 {
+		t.Errorf("Len = %d, Cost = %d, want d and e at cost 8", c.Len(), c.Cost())
+	}
+	for _, k := range []string{"d", "e"} {
+		if _, ok := c.Get(k); !ok {
+			t.Errorf("%s evicted, want it kept", k)
+		}
+	}
+}
+
+func TestCache_OverwriteReplacesCost(t *testing.T) {
+	var evicted []string
+	c := newCache(t, 10, evictions(&evicted))
+	c.Set("a", &item{}, 2)
+	c.Set("b", &item{}, 3)
+
+	updated := &item{n: 2}
+	c.Set("a", updated, 5) // 2 -> 5, not 2 + 5
+	if c.Cost() != 8 || c.Len() != 2 {
+		t.Errorf("after growing a: Cost = %d, Len = %d, want 8, 2", c.Cost(), c.Len())
+	}
+	c.Set("a", updated, 1)
+	if c.Cost() != 4 {
+		t.Errorf("after shrinking a: Cost = %d, want 4", c.Cost())
+	}
+
+	// Growing a past the budget evicts b, the older entry, and never a itself.
+	c.Set("a", updated, 9)
+	if !slices.Equal(evicted, []string{"b"}) || c.Cost() != 9 {
+		t.Errorf("evicted %v at cost %d, want [b] at 9", evicted, c.Cost())
+	}
+	if got, ok := c.Get("a"); !ok || got != updated {
+		t.Errorf("Get(a) = %p, %v, want updated value %p", got, ok, updated)
+	}
+}
+
+func TestCache_OverwriteDoesNotCallOnEvict(t *testing.T) {
+	var evicted []string
+	c := newCache(t, 10, evictions(&evicted))
+	c.Set("a", &item{}, 5)
+	c.Set("a", &item{}, 6)
+	if len(evicted) != 0 {
+		t.Errorf("replacing a's value reported evictions %v", evicted)
+	}
+}
+
+func TestCache_EntryLargerThanBudget(t *testing.T) {
+	var evicted []string
+	c := newCache(t, 10, evictions(&evicted))
+	c.Set("a", &item{}, 4)
+	c.Set("b", &item{}, 4)
+
+	if c.Set("huge", &item{}, 11) {
+		t.Error("Set of an entry costing more than the budget = true")
+	}
+	if _, ok := c.Get("huge"); ok || c.Len() != 2 || c.Cost() != 8 || len(evicted) != 0 {
+		t.Errorf("oversized Set changed the cache: Len %d, Cost %d, evicted %v", c.Len(), c.Cost(), evicted)
+	}
+
+	// Oversizing an existing key drops its old value rather than leaving it stale.
+	if c.Set("a", &item{}, 100) {
+		t.Error("oversized overwrite of a = true")
+	}
+	if _, ok := c.Get("a"); ok || c.Cost() != 4 || c.Len() != 1 {
+		t.Errorf("after oversized overwrite: a present %v, Cost %d, Len %d; want a gone at cost 4", ok, c.Cost(), c.Len())
+	}
+
+	// An entry costing exactly the budget fits, evicting everything else.
+	if !c.Set("full", &item{}, 10) || c.Len() != 1 || c.Cost() != 10 || !slices.Equal(evicted, []string{"b"}) {
+		t.Errorf("Set at exactly the budget: Len %d, Cost %d, evicted %v", c.Len(), c.Cost(), evicted)
+	}
+}
+
+func TestCache_ZeroAndNegativeCost(t *testing.T) {
+	c := newCache(t, 1)
+	for _, k := range []string{"a", "b", "c"} {
+		c.Set(k, &item{}, 0)
+	}
+	c.Set("d", &item{}, -5)
+	if c.Len() != 4 || c.Cost() != 0 {
+		t.Errorf("free entries: Len = %d, Cost = %d, want 4, 0", c.Len(), c.Cost())
+	}
+}
+
+func TestCache_OnEvictReceivesValue(t *testing.T) {
+	a := &item{n: 1}
+	var gotKey string
+	var gotValue *item
+	calls := 0
+	c := newCache(t, 1, WithOnEvict(func(key string, value *item) {
+		calls++
+		gotKey, gotValue = key, value
+	}))
+
+	c.Set("a", a, 1)
+	c.Get("a")
+	if calls != 0 {
+		t.Fatalf("callback ran %d times before any eviction", calls)
+	}
+
+	c.Set("b", &item{}, 1)
+	if calls != 1 || gotKey != "a" || gotValue != a {
+		t.Errorf("callback ran %d times with (%q, %p), want once with (a, %p)", calls, gotKey, gotValue, a)
+	}
+}
@@ -0,0 +1,34 @@
+// Package ctxkey provides typed context keys, so request-scoped values are stored and
+// read back without untyped keys or type assertions at every call site.
+package ctxkey
+
+import "context"
+
+// Key identifies a *T stored in a context. Keys compare by identity: two keys created
+// with the same name are still distinct, so packages cannot collide by accident.
+type Key[T any] struct {
+	name string
+}
+
+// New creates a Key. The name is only used by String, for debugging.
+func New[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// Set returns a copy of ctx carrying v under k. Each call allocates a context node, and
+// Get walks the chain of nodes, so keep request-scoped values few.
+func (k *Key[T]) Set(ctx context.Context, v *T) context.Context {
+	return context.WithValue(ctx, k, v)
+}
+
+// Get returns the value stored under k. It reports false when ctx has no value for k or
+// the stored value is nil.
+func (k *Key[T]) Get(ctx context.Context) (*T, bool) {
+	v, ok := ctx.Value(k).(*T)
+	return v, ok && v != nil
+}
+
+// String returns the key's name.
+func (k *Key[T]) String() string {
+	return "ctxkey." + k.name
+}
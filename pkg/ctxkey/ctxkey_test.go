@@ -0,0 +1,67 @@
+package ctxkey
+
+import (
+	"context"
+	"testing"
+)
+
+type order struct {
+	id int64
+}
+
+func TestKey_SetGet(t *testing.T) {
+	k := New[order]("order")
+	o := &order{id: 1}
+
+	ctx := k.Set(context.Background(), o)
+	if got, ok := k.Get(ctx); !ok || got != o {
+		t.Errorf("Get = %p, %v, want %p, true", got, ok, o)
+	}
+}
+
+func TestKey_Missing(t *testing.T) {
+	k := New[order]("order")
+
+	if got, ok := k.Get(context.Background()); ok || got != nil {
+		t.Errorf("Get on empty context = %p, %v, want nil, false", got, ok)
+	}
+	if got, ok := k.Get(k.Set(context.Background(), nil)); ok || got != nil {
+		t.Errorf("Get of stored nil = %p, %v, want nil, false", got, ok)
+	}
+}
+
+func TestKey_WrongType(t *testing.T) {
+	k := New[order]("order")
+
+	for _, v := range []any{"order", order{id: 1}, new(int)} {
+		ctx := context.WithValue(context.Background(), k, v)
+		if got, ok := k.Get(ctx); ok || got != nil {
+			t.Errorf("Get with %T stored = %p, %v, want nil, false", v, got, ok)
+		}
+	}
+}
+
+func TestKey_SameNameDistinct(t *testing.T) {
+	a, b := New[order]("order"), New[order]("order")
+	ctx := a.Set(context.Background(), &order{id: 1})
+
+	if _, ok := b.Get(ctx); ok {
+		t.Error("a key created with the same name read another key's value")
+	}
+}
+
+func TestKey_InnerSetShadows(t *testing.T) {
+	k := New[order]("order")
+	outer, inner := &order{id: 1}, &order{id: 2}
+
+	ctx := k.Set(k.Set(context.Background(), outer), inner)
+	if got, _ := k.Get(ctx); got != inner {
+		t.Errorf("Get = %p, want the innermost value %p", got, inner)
+	}
+}
+
+func TestKey_String(t *testing.T) {
+	if got := New[order]("order").String(); got != "ctxkey.order" {
+		t.Errorf("String = %q, want ctxkey.order", got)
+	}
+}
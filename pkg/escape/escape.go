@@ -0,0 +1,116 @@
+// Package escape parses the escape-analysis diagnostics the compiler prints with
+// -gcflags=-m, so tests can assert that a value still stays on the stack.
+package escape
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kind classifies a diagnostic.
+type Kind int
+
+const (
+	// Other is any diagnostic that is not about escapes, such as "can inline f".
+	Other Kind = iota
+	// Heap is a value the compiler allocates on the heap: "x escapes to heap" or
+	// "moved to heap: x".
+	Heap
+	// Stack is a value proven not to escape: "x does not escape".
+	Stack
+	// Leak is a parameter that flows out of the function: "leaking param: p".
+	Leak
+)
+
+// String returns the kind's name.
+func (k Kind) String() string {
+	switch k {
+	case Heap:
+		return "heap"
+	case Stack:
+		return "stack"
+	case Leak:
+		return "leak"
+	default:
+		return "other"
+	}
+}
+
+// Diagnostic is one compiler message.
+type Diagnostic struct {
+	File    string
+	Line    int
+	Col     int
+	Message string
+	Kind    Kind
+	// Expr is the expression or variable the message is about, for Heap, Stack, and Leak.
+	// Composite literals read "&T{...}" in current compilers and "&T literal" in older ones.
+	Expr string
+}
+
+// position matches "file:line:col: message". The file is matched lazily so that a Windows
+// drive letter or a colon inside the message does not split it.
+var position = regexp.MustCompile(`^(.+?):(\d+):(\d+): (.*)$`)
+
+// ParseLine parses one line of compiler output. It reports false for lines without a
+// position, such as the "# package" headers go build prints.
+func ParseLine(line string) (Diagnostic, bool) {
+	m := position.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if m == nil {
+		return Diagnostic{}, false
+	}
+	lineNo, err := strconv.Atoi(m[2])
+	if err != nil {
+		return Diagnostic{}, false
+	}
+	col, err := strconv.Atoi(m[3])
+	if err != nil {
+		return Diagnostic{}, false
+	}
+
+	d := Diagnostic{File: m[1], Line: lineNo, Col: col, Message: m[4]}
+	d.Kind, d.Expr = classify(d.Message)
+	return d, true
+}
+
+// classify extracts the kind and subject of msg. With -m=2 the compiler appends a colon to
+// escape messages that are followed by an explanation, and indents the explanation lines.
+func classify(msg string) (Kind, string) {
+	if strings.HasPrefix(msg, " ") {
+		return Other, ""
+	}
+	msg = strings.TrimSuffix(msg, ":")
+
+	switch {
+	case strings.HasSuffix(msg, " escapes to heap"):
+		return Heap, strings.TrimSuffix(msg, " escapes to heap")
+	case strings.HasPrefix(msg, "moved to heap: "):
+		return Heap, strings.TrimPrefix(msg, "moved to heap: ")
+	case strings.HasSuffix(msg, " does not escape"):
+		return Stack, strings.TrimSuffix(msg, " does not escape")
+	case strings.HasPrefix(msg, "leaking param content: "):
+		return Leak, strings.TrimPrefix(msg, "leaking param content: ")
+	case strings.HasPrefix(msg, "leaking param: "):
+		expr := strings.TrimPrefix(msg, "leaking param: ")
+		// "leaking param: p to result ~r0 level=0"
+		expr, _, _ = strings.Cut(expr, " ")
+		return Leak, expr
+	default:
+		return Other, ""
+	}
+}
+
+// Parse reads compiler output and returns every positioned diagnostic in order.
+func Parse(r io.Reader) ([]Diagnostic, error) {
+	var diags []Diagnostic
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if d, ok := ParseLine(sc.Text()); ok {
+			diags = append(diags, d)
+		}
+	}
+	return diags, sc.Err()
+}
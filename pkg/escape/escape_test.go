@@ -0,0 +1,120 @@
+package escape
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Diagnostic
+		ok   bool
+	}{
+		{
+			name: "composite literal, current format",
+			line: "benchmarks/allocation_test.go:23:9: &SmallObject{...} escapes to heap",
+			want: Diagnostic{File: "benchmarks/allocation_test.go", Line: 23, Col: 9,
+				Message: "&SmallObject{...} escapes to heap", Kind: Heap, Expr: "&SmallObject{...}"},
+			ok: true,
+		},
+		{
+			name: "composite literal, pre-1.13 format",
+			line: "./allocation_test.go:23:9: &SmallObject literal escapes to heap",
+			want: Diagnostic{File: "./allocation_test.go", Line: 23, Col: 9,
+				Message: "&SmallObject literal escapes to heap", Kind: Heap, Expr: "&SmallObject literal"},
+			ok: true,
+		},
+		{
+			name: "moved to heap",
+			line: "./escape_test.go:14:2: moved to heap: o",
+			want: Diagnostic{File: "./escape_test.go", Line: 14, Col: 2,
+				Message: "moved to heap: o", Kind: Heap, Expr: "o"},
+			ok: true,
+		},
+		{
+			name: "does not escape",
+			line: "benchmarks/order.go:19:7: o does not escape",
+			want: Diagnostic{File: "benchmarks/order.go", Line: 19, Col: 7,
+				Message: "o does not escape", Kind: Stack, Expr: "o"},
+			ok: true,
+		},
+		{
+			name: "-m=2 trailing colon",
+			line: "x.go:5:9: &T{...} escapes to heap:",
+			want: Diagnostic{File: "x.go", Line: 5, Col: 9,
+				Message: "&T{...} escapes to heap:", Kind: Heap, Expr: "&T{...}"},
+			ok: true,
+		},
+		{
+			name: "-m=2 explanation line",
+			line: "x.go:5:9:   flow: ~r0 = &{storage for &T{...}}:",
+			want: Diagnostic{File: "x.go", Line: 5, Col: 9,
+				Message: "  flow: ~r0 = &{storage for &T{...}}:", Kind: Other},
+			ok: true,
+		},
+		{
+			name: "leaking param to result",
+			line: "x.go:3:8: leaking param: p to result ~r0 level=0",
+			want: Diagnostic{File: "x.go", Line: 3, Col: 8,
+				Message: "leaking param: p to result ~r0 level=0", Kind: Leak, Expr: "p"},
+			ok: true,
+		},
+		{
+			name: "leaking param content",
+			line: "x.go:3:8: leaking param content: s",
+			want: Diagnostic{File: "x.go", Line: 3, Col: 8,
+				Message: "leaking param content: s", Kind: Leak, Expr: "s"},
+			ok: true,
+		},
+		{
+			name: "inlining message",
+			line: "x.go:7:6: can inline f",
+			want: Diagnostic{File: "x.go", Line: 7, Col: 6, Message: "can inline f", Kind: Other},
+			ok:   true,
+		},
+		{
+			name: "windows path",
+			line: `C:\src\x.go:7:6: v does not escape` + "\r",
+			want: Diagnostic{File: `C:\src\x.go`, Line: 7, Col: 6,
+				Message: "v does not escape", Kind: Stack, Expr: "v"},
+			ok: true,
+		},
+		{name: "package header", line: "# golang-backend/benchmarks"},
+		{name: "blank", line: ""},
+		{name: "missing column", line: "x.go:7: can inline f"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseLine(tt.line)
+			if ok != tt.ok {
+				t.Fatalf("ParseLine(%q) ok = %v, want %v", tt.line, ok, tt.ok)
+			}
+			if ok && got != tt.want {
+				t.Errorf("ParseLine(%q) =\n\t%+v\nwant\n\t%+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	out := `# golang-backend/benchmarks
+benchmarks/allocation_test.go:22:6: can inline ReturnPointer
+benchmarks/allocation_test.go:23:9: &SmallObject{...} escapes to heap
+benchmarks/allocation_test.go:34:26: b does not escape
+`
+	diags, err := Parse(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	var kinds []string
+	for _, d := range diags {
+		kinds = append(kinds, d.Kind.String())
+	}
+	if got, want := strings.Join(kinds, ","), "other,heap,stack"; got != want {
+		t.Errorf("kinds = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+// Package gcstats measures the garbage collector's work between two points in a program
+// and prints comparisons of several measured runs.
+//
+// Take a Snapshot before and after the code of interest and subtract them:
+//
+//	before := gcstats.Read()
+//	work()
+//	d := gcstats.Read().Sub(before)
+//
+// Read calls runtime.ReadMemStats, which stops the world briefly, so take snapshots
+// around the measured region rather than inside a hot loop.
+package gcstats
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"text/tabwriter"
+	"time"
+)
+
+// Snapshot holds the cumulative collector counters at one moment.
+type Snapshot struct {
+	NumGC      uint32
+	PauseTotal time.Duration
+	TotalAlloc uint64 // bytes allocated for heap objects, never decreasing
+	Mallocs    uint64
+	At         time.Time
+}
+
+// Read returns the current counters.
+func Read() Snapshot {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return FromMemStats(&ms, time.Now())
+}
+
+// FromMemStats returns the counters in ms, stamped with at.
+func FromMemStats(ms *runtime.MemStats, at time.Time) Snapshot {
+	return Snapshot{
+		NumGC:      ms.NumGC,
+		PauseTotal: time.Duration(ms.PauseTotalNs),
+		TotalAlloc: ms.TotalAlloc,
+		Mallocs:    ms.Mallocs,
+		At:         at,
+	}
+}
+
+// Delta is the collector's work between two snapshots.
+type Delta struct {
+	NumGC      uint32
+	PauseTotal time.Duration
+	TotalAlloc uint64
+	Mallocs    uint64
+	Elapsed    time.Duration
+}
+
+// Sub returns the work done from before to s. NumGC is a uint32 that may wrap around in a
+// long-running process; the unsigned difference is still correct across one wrap.
+func (s Snapshot) Sub(before Snapshot) Delta {
+	return Delta{
+		NumGC:      s.NumGC - before.NumGC,
+		PauseTotal: s.PauseTotal - before.PauseTotal,
+		TotalAlloc: s.TotalAlloc - before.TotalAlloc,
+		Mallocs:    s.Mallocs - before.Mallocs,
+		Elapsed:    s.At.Sub(before.At),
+	}
+}
+
+// AvgPause returns the mean stop-the-world pause per collection, or 0 if none ran.
+func (d Delta) AvgPause() time.Duration {
+	if d.NumGC == 0 {
+		return 0
+	}
+	return d.PauseTotal / time.Duration(d.NumGC)
+}
+
+// GCPerSecond returns how often the collector ran, or 0 if no time elapsed.
+func (d Delta) GCPerSecond() float64 {
+	if d.Elapsed <= 0 {
+		return 0
+	}
+	return float64(d.NumGC) / d.Elapsed.Seconds()
+}
+
+// Run is one named measurement in a comparison.
+type Run struct {
+	Name  string
+	Delta Delta
+}
+
+// WriteTable prints one aligned row per run with its collection count and rate, pause
+// totals, and bytes allocated.
+func WriteTable(w io.Writer, runs []Run) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "config\tGCs\tGC/s\tpause total\tavg pause\tallocated MB\t")
+	for _, r := range runs {
+		d := r.Delta
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%v\t%v\t%.1f\t\n",
+			r.Name, d.NumGC, d.GCPerSecond(), d.PauseTotal, d.AvgPause(), float64(d.TotalAlloc)/(1<<20))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,97 @@
+package gcstats
+
+import (
+	"math"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestSub(t *testing.T) {
+	before := Snapshot{NumGC: 10, PauseTotal: 3 * time.Millisecond, TotalAlloc: 1000, Mallocs: 7, At: t0}
+	after := Snapshot{NumGC: 14, PauseTotal: 5 * time.Millisecond, TotalAlloc: 9000, Mallocs: 27, At: t0.Add(2 * time.Second)}
+
+	want := Delta{NumGC: 4, PauseTotal: 2 * time.Millisecond, TotalAlloc: 8000, Mallocs: 20, Elapsed: 2 * time.Second}
+	if got := after.Sub(before); got != want {
+		t.Errorf("Sub = %+v, want %+v", got, want)
+	}
+	if got := before.Sub(before); got != (Delta{}) {
+		t.Errorf("Sub of a snapshot from itself = %+v, want zero", got)
+	}
+}
+
+func TestSub_NumGCWraps(t *testing.T) {
+	before := Snapshot{NumGC: math.MaxUint32 - 1}
+	after := Snapshot{NumGC: 2}
+	if got := after.Sub(before).NumGC; got != 4 {
+		t.Errorf("NumGC across a wrap = %d, want 4", got)
+	}
+}
+
+func TestDelta_Rates(t *testing.T) {
+	tests := []struct {
+		name      string
+		d         Delta
+		wantPause time.Duration
+		wantRate  float64
+	}{
+		{name: "zero", d: Delta{}, wantPause: 0, wantRate: 0},
+		{name: "no GCs", d: Delta{Elapsed: time.Second}, wantPause: 0, wantRate: 0},
+		{name: "no elapsed time", d: Delta{NumGC: 3, PauseTotal: 3 * time.Millisecond}, wantPause: time.Millisecond, wantRate: 0},
+		{name: "typical", d: Delta{NumGC: 8, PauseTotal: 4 * time.Millisecond, Elapsed: 2 * time.Second}, wantPause: 500 * time.Microsecond, wantRate: 4},
+	}
+	for _, tt := range tests {
+		if got := tt.d.AvgPause(); got != tt.wantPause {
+			t.Errorf("%s: AvgPause = %v, want %v", tt.name, got, tt.wantPause)
+		}
+		if got := tt.d.GCPerSecond(); got != tt.wantRate {
+			t.Errorf("%s: GCPerSecond = %v, want %v", tt.name, got, tt.wantRate)
+		}
+	}
+}
+
+func TestFromMemStats(t *testing.T) {
+	ms := runtime.MemStats{NumGC: 5, PauseTotalNs: 1500, TotalAlloc: 64, Mallocs: 2}
+	want := Snapshot{NumGC: 5, PauseTotal: 1500 * time.Nanosecond, TotalAlloc: 64, Mallocs: 2, At: t0}
+	if got := FromMemStats(&ms, t0); got != want {
+		t.Errorf("FromMemStats = %+v, want %+v", got, want)
+	}
+}
+
+func TestRead_SeesCollections(t *testing.T) {
+	before := Read()
+	runtime.GC()
+	if got := Read().Sub(before).NumGC; got < 1 {
+		t.Errorf("NumGC after runtime.GC = %d, want at least 1", got)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	runs := []Run{
+		{Name: "GOGC=100", Delta: Delta{NumGC: 8, PauseTotal: 4 * time.Millisecond, TotalAlloc: 3 << 20, Elapsed: 2 * time.Second}},
+		{Name: "GOGC=off", Delta: Delta{TotalAlloc: 1 << 20, Elapsed: 2 * time.Second}},
+	}
+
+	var sb strings.Builder
+	if err := WriteTable(&sb, runs); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want a header and 2 rows:\n%s", len(lines), sb.String())
+	}
+
+	wantFields := [][]string{
+		{"config", "GCs", "GC/s", "pause", "total", "avg", "pause", "allocated", "MB"},
+		{"GOGC=100", "8", "4.0", "4ms", "500µs", "3.0"},
+		{"GOGC=off", "0", "0.0", "0s", "0s", "1.0"},
+	}
+	for i, want := range wantFields {
+		if got := strings.Fields(lines[i]); strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("line %d = %q, want fields %q", i, lines[i], want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Package gzippool pools gzip writers. A gzip.Writer carries several hundred KB of
+// compressor state, so allocating one per response dominates the cost of compressing it.
+package gzippool
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+
+	"golang-backend/pkg/pool"
+)
+
+// ErrInvalidLevel is returned for a compression level gzip does not accept.
+var ErrInvalidLevel = errors.New("gzippool: invalid compression level")
+
+// Pool hands out *gzip.Writer values at one compression level.
+type Pool struct {
+	writers *pool.Pool[gzip.Writer]
+}
+
+// New creates a Pool of writers at level, one of gzip.HuffmanOnly through
+// gzip.BestCompression or gzip.DefaultCompression.
+func New(level int) (*Pool, error) {
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		return nil, ErrInvalidLevel
+	}
+
+	writers := pool.New(
+		pool.WithNew(func() *gzip.Writer {
+			// The level was validated above, so this cannot fail.
+			w, _ := gzip.NewWriterLevel(io.Discard, level)
+			return w
+		}),
+		// Reset on Put drops the reference to the last destination, so pooled writers
+		// do not keep response buffers alive.
+		pool.WithReset(func(w *gzip.Writer) { w.Reset(io.Discard) }),
+	)
+	return &Pool{writers: writers}, nil
+}
+
+// Get returns a writer that compresses into dst, reset so that nothing written before it
+// was pooled, including the header of its last stream, can reach dst.
+func (p *Pool) Get(dst io.Writer) *gzip.Writer {
+	w := p.writers.Get()
+	w.Reset(dst)
+	return w
+}
+
+// Put returns w to the pool. Close w first: Put discards any data not yet flushed. The
+// caller must not use w afterwards. Putting nil is a no-op.
+func (p *Pool) Put(w *gzip.Writer) {
+	p.writers.Put(w)
+}
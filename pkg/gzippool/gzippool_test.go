@@ -0,0 +1,136 @@
+package gzippool
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+var payload = []byte(strings.Repeat(`{"price":99.5,"qty":10},`, 2000))
+
+// compress writes data through w into dst and closes w.
+func compress(t *testing.T, w *gzip.Writer, data []byte) {
+	t.Helper()
+
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func decompress(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestNew_RejectsInvalidLevel(t *testing.T) {
+	for _, level := range []int{gzip.HuffmanOnly - 1, gzip.BestCompression + 1} {
+		if _, err := New(level); !errors.Is(err, ErrInvalidLevel) {
+			t.Errorf("New(%d) error = %v, want ErrInvalidLevel", level, err)
+		}
+	}
+	for _, level := range []int{gzip.HuffmanOnly, gzip.DefaultCompression, gzip.NoCompression, gzip.BestSpeed, gzip.BestCompression} {
+		if _, err := New(level); err != nil {
+			t.Errorf("New(%d): %v", level, err)
+		}
+	}
+}
+
+func TestGet_MatchesFreshWriter(t *testing.T) {
+	var fresh bytes.Buffer
+	compress(t, gzip.NewWriter(&fresh), payload)
+
+	p, err := New(gzip.DefaultCompression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 5 {
+		var pooled bytes.Buffer
+		w := p.Get(&pooled)
+		compress(t, w, payload)
+		p.Put(w)
+
+		if !bytes.Equal(pooled.Bytes(), fresh.Bytes()) {
+			t.Fatalf("use %d: pooled output differs from a fresh writer's", i)
+		}
+		if got := decompress(t, pooled.Bytes()); !bytes.Equal(got, payload) {
+			t.Fatalf("use %d: round trip returned %d bytes, want %d", i, len(got), len(payload))
+		}
+	}
+}
+
+func TestGet_ResetsAbandonedWriter(t *testing.T) {
+	p, err := New(gzip.DefaultCompression)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A writer put back mid-stream, unclosed and with a customised header.
+	var abandoned bytes.Buffer
+	w := p.Get(&abandoned)
+	w.Name = "stale.json"
+	if _, err := w.Write([]byte("stale data that was never flushed")); err != nil {
+		t.Fatal(err)
+	}
+	p.Put(w)
+
+	var out bytes.Buffer
+	w = p.Get(&out)
+	compress(t, w, payload)
+	p.Put(w)
+
+	zr, err := gzip.NewReader(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zr.Name != "" {
+		t.Errorf("header Name = %q carried over from the previous stream", zr.Name)
+	}
+	if got, err := io.ReadAll(zr); err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("round trip = %d bytes, %v; want the %d-byte payload only", len(got), err, len(payload))
+	}
+}
+
+func TestPut_DetachesDestination(t *testing.T) {
+	p, err := New(gzip.BestSpeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dst bytes.Buffer
+	w := p.Get(&dst)
+	compress(t, w, payload)
+	n := dst.Len()
+	p.Put(w)
+
+	// Using w after Put is only done here to prove it no longer writes to dst.
+	compress(t, w, payload)
+	if dst.Len() != n {
+		t.Errorf("writer still wrote to its old destination after Put: %d bytes, want %d", dst.Len(), n)
+	}
+}
+
+func TestPut_Nil(t *testing.T) {
+	p, err := New(gzip.DefaultCompression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Put(nil)
+	if w := p.Get(io.Discard); w == nil {
+		t.Fatal("Get returned nil after Put(nil)")
+	}
+}
@@ -0,0 +1,149 @@
+// Package hashring provides a consistent-hash ring, so adding or removing one of N nodes
+// moves about 1/N of the keys instead of reshuffling nearly all of them the way hashing
+// modulo N does.
+package hashring
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrInvalidSize is returned when a ring is created with fewer than one virtual node per node.
+var ErrInvalidSize = errors.New("hashring: virtual nodes must be positive")
+
+// DefaultVirtualNodes places each node at 160 points on the ring, enough to keep the
+// busiest node within about 25% of the average at 64 nodes.
+const DefaultVirtualNodes = 160
+
+// Ring maps keys to nodes. Each node is hashed onto the ring at vnodes points, and a key
+// belongs to the first point at or after its own hash, wrapping around at the top.
+//
+// A Ring is safe for concurrent use. Get reads an immutable snapshot without locking; Add
+// and Remove rebuild the snapshot under a mutex and publish it atomically, so membership
+// changes are expected to be rare next to lookups.
+type Ring struct {
+	vnodes int
+	mu     sync.Mutex // serializes Add and Remove
+	state  atomic.Pointer[ringState]
+}
+
+// ringState is one published membership. It is never modified once stored.
+type ringState struct {
+	nodes  []string // sorted
+	hashes []uint64 // sorted ring positions
+	owners []string // owners[i] is the node at hashes[i]
+}
+
+// New creates an empty Ring placing each node at vnodes points.
+func New(vnodes int) (*Ring, error) {
+	if vnodes <= 0 {
+		return nil, ErrInvalidSize
+	}
+	r := &Ring{vnodes: vnodes}
+	r.state.Store(&ringState{})
+	return r, nil
+}
+
+// Add adds node to the ring. Adding a node already present does nothing.
+func (r *Ring) Add(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	nodes := r.state.Load().nodes
+	i, found := slices.BinarySearch(nodes, node)
+	if found {
+		return
+	}
+	r.state.Store(r.build(slices.Insert(slices.Clone(nodes), i, node)))
+}
+
+// Remove removes node from the ring. Removing a node not present does nothing.
+func (r *Ring) Remove(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	nodes := r.state.Load().nodes
+	i, found := slices.BinarySearch(nodes, node)
+	if !found {
+		return
+	}
+	r.state.Store(r.build(slices.Delete(slices.Clone(nodes), i, i+1)))
+}
+
+// build places every node's virtual nodes and sorts them into a new snapshot. Points that
+// collide are ordered by node name, so the ring depends only on its members and not on the
+// order they were added in.
+func (r *Ring) build(nodes []string) *ringState {
+	type point struct {
+		hash  uint64
+		owner string
+	}
+	points := make([]point, 0, len(nodes)*r.vnodes)
+	for _, node := range nodes {
+		h := hashString(node)
+		for v := range r.vnodes {
+			points = append(points, point{hash: mix(h + uint64(v)*0x9e3779b97f4a7c15), owner: node})
+		}
+	}
+	slices.SortFunc(points, func(a, b point) int {
+		return cmp.Or(cmp.Compare(a.hash, b.hash), cmp.Compare(a.owner, b.owner))
+	})
+
+	s := &ringState{
+		nodes:  nodes,
+		hashes: make([]uint64, len(points)),
+		owners: make([]string, len(points)),
+	}
+	for i, p := range points {
+		s.hashes[i], s.owners[i] = p.hash, p.owner
+	}
+	return s
+}
+
+// Get returns the node that owns key, or false if the ring is empty. It does not allocate.
+func (r *Ring) Get(key string) (string, bool) {
+	s := r.state.Load()
+	if len(s.hashes) == 0 {
+		return "", false
+	}
+	i, _ := slices.BinarySearch(s.hashes, hashString(key))
+	if i == len(s.hashes) {
+		i = 0
+	}
+	return s.owners[i], true
+}
+
+// Nodes returns the ring's members in sorted order.
+func (r *Ring) Nodes() []string {
+	return slices.Clone(r.state.Load().nodes)
+}
+
+// Len returns the number of nodes in the ring.
+func (r *Ring) Len() int {
+	return len(r.state.Load().nodes)
+}
+
+// hashString is 64-bit FNV-1a with a final mix. Unlike maphash it uses no per-process
+// seed, so every process sharing a membership list routes a key to the same node.
+func hashString(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return mix(h)
+}
+
+// mix is the splitmix64 finalizer. FNV-1a leaves keys that differ only in their last byte
+// close together, which would cluster one node's virtual nodes on the ring.
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
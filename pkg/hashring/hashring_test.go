@@ -0,0 +1,219 @@
+package hashring
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func newRing(t testing.TB, vnodes int, nodes ...string) *Ring {
+	t.Helper()
+	r, err := New(vnodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range nodes {
+		r.Add(n)
+	}
+	return r
+}
+
+func nodeNames(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = "node-" + strconv.Itoa(i)
+	}
+	return names
+}
+
+func testKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "order-" + strconv.Itoa(i)
+	}
+	return keys
+}
+
+func TestNew_RejectsNonPositive(t *testing.T) {
+	for _, v := range []int{0, -1} {
+		if r, err := New(v); err != ErrInvalidSize || r != nil {
+			t.Errorf("New(%d) = %v, %v; want nil, ErrInvalidSize", v, r, err)
+		}
+	}
+}
+
+func TestGet_EmptyRing(t *testing.T) {
+	r := newRing(t, DefaultVirtualNodes)
+	if node, ok := r.Get("order-1"); ok || node != "" {
+		t.Errorf("Get on empty ring = %q, %v; want \"\", false", node, ok)
+	}
+
+	r.Add("a")
+	r.Remove("a")
+	if _, ok := r.Get("order-1"); ok || r.Len() != 0 {
+		t.Errorf("Get after removing the only node found a node; Len = %d", r.Len())
+	}
+}
+
+func TestAddRemove_Idempotent(t *testing.T) {
+	r := newRing(t, 4, "b", "a", "c", "a")
+	if got, want := r.Nodes(), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Nodes = %v, want %v", got, want)
+	}
+	r.Remove("missing")
+	r.Remove("b")
+	r.Remove("b")
+	if got, want := r.Nodes(), []string{"a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Nodes after removing b = %v, want %v", got, want)
+	}
+
+	// Nodes returns a copy.
+	r.Nodes()[0] = "changed"
+	if r.Nodes()[0] != "a" {
+		t.Error("editing the slice from Nodes changed the ring")
+	}
+}
+
+func TestGet_IndependentOfInsertionOrder(t *testing.T) {
+	names := nodeNames(16)
+	reversed := slices.Clone(names)
+	slices.Reverse(reversed)
+	r1 := newRing(t, DefaultVirtualNodes, names...)
+	r2 := newRing(t, DefaultVirtualNodes, reversed...)
+
+	for _, k := range testKeys(10_000) {
+		n1, _ := r1.Get(k)
+		n2, _ := r2.Get(k)
+		if n1 != n2 {
+			t.Fatalf("Get(%q) = %q or %q depending on insertion order", k, n1, n2)
+		}
+	}
+}
+
+func TestGet_Balanced(t *testing.T) {
+	keys := testKeys(1_000_000)
+	for _, n := range []int{8, 64} {
+		r := newRing(t, DefaultVirtualNodes, nodeNames(n)...)
+		loads := map[string]int{}
+		for _, k := range keys {
+			node, _ := r.Get(k)
+			loads[node]++
+		}
+		if len(loads) != n {
+			t.Fatalf("%d nodes: keys landed on %d of them", n, len(loads))
+		}
+		mean := float64(len(keys)) / float64(n)
+		for node, l := range loads {
+			if float64(l) > 1.25*mean || float64(l) < 0.75*mean {
+				t.Errorf("%d nodes: %s holds %d keys, more than 25%% from the mean %.0f", n, node, l, mean)
+			}
+		}
+	}
+}
+
+// TestRemove_MovesOnlyRemovedKeys checks the property the ring exists for: removing a node
+// reassigns exactly the keys it owned, about 1/N of them, and leaves every other key alone.
+func TestRemove_MovesOnlyRemovedKeys(t *testing.T) {
+	keys := testKeys(1_000_000)
+	for _, n := range []int{8, 64} {
+		r := newRing(t, DefaultVirtualNodes, nodeNames(n)...)
+		before := make([]string, len(keys))
+		for i, k := range keys {
+			before[i], _ = r.Get(k)
+		}
+
+		removed := "node-3"
+		r.Remove(removed)
+		moved := 0
+		for i, k := range keys {
+			after, _ := r.Get(k)
+			if after == removed {
+				t.Fatalf("%d nodes: %q still routed to removed node", n, k)
+			}
+			if after != before[i] {
+				if before[i] != removed {
+					t.Fatalf("%d nodes: %q moved from %s to %s, but %s was not removed", n, k, before[i], after, before[i])
+				}
+				moved++
+			}
+		}
+		frac := float64(moved) / float64(len(keys))
+		if want := 1 / float64(n); frac < 0.75*want || frac > 1.25*want {
+			t.Errorf("%d nodes: removing one moved %.2f%% of keys, want about %.2f%%", n, 100*frac, 100*want)
+		}
+
+		// Adding it back restores the original assignment.
+		r.Add(removed)
+		for i, k := range keys {
+			if node, _ := r.Get(k); node != before[i] {
+				t.Fatalf("%d nodes: %q on %s after re-adding %s, was on %s", n, k, node, removed, before[i])
+			}
+		}
+	}
+}
+
+func TestGet_DoesNotAllocate(t *testing.T) {
+	r := newRing(t, DefaultVirtualNodes, nodeNames(8)...)
+	if allocs := testing.AllocsPerRun(100, func() { r.Get("order-42") }); allocs != 0 {
+		t.Errorf("Get allocated %v times, want 0", allocs)
+	}
+}
+
+// TestGet_ConcurrentWithMembershipChanges is meant for -race: readers look keys up while a
+// writer keeps adding and removing nodes, and every answer must name a node that was a
+// member at some point.
+func TestGet_ConcurrentWithMembershipChanges(t *testing.T) {
+	stable := nodeNames(4)
+	r := newRing(t, 32, stable...)
+	members := map[string]bool{}
+	for _, n := range stable {
+		members[n] = true
+	}
+	var churn []string
+	for i := range 4 {
+		name := fmt.Sprintf("churn-%d", i)
+		churn = append(churn, name)
+		members[name] = true
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			keys := testKeys(1_000)
+			for i := 0; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				node, ok := r.Get(keys[(i+g)%len(keys)])
+				if !ok || !members[node] {
+					t.Errorf("Get = %q, %v; want a member", node, ok)
+					return
+				}
+				r.Len()
+			}
+		}()
+	}
+
+	for i := range 200 {
+		name := churn[i%len(churn)]
+		r.Add(name)
+		if i%3 == 0 {
+			r.Remove(name)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	for _, n := range stable {
+		if !slices.Contains(r.Nodes(), n) {
+			t.Errorf("stable node %s missing after churn", n)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package intern deduplicates strings so that many records carrying the same value, such
+// as a symbol parsed from every line of a feed, share one copy of its bytes.
+//
+// Map keeps every string it has seen until it is dropped, which suits a small, known set
+// of values. Unique delegates to the standard library's unique package, whose entries
+// are freed once nothing references them.
+package intern
+
+import (
+	"sync"
+	"unique"
+)
+
+// Interner returns a canonical copy of s: equal inputs yield strings sharing the same
+// memory.
+type Interner interface {
+	Intern(s string) string
+}
+
+// Map is an Interner backed by a map guarded by a read-write mutex. It is safe for
+// concurrent use. Its entries are never evicted, so it grows with the number of distinct
+// strings interned.
+type Map struct {
+	mu sync.RWMutex
+	m  map[string]string
+}
+
+// NewMap creates an empty Map.
+func NewMap() *Map {
+	return &Map{m: make(map[string]string)}
+}
+
+// Intern returns the canonical copy of s, storing s itself if it is new.
+func (m *Map) Intern(s string) string {
+	m.mu.RLock()
+	c, ok := m.m[s]
+	m.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.m[s]; ok {
+		return c
+	}
+	m.m[s] = s
+	return s
+}
+
+// InternBytes returns the canonical copy of string(b). Unlike Intern(string(b)), it does
+// not allocate when the string is already interned, so a parser can intern straight from
+// its read buffer. A new string is copied out of b, and b may be reused afterwards.
+func (m *Map) InternBytes(b []byte) string {
+	m.mu.RLock()
+	c, ok := m.m[string(b)]
+	m.mu.RUnlock()
+	if ok {
+		return c
+	}
+	return m.Intern(string(b))
+}
+
+// Len returns the number of distinct strings interned.
+func (m *Map) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.m)
+}
+
+// Unique is an Interner built on unique.Make. It is safe for concurrent use, needs no
+// setup, and shares one process-wide table, so its zero value is ready to use.
+type Unique struct{}
+
+// Intern returns the canonical copy of s.
+func (Unique) Intern(s string) string {
+	return unique.Make(s).Value()
+}
@@ -0,0 +1,112 @@
+package intern
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+var interners = []struct {
+	name string
+	new  func() Interner
+}{
+	{name: "map", new: func() Interner { return NewMap() }},
+	{name: "unique", new: func() Interner { return Unique{} }},
+}
+
+// sameMemory reports whether a and b share their bytes, not merely compare equal.
+func sameMemory(a, b string) bool {
+	return len(a) == len(b) && unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func TestIntern_EqualInputsShareMemory(t *testing.T) {
+	for _, in := range interners {
+		it := in.new()
+		// strings.Clone gives each input its own memory.
+		a := it.Intern(strings.Clone("AAPL"))
+		b := it.Intern(strings.Clone("AAPL"))
+		c := it.Intern(strings.Clone("MSFT"))
+
+		if a != "AAPL" || b != "AAPL" || c != "MSFT" {
+			t.Errorf("%s: Intern changed values: %q %q %q", in.name, a, b, c)
+		}
+		if !sameMemory(a, b) {
+			t.Errorf("%s: two interned copies of %q do not share memory", in.name, a)
+		}
+		if sameMemory(a, c) {
+			t.Errorf("%s: %q and %q share memory", in.name, a, c)
+		}
+	}
+}
+
+func TestIntern_EmptyString(t *testing.T) {
+	for _, in := range interners {
+		if got := in.new().Intern(""); got != "" {
+			t.Errorf("%s: Intern(\"\") = %q", in.name, got)
+		}
+	}
+}
+
+func TestMap_InternBytes(t *testing.T) {
+	m := NewMap()
+	buf := []byte("GOOG")
+	s := m.InternBytes(buf)
+
+	// The interned string must not alias the caller's buffer.
+	copy(buf, "XXXX")
+	if s != "GOOG" {
+		t.Fatalf("interned string changed with its source buffer: %q", s)
+	}
+
+	buf = []byte("GOOG")
+	if got := m.InternBytes(buf); !sameMemory(got, s) {
+		t.Error("InternBytes of an interned value returned a new copy")
+	}
+	if got := m.Intern("GOOG"); !sameMemory(got, s) {
+		t.Error("Intern and InternBytes returned different copies")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { m.InternBytes(buf) }); allocs != 0 {
+		t.Errorf("InternBytes on a hit allocated %v times, want 0", allocs)
+	}
+	if m.Len() != 1 {
+		t.Errorf("Len = %d, want 1", m.Len())
+	}
+}
+
+func TestIntern_Concurrent(t *testing.T) {
+	const (
+		goroutines = 16
+		distinct   = 50
+	)
+	for _, in := range interners {
+		it := in.new()
+		results := make([][distinct]string, goroutines)
+
+		var wg sync.WaitGroup
+		for g := range goroutines {
+			wg.Go(func() {
+				for round := range 20 {
+					for i := range distinct {
+						// Each goroutine walks the values in a different order.
+						k := (i + g*7 + round) % distinct
+						results[g][k] = it.Intern(fmt.Sprintf("SYM%03d", k))
+					}
+				}
+			})
+		}
+		wg.Wait()
+
+		for g := 1; g < goroutines; g++ {
+			for k := range distinct {
+				if !sameMemory(results[g][k], results[0][k]) {
+					t.Fatalf("%s: goroutines 0 and %d got different copies of %q", in.name, g, results[0][k])
+				}
+			}
+		}
+		if m, ok := it.(*Map); ok && m.Len() != distinct {
+			t.Errorf("map: Len = %d, want %d", m.Len(), distinct)
+		}
+	}
+}
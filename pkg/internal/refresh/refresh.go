@@ -0,0 +1,107 @@
+// Package refresh runs the ticker goroutine behind values that are cached and refreshed
+// periodically, such as cachedclock's timestamp string and coarseclock's time.
+package refresh
+
+import (
+	"sync"
+	"time"
+)
+
+// Config holds a Loop's time sources.
+type Config struct {
+	Now       func() time.Time
+	NewTicker func(d time.Duration) (<-chan time.Time, func())
+}
+
+// Option configures a Loop.
+type Option func(*Config)
+
+// WithClock replaces time.Now as the time source.
+func WithClock(now func() time.Time) Option {
+	return func(c *Config) {
+		c.Now = now
+	}
+}
+
+// WithTicker replaces time.NewTicker. newTicker returns the tick channel and a function
+// that stops it.
+func WithTicker(newTicker func(d time.Duration) (<-chan time.Time, func())) Option {
+	return func(c *Config) {
+		c.NewTicker = newTicker
+	}
+}
+
+// Loop calls a refresh function every interval on a background goroutine while started.
+// Calls to refresh never overlap: Start makes one before launching the goroutine, and the
+// goroutine makes the rest until Stop returns.
+type Loop struct {
+	Config
+	interval time.Duration
+	refresh  func()
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates a stopped Loop calling refresh every interval.
+func New(interval time.Duration, refresh func(), opts ...Option) *Loop {
+	l := &Loop{
+		Config: Config{
+			Now: time.Now,
+			NewTicker: func(d time.Duration) (<-chan time.Time, func()) {
+				t := time.NewTicker(d)
+				return t.C, t.Stop
+			},
+		},
+		interval: interval,
+		refresh:  refresh,
+	}
+	for _, opt := range opts {
+		opt(&l.Config)
+	}
+	return l
+}
+
+// Start calls refresh now and then every interval. Starting a running Loop does nothing.
+func (l *Loop) Start() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stop != nil {
+		return
+	}
+
+	l.refresh()
+	l.stop = make(chan struct{})
+	l.done = make(chan struct{})
+	ticks, stopTicker := l.NewTicker(l.interval)
+	go l.run(ticks, stopTicker, l.stop, l.done)
+}
+
+func (l *Loop) run(ticks <-chan time.Time, stopTicker func(), stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	defer stopTicker()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticks:
+			l.refresh()
+		}
+	}
+}
+
+// Stop ends the refresh goroutine and waits for it to exit. Stopping a Loop that is not
+// running does nothing; it can be started again.
+func (l *Loop) Stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stop == nil {
+		return
+	}
+
+	close(l.stop)
+	<-l.done
+	l.stop, l.done = nil, nil
+}
@@ -0,0 +1,115 @@
+package refresh
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang-backend/pkg/internal/refresh/refreshtest"
+)
+
+// waitForGoroutines waits up to a second for the goroutine count to drop to want.
+func waitForGoroutines(t *testing.T, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > want {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines running, want %d", runtime.NumGoroutine(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLoop_RefreshesOnStartAndTick(t *testing.T) {
+	var calls atomic.Int32
+	ticker := refreshtest.NewTicker()
+	l := New(time.Second, func() { calls.Add(1) }, WithTicker(ticker.New))
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("New refreshed %d times, want 0", got)
+	}
+
+	l.Start()
+	defer l.Stop()
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Start refreshed %d times, want 1", got)
+	}
+	ticker.Tick()
+	if got := calls.Load(); got < 2 {
+		t.Errorf("refreshed %d times after ticks, want at least 2", got)
+	}
+}
+
+func TestLoop_WithClock(t *testing.T) {
+	var now refreshtest.Now
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	now.Set(want)
+
+	l := New(time.Second, func() {}, WithClock(now.Now))
+	if got := l.Now(); !got.Equal(want) {
+		t.Errorf("Now = %v, want %v from the injected clock", got, want)
+	}
+}
+
+func TestStop_EndsLoop(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ticker := refreshtest.NewTicker()
+	var calls atomic.Int32
+	l := New(time.Second, func() { calls.Add(1) }, WithTicker(ticker.New))
+
+	l.Start()
+	ticker.Tick()
+	l.Stop()
+	waitForGoroutines(t, before)
+
+	if got := ticker.Stopped(); got != 1 {
+		t.Errorf("ticker stopped %d times, want 1", got)
+	}
+	// Nothing refreshes once Stop has returned.
+	n := calls.Load()
+	time.Sleep(5 * time.Millisecond)
+	if got := calls.Load(); got != n {
+		t.Errorf("refreshed %d more times after Stop", got-n)
+	}
+}
+
+func TestStartStop_Idempotent(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ticker := refreshtest.NewTicker()
+	l := New(time.Second, func() {}, WithTicker(ticker.New))
+
+	l.Stop() // not running
+	l.Start()
+	l.Start()
+	if got := ticker.Started(); got != 1 {
+		t.Errorf("second Start created a ticker: %d started, want 1", got)
+	}
+	l.Stop()
+	l.Stop()
+
+	// A stopped loop can be started again.
+	l.Start()
+	ticker.Tick()
+	l.Stop()
+	waitForGoroutines(t, before)
+
+	if started, stopped := ticker.Started(), ticker.Stopped(); started != 2 || stopped != 2 {
+		t.Errorf("tickers started %d, stopped %d; want 2 and 2", started, stopped)
+	}
+}
+
+func TestLoop_RealTicker(t *testing.T) {
+	var calls atomic.Int32
+	l := New(time.Millisecond, func() { calls.Add(1) })
+	l.Start()
+	defer l.Stop()
+
+	deadline := time.Now().Add(time.Second)
+	for calls.Load() < 5 {
+		if time.Now().After(deadline) {
+			t.Fatalf("refreshed %d times in a second at a 1ms interval", calls.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,52 @@
+// Package refreshtest provides a hand-driven ticker and a settable time source for testing
+// packages built on refresh.
+package refreshtest
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Ticker is a tick channel driven by the test. Pass its New method to a package's
+// WithTicker option.
+type Ticker struct {
+	c       chan time.Time
+	started atomic.Int32
+	stopped atomic.Int32
+}
+
+// NewTicker returns a Ticker that has not been started.
+func NewTicker() *Ticker {
+	return &Ticker{c: make(chan time.Time)}
+}
+
+// New has the signature WithTicker expects. It records the start and returns a stop
+// function that records the stop.
+func (t *Ticker) New(time.Duration) (<-chan time.Time, func()) {
+	t.started.Add(1)
+	return t.c, func() { t.stopped.Add(1) }
+}
+
+// Tick delivers two ticks. The channel is unbuffered, so once the second send returns the
+// refresh triggered by the first has completed.
+func (t *Ticker) Tick() {
+	t.c <- time.Time{}
+	t.c <- time.Time{}
+}
+
+// Started returns how many times New has been called.
+func (t *Ticker) Started() int { return int(t.started.Load()) }
+
+// Stopped returns how many of the tickers New returned have been stopped.
+func (t *Ticker) Stopped() int { return int(t.stopped.Load()) }
+
+// Now is a settable time source, safe to set while a refresh goroutine reads it.
+type Now struct {
+	t atomic.Pointer[time.Time]
+}
+
+// Set makes Now return t.
+func (n *Now) Set(t time.Time) { n.t.Store(&t) }
+
+// Now returns the time last set.
+func (n *Now) Now() time.Time { return *n.t.Load() }
@@ -0,0 +1,37 @@
+// Package iocopy copies between readers and writers using pooled buffers,
+// avoiding the 32KB allocation io.Copy makes on every call without a fast path.
+package iocopy
+
+import (
+	"io"
+
+	"golang-backend/pkg/pool"
+)
+
+// BufferSize is the size of each pooled copy buffer, matching io.Copy's internal buffer.
+const BufferSize = 32 << 10
+
+// bufferPool is the part of pool.Pool that CopyPooled uses, so tests can substitute a
+// pool.Metered and count Gets and Puts without production paying for the counters.
+type bufferPool interface {
+	Get() *[]byte
+	Put(*[]byte)
+}
+
+func newBuffer() *[]byte {
+	b := make([]byte, BufferSize)
+	return &b
+}
+
+var buffers bufferPool = pool.New(pool.WithNew(newBuffer))
+
+// CopyPooled copies from src to dst like io.Copy, but borrows its buffer from a pool.
+// The buffer is returned to the pool before CopyPooled returns, including on error.
+// As with io.CopyBuffer, the buffer is unused when src implements io.WriterTo or
+// dst implements io.ReaderFrom.
+func CopyPooled(dst io.Writer, src io.Reader) (int64, error) {
+	buf := buffers.Get()
+	defer buffers.Put(buf)
+
+	return io.CopyBuffer(dst, src, *buf)
+}
@@ -0,0 +1,131 @@
+package iocopy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime/debug"
+	"testing"
+
+	"golang-backend/pkg/pool"
+)
+
+// meterBuffers replaces the buffer pool with a metered one for the rest of the test.
+func meterBuffers(t *testing.T) *pool.Metered[[]byte] {
+	t.Helper()
+
+	m := pool.NewMetered(pool.WithNew(newBuffer))
+	prev := buffers
+	buffers = m
+	t.Cleanup(func() { buffers = prev })
+	return m
+}
+
+// onlyReader and onlyWriter hide any WriterTo/ReaderFrom fast path of the wrapped value.
+type onlyReader struct{ r io.Reader }
+
+func (o onlyReader) Read(p []byte) (int, error) { return o.r.Read(p) }
+
+type onlyWriter struct{ w io.Writer }
+
+func (o onlyWriter) Write(p []byte) (int, error) { return o.w.Write(p) }
+
+// failingReader returns data, then err.
+type failingReader struct {
+	data []byte
+	err  error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if len(f.data) == 0 {
+		return 0, f.err
+	}
+	n := copy(p, f.data)
+	f.data = f.data[n:]
+	return n, nil
+}
+
+var errWrite = errors.New("write failed")
+
+// errWriter fails every Write.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errWrite }
+
+func payload(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i * 31)
+	}
+	return b
+}
+
+func TestCopyPooled_ByteExact(t *testing.T) {
+	for _, n := range []int{0, 1, BufferSize - 1, BufferSize, BufferSize + 1, 1 << 20} {
+		src := payload(n)
+		var dst bytes.Buffer
+
+		written, err := CopyPooled(onlyWriter{&dst}, onlyReader{bytes.NewReader(src)})
+		if err != nil {
+			t.Fatalf("n=%d: CopyPooled: %v", n, err)
+		}
+		if written != int64(n) {
+			t.Errorf("n=%d: wrote %d bytes", n, written)
+		}
+		if !bytes.Equal(dst.Bytes(), src) {
+			t.Errorf("n=%d: copied bytes differ from source", n)
+		}
+	}
+}
+
+func TestCopyPooled_ReturnsBufferOnError(t *testing.T) {
+	errRead := errors.New("read failed")
+	m := meterBuffers(t)
+
+	var dst bytes.Buffer
+	src := &failingReader{data: payload(100), err: errRead}
+	written, err := CopyPooled(onlyWriter{&dst}, src)
+	if !errors.Is(err, errRead) {
+		t.Fatalf("CopyPooled error = %v, want %v", err, errRead)
+	}
+	if written != 100 {
+		t.Errorf("wrote %d bytes before the error, want 100", written)
+	}
+
+	if st := m.Stats(); st.Gets != 1 || st.Puts != 1 {
+		t.Errorf("Gets %d, Puts %d, want 1 each", st.Gets, st.Puts)
+	}
+}
+
+func TestCopyPooled_WriteError(t *testing.T) {
+	m := meterBuffers(t)
+
+	_, err := CopyPooled(onlyWriter{errWriter{}}, onlyReader{bytes.NewReader(payload(10))})
+	if !errors.Is(err, errWrite) {
+		t.Fatalf("CopyPooled error = %v, want %v", err, errWrite)
+	}
+
+	if st := m.Stats(); st.Puts != 1 {
+		t.Errorf("Puts %d after write error, want 1", st.Puts)
+	}
+}
+
+func TestCopyPooled_ReusesBuffer(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops objects at random under the race detector")
+	}
+	// A GC between copies would legitimately empty the pool.
+	defer debug.SetGCPercent(debug.SetGCPercent(-1))
+
+	m := meterBuffers(t)
+	for range 10 {
+		var dst bytes.Buffer
+		if _, err := CopyPooled(onlyWriter{&dst}, onlyReader{bytes.NewReader(payload(100))}); err != nil {
+			t.Fatalf("CopyPooled: %v", err)
+		}
+	}
+	// Each copy returns the buffer before the next borrows it, so one is allocated.
+	if st := m.Stats(); st.Gets != 10 || st.Puts != 10 || st.News != 1 {
+		t.Errorf("Stats = %+v, want 10 Gets and Puts sharing one buffer", st)
+	}
+}
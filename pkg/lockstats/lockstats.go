@@ -0,0 +1,85 @@
+// Package lockstats provides a sync.Mutex that records how often, and for how long,
+// goroutines waited to acquire it.
+package lockstats
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a snapshot of a Mutex's counters.
+// Every Lock is either uncontended or contended, so Locks >= Contended; only contended
+// acquisitions contribute to TotalWait and MaxWait.
+type Stats struct {
+	Locks     uint64
+	Contended uint64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// MeanWait returns the average wait of a contended acquisition, or 0 if there were none.
+func (s Stats) MeanWait() time.Duration {
+	if s.Contended == 0 {
+		return 0
+	}
+	return s.TotalWait / time.Duration(s.Contended)
+}
+
+// Mutex is a sync.Mutex that counts acquisitions and times the contended ones with
+// atomics. An uncontended Lock costs one TryLock and one atomic add; only a Lock that has
+// to wait reads the clock. The zero value is an unlocked Mutex.
+type Mutex struct {
+	mu sync.Mutex
+
+	locks     atomic.Uint64
+	contended atomic.Uint64
+	waitNanos atomic.Int64
+	maxNanos  atomic.Int64
+}
+
+// Lock locks m, recording the time spent waiting if m was already held.
+func (m *Mutex) Lock() {
+	m.locks.Add(1)
+	if m.mu.TryLock() {
+		return
+	}
+
+	start := time.Now()
+	m.mu.Lock()
+	wait := int64(time.Since(start))
+
+	m.contended.Add(1)
+	m.waitNanos.Add(wait)
+	for {
+		cur := m.maxNanos.Load()
+		if wait <= cur || m.maxNanos.CompareAndSwap(cur, wait) {
+			break
+		}
+	}
+}
+
+// Unlock unlocks m. As with sync.Mutex, unlocking an unlocked Mutex is a run-time error.
+func (m *Mutex) Unlock() {
+	m.mu.Unlock()
+}
+
+// Stats returns the current counter values.
+// Counters are read individually, so a snapshot taken during concurrent use may not reconcile exactly.
+func (m *Mutex) Stats() Stats {
+	return Stats{
+		Locks:     m.locks.Load(),
+		Contended: m.contended.Load(),
+		TotalWait: time.Duration(m.waitNanos.Load()),
+		MaxWait:   time.Duration(m.maxNanos.Load()),
+	}
+}
+
+// ResetStats zeroes the counters, for measuring one phase of a run. It does not touch the
+// lock itself.
+func (m *Mutex) ResetStats() {
+	m.locks.Store(0)
+	m.contended.Store(0)
+	m.waitNanos.Store(0)
+	m.maxNanos.Store(0)
+}
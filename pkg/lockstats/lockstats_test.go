@@ -0,0 +1,141 @@
+package lockstats
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+var _ sync.Locker = (*Mutex)(nil)
+
+func TestMutex_UncontendedCountsWithoutWait(t *testing.T) {
+	var m Mutex
+	for range 10 {
+		m.Lock()
+		m.Unlock()
+	}
+	st := m.Stats()
+	if st.Locks != 10 || st.Contended != 0 || st.TotalWait != 0 || st.MaxWait != 0 {
+		t.Errorf("Stats = %+v, want 10 locks and no contention", st)
+	}
+	if st.MeanWait() != 0 {
+		t.Errorf("MeanWait with no contention = %v, want 0", st.MeanWait())
+	}
+}
+
+// lockWhileHeld starts a goroutine that locks m, which the caller already holds, and
+// returns once that goroutine is about to block, plus a channel closed when it gets the lock.
+func lockWhileHeld(m *Mutex) <-chan struct{} {
+	started := make(chan struct{})
+	acquired := make(chan struct{})
+	go func() {
+		close(started)
+		m.Lock()
+		m.Unlock()
+		close(acquired)
+	}()
+	<-started
+	return acquired
+}
+
+func TestMutex_RecordsContendedWait(t *testing.T) {
+	const hold = 20 * time.Millisecond
+	var m Mutex
+	m.Lock()
+	acquired := lockWhileHeld(&m)
+	time.Sleep(hold)
+	m.Unlock()
+	<-acquired
+
+	st := m.Stats()
+	if st.Locks != 2 || st.Contended != 1 {
+		t.Fatalf("Stats = %+v, want 2 locks, 1 contended", st)
+	}
+	// The waiter blocks for most of hold, less the time it took to reach Lock.
+	if st.MaxWait < hold/2 || st.MaxWait > time.Second {
+		t.Errorf("MaxWait = %v, want about %v", st.MaxWait, hold)
+	}
+	if st.TotalWait != st.MaxWait || st.MeanWait() != st.MaxWait {
+		t.Errorf("one contended lock: TotalWait %v, MeanWait %v, MaxWait %v; want all equal", st.TotalWait, st.MeanWait(), st.MaxWait)
+	}
+}
+
+func TestMutex_MaxKeepsLongestWait(t *testing.T) {
+	var m Mutex
+	for _, hold := range []time.Duration{30 * time.Millisecond, time.Millisecond} {
+		m.Lock()
+		acquired := lockWhileHeld(&m)
+		time.Sleep(hold)
+		m.Unlock()
+		<-acquired
+	}
+
+	st := m.Stats()
+	if st.Contended != 2 {
+		t.Fatalf("Contended = %d, want 2", st.Contended)
+	}
+	if st.MaxWait < 15*time.Millisecond {
+		t.Errorf("MaxWait = %v, want the first, longer wait kept", st.MaxWait)
+	}
+	if st.TotalWait < st.MaxWait || st.MeanWait() != st.TotalWait/2 {
+		t.Errorf("TotalWait %v, MeanWait %v for MaxWait %v", st.TotalWait, st.MeanWait(), st.MaxWait)
+	}
+}
+
+func TestMutex_ResetStats(t *testing.T) {
+	var m Mutex
+	m.Lock()
+	acquired := lockWhileHeld(&m)
+	time.Sleep(time.Millisecond)
+	m.Unlock()
+	<-acquired
+
+	m.ResetStats()
+	if st := m.Stats(); st != (Stats{}) {
+		t.Errorf("Stats after ResetStats = %+v, want zero", st)
+	}
+	m.Lock()
+	m.Unlock()
+	if st := m.Stats(); st.Locks != 1 {
+		t.Errorf("Locks after reset and one Lock = %d, want 1", st.Locks)
+	}
+}
+
+// TestMutex_ConcurrentAccounting is meant for -race: the Mutex must still exclude, and
+// every Lock must be counted.
+func TestMutex_ConcurrentAccounting(t *testing.T) {
+	const (
+		goroutines = 8
+		cycles     = 1000
+	)
+	var (
+		m  Mutex
+		n  int
+		wg sync.WaitGroup
+	)
+	for range goroutines {
+		wg.Go(func() {
+			for range cycles {
+				m.Lock()
+				n++
+				m.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	st := m.Stats()
+	if n != goroutines*cycles || st.Locks != goroutines*cycles {
+		t.Errorf("n = %d, Locks = %d, want %d each", n, st.Locks, goroutines*cycles)
+	}
+	if st.Contended > st.Locks || st.MaxWait > st.TotalWait {
+		t.Errorf("inconsistent Stats %+v", st)
+	}
+}
+
+func TestMutex_UncontendedDoesNotAllocate(t *testing.T) {
+	var m Mutex
+	if allocs := testing.AllocsPerRun(100, func() { m.Lock(); m.Unlock() }); allocs != 0 {
+		t.Errorf("Lock and Unlock allocated %v times, want 0", allocs)
+	}
+}
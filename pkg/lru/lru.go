@@ -0,0 +1,105 @@
+// Package lru provides a fixed-capacity least-recently-used cache.
+package lru
+
+import (
+	"container/list"
+	"errors"
+)
+
+// ErrInvalidSize is returned when a cache is created with a non-positive capacity.
+var ErrInvalidSize = errors.New("lru: capacity must be positive")
+
+// Cache maps keys to *V values and holds at most Cap entries. Once full, each Put of a new
+// key evicts the entry that was least recently read or written.
+//
+// A Cache is not safe for concurrent use; even Get reorders the recency list, so callers
+// sharing one across goroutines must guard every method with a sync.Mutex.
+type Cache[K comparable, V any] struct {
+	capacity int
+	ll       *list.List // front is most recently used; elements hold *entry[K, V]
+	items    map[K]*list.Element
+	config[K, V]
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value *V
+}
+
+// config holds the optional settings of a Cache.
+type config[K comparable, V any] struct {
+	onEvict func(key K, value *V)
+}
+
+// Option configures a Cache.
+type Option[K comparable, V any] func(*config[K, V])
+
+// WithOnEvict registers fn to be called with each entry evicted to make room for a new key.
+// It runs synchronously inside Put and is not called when Put replaces an existing key's value.
+func WithOnEvict[K comparable, V any](fn func(key K, value *V)) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.onEvict = fn
+	}
+}
+
+// New creates a Cache holding at most capacity entries.
+func New[K comparable, V any](capacity int, opts ...Option[K, V]) (*Cache[K, V], error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidSize
+	}
+
+	c := &Cache[K, V]{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[K]*list.Element, capacity),
+	}
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c, nil
+}
+
+// Get returns the value for key and marks it most recently used.
+func (c *Cache[K, V]) Get(key K) (*V, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*entry[K, V]).value, true
+}
+
+// Put stores value under key and marks it most recently used. If key is new and the cache
+// is full, the least recently used entry is evicted first.
+func (c *Cache[K, V]) Put(key K, value *V) {
+	if el, ok := c.items[key]; ok {
+		el.Value.(*entry[K, V]).value = value
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	if c.ll.Len() >= c.capacity {
+		c.evictOldest()
+	}
+	c.items[key] = c.ll.PushFront(&entry[K, V]{key: key, value: value})
+}
+
+// evictOldest removes the least recently used entry and reports it to the callback.
+func (c *Cache[K, V]) evictOldest() {
+	el := c.ll.Back()
+	e := c.ll.Remove(el).(*entry[K, V])
+	delete(c.items, e.key)
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	return c.ll.Len()
+}
+
+// Cap returns the maximum number of entries the cache holds.
+func (c *Cache[K, V]) Cap() int {
+	return c.capacity
+}
@@ -0,0 +1,138 @@
+package lru
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type item struct {
+	n int
+}
+
+func newCache(t *testing.T, capacity int, opts ...Option[string, item]) *Cache[string, item] {
+	t.Helper()
+
+	c, err := New(capacity, opts...)
+	if err != nil {
+		t.Fatalf("New(%d): %v", capacity, err)
+	}
+	return c
+}
+
+func TestNew_InvalidSize(t *testing.T) {
+	for _, capacity := range []int{0, -1} {
+		if _, err := New[string, item](capacity); !errors.Is(err, ErrInvalidSize) {
+			t.Errorf("New(%d) error = %v, want %v", capacity, err, ErrInvalidSize)
+		}
+	}
+}
+
+func TestCache_GetPut(t *testing.T) {
+	c := newCache(t, 2)
+	a := &item{n: 1}
+	c.Put("a", a)
+
+	if got, ok := c.Get("a"); !ok || got != a {
+		t.Errorf("Get(a) = %p, %v, want %p, true", got, ok, a)
+	}
+	if got, ok := c.Get("missing"); ok || got != nil {
+		t.Errorf("Get(missing) = %p, %v, want nil, false", got, ok)
+	}
+	if c.Len() != 1 || c.Cap() != 2 {
+		t.Errorf("Len = %d, Cap = %d, want 1, 2", c.Len(), c.Cap())
+	}
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	var evicted []string
+	c := newCache(t, 3, WithOnEvict(func(key string, _ *item) {
+		evicted = append(evicted, key)
+	}))
+
+	c.Put("a", &item{})
+	c.Put("b", &item{})
+	c.Put("c", &item{})
+	c.Get("a") // recency, oldest first: b, c, a
+
+	c.Put("d", &item{}) // evicts b
+	c.Put("e", &item{}) // evicts c
+
+	if want := []string{"b", "c"}; !slices.Equal(evicted, want) {
+		t.Errorf("evicted %v, want %v", evicted, want)
+	}
+	for _, key := range []string{"a", "d", "e"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("%s was evicted", key)
+		}
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len = %d, want 3", c.Len())
+	}
+}
+
+func TestCache_CapacityOne(t *testing.T) {
+	var evicted []string
+	c := newCache(t, 1, WithOnEvict(func(key string, _ *item) {
+		evicted = append(evicted, key)
+	}))
+
+	c.Put("a", &item{})
+	c.Put("b", &item{})
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("a survived a Put into a full capacity-1 cache")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Error("b missing")
+	}
+	if !slices.Equal(evicted, []string{"a"}) || c.Len() != 1 {
+		t.Errorf("evicted %v, Len = %d, want [a], 1", evicted, c.Len())
+	}
+}
+
+func TestCache_UpdateMovesToFront(t *testing.T) {
+	var evicted []string
+	c := newCache(t, 2, WithOnEvict(func(key string, _ *item) {
+		evicted = append(evicted, key)
+	}))
+
+	c.Put("a", &item{n: 1})
+	c.Put("b", &item{n: 2})
+	updated := &item{n: 3}
+	c.Put("a", updated) // recency, oldest first: b, a
+
+	if c.Len() != 2 || len(evicted) != 0 {
+		t.Fatalf("update changed Len to %d or evicted %v", c.Len(), evicted)
+	}
+
+	c.Put("c", &item{})
+	if !slices.Equal(evicted, []string{"b"}) {
+		t.Errorf("evicted %v, want [b]", evicted)
+	}
+	if got, ok := c.Get("a"); !ok || got != updated {
+		t.Errorf("Get(a) = %p, %v, want updated value %p", got, ok, updated)
+	}
+}
+
+func TestCache_OnEvictReceivesValue(t *testing.T) {
+	a := &item{n: 1}
+	var gotKey string
+	var gotValue *item
+	calls := 0
+	c := newCache(t, 1, WithOnEvict(func(key string, value *item) {
+		calls++
+		gotKey, gotValue = key, value
+	}))
+
+	c.Put("a", a)
+	c.Get("a")
+	if calls != 0 {
+		t.Fatalf("callback ran %d times before any eviction", calls)
+	}
+
+	c.Put("b", &item{})
+	if calls != 1 || gotKey != "a" || gotValue != a {
+		t.Errorf("callback ran %d times with (%q, %p), want once with (a, %p)", calls, gotKey, gotValue, a)
+	}
+}
@@ -0,0 +1,115 @@
+// Package optional provides Option and Result, generic alternatives to (T, bool) and
+// (T, error) returns. Both are plain structs holding a T by value, so returning one does
+// not allocate unless T itself does.
+package optional
+
+import "fmt"
+
+// Option holds a T or nothing. The zero Option is None.
+type Option[T any] struct {
+	v  T
+	ok bool
+}
+
+// Some returns an Option holding v.
+func Some[T any](v T) Option[T] {
+	return Option[T]{v: v, ok: true}
+}
+
+// None returns an empty Option.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// IsSome reports whether o holds a value.
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+// Get returns the value and whether there is one, converting back to the (T, bool) shape.
+func (o Option[T]) Get() (T, bool) {
+	return o.v, o.ok
+}
+
+// Unwrap returns the value, panicking if o is None.
+func (o Option[T]) Unwrap() T {
+	if !o.ok {
+		panic("optional: Unwrap of None")
+	}
+	return o.v
+}
+
+// UnwrapOr returns the value, or def if o is None.
+func (o Option[T]) UnwrapOr(def T) T {
+	if !o.ok {
+		return def
+	}
+	return o.v
+}
+
+// Map returns Some(fn(v)) if o holds v, and None without calling fn otherwise. Methods
+// cannot take type parameters, so fn must return the same type.
+func (o Option[T]) Map(fn func(T) T) Option[T] {
+	if !o.ok {
+		return o
+	}
+	return Some(fn(o.v))
+}
+
+// Result holds a T or the error that prevented producing one. The zero Result is Ok with
+// the zero T, matching a (T, error) return of (zero, nil).
+type Result[T any] struct {
+	v   T
+	err error
+}
+
+// Ok returns a successful Result holding v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{v: v}
+}
+
+// Err returns a failed Result. Err(nil) is the same as Ok of the zero T, as with a
+// function returning (zero, nil).
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// IsOk reports whether r holds a value rather than an error.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Get returns the value and error, converting back to the (T, error) shape. The value is
+// the zero T when there is an error.
+func (r Result[T]) Get() (T, error) {
+	return r.v, r.err
+}
+
+// Err returns the error, or nil if r is Ok.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Unwrap returns the value, panicking with an error wrapping r's if r failed.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Errorf("optional: Unwrap of Err: %w", r.err))
+	}
+	return r.v
+}
+
+// UnwrapOr returns the value, or def if r failed.
+func (r Result[T]) UnwrapOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.v
+}
+
+// Map returns Ok(fn(v)) if r holds v, and r's error without calling fn otherwise.
+func (r Result[T]) Map(fn func(T) T) Result[T] {
+	if r.err != nil {
+		return r
+	}
+	return Ok(fn(r.v))
+}
@@ -0,0 +1,126 @@
+package optional
+
+import (
+	"errors"
+	"testing"
+)
+
+var errMissing = errors.New("missing")
+
+// mustPanic calls fn and returns what it panicked with, failing if it did not panic.
+func mustPanic(t *testing.T, fn func()) (v any) {
+	t.Helper()
+	defer func() { v = recover() }()
+	fn()
+	t.Fatal("did not panic")
+	return nil
+}
+
+func TestOption_Some(t *testing.T) {
+	o := Some(42)
+	if !o.IsSome() {
+		t.Error("Some(42).IsSome() = false")
+	}
+	if v, ok := o.Get(); v != 42 || !ok {
+		t.Errorf("Get = %d, %v; want 42, true", v, ok)
+	}
+	if got := o.Unwrap(); got != 42 {
+		t.Errorf("Unwrap = %d, want 42", got)
+	}
+	if got := o.UnwrapOr(7); got != 42 {
+		t.Errorf("UnwrapOr(7) = %d, want 42", got)
+	}
+	if got := o.Map(func(v int) int { return v + 1 }); got != Some(43) {
+		t.Errorf("Map(+1) = %v, want Some(43)", got)
+	}
+}
+
+func TestOption_SomeZeroValueIsStillSome(t *testing.T) {
+	if o := Some(0); !o.IsSome() || o.Unwrap() != 0 {
+		t.Error("Some(0) is not a present zero value")
+	}
+}
+
+func TestOption_ZeroValueIsNone(t *testing.T) {
+	for name, o := range map[string]Option[int]{"zero": {}, "None": None[int]()} {
+		if o.IsSome() {
+			t.Errorf("%s: IsSome() = true", name)
+		}
+		if v, ok := o.Get(); v != 0 || ok {
+			t.Errorf("%s: Get = %d, %v; want 0, false", name, v, ok)
+		}
+		if got := o.UnwrapOr(7); got != 7 {
+			t.Errorf("%s: UnwrapOr(7) = %d, want 7", name, got)
+		}
+		called := false
+		if got := o.Map(func(v int) int { called = true; return v }); got.IsSome() || called {
+			t.Errorf("%s: Map = %v, called = %v; want None without calling fn", name, got, called)
+		}
+		mustPanic(t, func() { o.Unwrap() })
+	}
+}
+
+func TestResult_Ok(t *testing.T) {
+	r := Ok("order")
+	if !r.IsOk() || r.Err() != nil {
+		t.Errorf("Ok: IsOk = %v, Err = %v", r.IsOk(), r.Err())
+	}
+	if v, err := r.Get(); v != "order" || err != nil {
+		t.Errorf("Get = %q, %v; want \"order\", nil", v, err)
+	}
+	if got := r.Unwrap(); got != "order" {
+		t.Errorf("Unwrap = %q", got)
+	}
+	if got := r.UnwrapOr("default"); got != "order" {
+		t.Errorf("UnwrapOr = %q, want \"order\"", got)
+	}
+	if got := r.Map(func(s string) string { return s + "s" }); got != Ok("orders") {
+		t.Errorf("Map = %v, want Ok(\"orders\")", got)
+	}
+}
+
+func TestResult_ZeroValueIsOk(t *testing.T) {
+	for name, r := range map[string]Result[int]{"zero": {}, "Err(nil)": Err[int](nil)} {
+		if !r.IsOk() || r.Err() != nil {
+			t.Errorf("%s: IsOk = %v, Err = %v; want Ok", name, r.IsOk(), r.Err())
+		}
+		if v, err := r.Get(); v != 0 || err != nil {
+			t.Errorf("%s: Get = %d, %v; want 0, nil", name, v, err)
+		}
+		if got := r.Unwrap(); got != 0 {
+			t.Errorf("%s: Unwrap = %d, want 0", name, got)
+		}
+		if got := r.UnwrapOr(7); got != 0 {
+			t.Errorf("%s: UnwrapOr(7) = %d, want 0", name, got)
+		}
+		if got := r.Map(func(v int) int { return v + 1 }); got != Ok(1) {
+			t.Errorf("%s: Map(+1) = %v, want Ok(1)", name, got)
+		}
+	}
+}
+
+func TestResult_Err(t *testing.T) {
+	r := Err[int](errMissing)
+	if r.IsOk() {
+		t.Error("Err: IsOk() = true")
+	}
+	if !errors.Is(r.Err(), errMissing) {
+		t.Errorf("Err() = %v, want errMissing", r.Err())
+	}
+	if v, err := r.Get(); v != 0 || !errors.Is(err, errMissing) {
+		t.Errorf("Get = %d, %v; want 0, errMissing", v, err)
+	}
+	if got := r.UnwrapOr(7); got != 7 {
+		t.Errorf("UnwrapOr(7) = %d, want 7", got)
+	}
+
+	called := false
+	if got := r.Map(func(v int) int { called = true; return v }); !errors.Is(got.Err(), errMissing) || called {
+		t.Errorf("Map = %v, called = %v; want the error without calling fn", got, called)
+	}
+
+	p := mustPanic(t, func() { r.Unwrap() })
+	if err, ok := p.(error); !ok || !errors.Is(err, errMissing) {
+		t.Errorf("Unwrap panicked with %v, want an error wrapping errMissing", p)
+	}
+}
@@ -0,0 +1,144 @@
+// Package pipeline is a fan-out/fan-in example: a generator feeds items to parallel
+// workers over channels, and a collector merges what they produce. Every stage stops when
+// its context is done, so cancelling mid-stream shuts the whole pipeline down.
+//
+// Run wires the stages together; Generate, FanOut, FanIn, and Collect are the stages
+// themselves, for pipelines shaped differently.
+package pipeline
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// Item is a value in flight, tagged with its position in the generator's output so the
+// collector can restore the original order.
+type Item[T any] struct {
+	Seq   uint64
+	Value T
+}
+
+// Mode selects how Collect hands results to its sink.
+type Mode int
+
+const (
+	// Unordered passes results on as they arrive.
+	Unordered Mode = iota
+	// Ordered passes results on in generator order, holding back any that arrive early.
+	Ordered
+)
+
+// Generate sends every value of src, numbered from 0, on the returned channel. The channel
+// is closed when src is exhausted or ctx is done.
+func Generate[T any](ctx context.Context, src iter.Seq[T]) <-chan Item[T] {
+	out := make(chan Item[T])
+	go func() {
+		defer close(out)
+		var seq uint64
+		for v := range src {
+			select {
+			case out <- Item[T]{Seq: seq, Value: v}:
+				seq++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// FanOut starts workers goroutines, each applying fn to the items it takes from in and
+// sending the results on a channel of its own. Each channel is closed once in is closed or
+// ctx is done. Values below 1 start a single worker.
+func FanOut[In, Out any](ctx context.Context, in <-chan Item[In], workers int, fn func(In) Out) []<-chan Item[Out] {
+	outs := make([]<-chan Item[Out], max(workers, 1))
+	for i := range outs {
+		out := make(chan Item[Out])
+		outs[i] = out
+		go func() {
+			defer close(out)
+			for it := range in {
+				select {
+				case out <- Item[Out]{Seq: it.Seq, Value: fn(it.Value)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	return outs
+}
+
+// FanIn merges chans into one channel, which is closed once all of them are closed or ctx
+// is done.
+func FanIn[T any](ctx context.Context, chans ...<-chan Item[T]) <-chan Item[T] {
+	out := make(chan Item[T])
+	var wg sync.WaitGroup
+	for _, ch := range chans {
+		wg.Go(func() {
+			for it := range ch {
+				select {
+				case out <- it:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Collect calls sink with the value of every item received from in, in the given mode,
+// until in is closed. It returns ctx.Err() if ctx was done by then, since the upstream
+// stages may have stopped early, and nil otherwise.
+//
+// Collect keeps receiving after ctx is done, discarding items, so that when it returns
+// every stage feeding in has finished.
+func Collect[T any](ctx context.Context, in <-chan Item[T], mode Mode, sink func(T)) error {
+	if mode == Unordered {
+		for it := range in {
+			if ctx.Err() == nil {
+				sink(it.Value)
+			}
+		}
+		return ctx.Err()
+	}
+
+	pending := make(map[uint64]T)
+	var next uint64
+	for it := range in {
+		if ctx.Err() != nil {
+			continue
+		}
+		pending[it.Seq] = it.Value
+		// sink may cancel ctx, after which nothing more is passed on.
+		for ctx.Err() == nil {
+			v, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			sink(v)
+			next++
+		}
+	}
+	return ctx.Err()
+}
+
+// Run streams src through workers parallel copies of fn and passes every result to sink,
+// which is called from a single goroutine. It returns once all stages have stopped: with
+// nil when every item was processed, or with ctx.Err() if ctx was done first.
+func Run[In, Out any](ctx context.Context, src iter.Seq[In], workers int, fn func(In) Out, mode Mode, sink func(Out)) error {
+	// If sink panics, cancelling releases the stages blocked sending to Collect.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items := Generate(ctx, src)
+	results := FanIn(ctx, FanOut(ctx, items, workers, fn)...)
+	return Collect(ctx, results, mode, sink)
+}
@@ -0,0 +1,169 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// count yields 0 to n-1.
+func count(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := range n {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// forever yields 0, 1, 2, ... until the consumer stops.
+func forever(yield func(int) bool) {
+	for i := 0; ; i++ {
+		if !yield(i) {
+			return
+		}
+	}
+}
+
+// jitter squares v after a delay that varies with v, so workers finish out of order.
+func jitter(v int) int {
+	if v%50 == 0 {
+		time.Sleep(50 * time.Microsecond)
+	}
+	return v * v
+}
+
+// waitForGoroutines waits up to a second for the goroutine count to drop to want.
+func waitForGoroutines(t *testing.T, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > want {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines running, want %d", runtime.NumGoroutine(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRun_EveryItemExactlyOnce(t *testing.T) {
+	const n = 2000
+	roots := make(map[int]int, n)
+	for i := range n {
+		roots[i*i] = i
+	}
+	for _, workers := range []int{0, 1, 4, 16} {
+		seen := make([]int, n)
+		err := Run(context.Background(), count(n), workers, jitter, Unordered, func(sq int) {
+			i, ok := roots[sq]
+			if !ok {
+				t.Errorf("workers=%d: unexpected result %d", workers, sq)
+				return
+			}
+			seen[i]++
+		})
+		if err != nil {
+			t.Fatalf("workers=%d: Run = %v", workers, err)
+		}
+		for i, c := range seen {
+			if c != 1 {
+				t.Fatalf("workers=%d: item %d collected %d times", workers, i, c)
+			}
+		}
+	}
+}
+
+func TestRun_OrderedKeepsGeneratorOrder(t *testing.T) {
+	const n = 1000
+	var got []int
+	if err := Run(context.Background(), count(n), 8, jitter, Ordered, func(sq int) { got = append(got, sq) }); err != nil {
+		t.Fatalf("Run = %v", err)
+	}
+
+	want := make([]int, n)
+	for i := range want {
+		want[i] = i * i
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ordered results out of order: first 10 = %v", got[:min(10, len(got))])
+	}
+}
+
+func TestRun_UnorderedCanReorder(t *testing.T) {
+	// Item 0 is the slowest, so with more than one worker it rarely arrives first.
+	slowFirst := func(v int) int {
+		if v == 0 {
+			time.Sleep(20 * time.Millisecond)
+		}
+		return v
+	}
+	var got []int
+	if err := Run(context.Background(), count(100), 4, slowFirst, Unordered, func(v int) { got = append(got, v) }); err != nil {
+		t.Fatalf("Run = %v", err)
+	}
+	if len(got) != 100 {
+		t.Fatalf("collected %d items, want 100", len(got))
+	}
+	if got[0] == 0 {
+		t.Errorf("unordered mode waited for the slow first item")
+	}
+}
+
+func TestRun_CancelMidStreamStopsEveryStage(t *testing.T) {
+	for _, mode := range []Mode{Unordered, Ordered} {
+		before := runtime.NumGoroutine()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		collected := 0
+		err := Run(ctx, forever, 8, jitter, mode, func(int) {
+			collected++
+			if collected == 100 {
+				cancel()
+			}
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("mode %d: Run = %v, want Canceled", mode, err)
+		}
+		if collected != 100 {
+			t.Errorf("mode %d: sink called %d times, want 100 and none after cancel", mode, collected)
+		}
+		waitForGoroutines(t, before)
+	}
+}
+
+func TestRun_AlreadyCanceled(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := Run(ctx, forever, 4, jitter, Unordered, func(int) {}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v, want Canceled", err)
+	}
+	waitForGoroutines(t, before)
+}
+
+func TestFanIn_MergesAll(t *testing.T) {
+	ctx := context.Background()
+	in := Generate(ctx, count(300))
+	outs := FanOut(ctx, in, 3, func(v int) int { return v })
+	if len(outs) != 3 {
+		t.Fatalf("FanOut started %d workers, want 3", len(outs))
+	}
+
+	var got []int
+	for it := range FanIn(ctx, outs...) {
+		if it.Seq != uint64(it.Value) {
+			t.Errorf("item %d carries Seq %d", it.Value, it.Seq)
+		}
+		got = append(got, it.Value)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, slices.Collect(count(300))) {
+		t.Errorf("FanIn lost or duplicated items: got %d", len(got))
+	}
+}
@@ -0,0 +1,72 @@
+package pool
+
+import "errors"
+
+// ErrInvalidSize is returned when a bounded pool is created with a non-positive size.
+var ErrInvalidSize = errors.New("pool: size must be positive")
+
+// Bounded is a fixed-capacity pool backed by a buffered channel.
+// Unlike sync.Pool it is never drained by the GC and retains at most Cap objects.
+type Bounded[T any] struct {
+	items chan *T
+	config[T]
+}
+
+// NewBounded creates a Bounded pool that retains at most size objects.
+func NewBounded[T any](size int, opts ...Option[T]) (*Bounded[T], error) {
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+
+	return &Bounded[T]{
+		items:  make(chan *T, size),
+		config: newConfig(opts),
+	}, nil
+}
+
+// Get returns a retained object, or allocates a new one when the pool is empty.
+// It never blocks.
+func (p *Bounded[T]) Get() *T {
+	select {
+	case v := <-p.items:
+		p.onGet(v)
+		return v
+	default:
+		return p.alloc()
+	}
+}
+
+// Put retains v for reuse, or drops it for the GC when the pool is full.
+// It never blocks. Putting nil is a no-op.
+func (p *Bounded[T]) Put(v *T) {
+	if v == nil {
+		return
+	}
+	p.onPut(v)
+
+	select {
+	case p.items <- v:
+	default:
+	}
+}
+
+// Len returns the number of objects currently retained.
+func (p *Bounded[T]) Len() int {
+	return len(p.items)
+}
+
+// Cap returns the maximum number of objects the pool retains.
+func (p *Bounded[T]) Cap() int {
+	return cap(p.items)
+}
+
+// Warm pre-populates the pool with up to n freshly allocated objects, stopping once it is full.
+func (p *Bounded[T]) Warm(n int) {
+	for range min(n, cap(p.items)-len(p.items)) {
+		select {
+		case p.items <- p.alloc():
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package pool
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewBounded_InvalidSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		if _, err := NewBounded[session](size); !errors.Is(err, ErrInvalidSize) {
+			t.Errorf("NewBounded(%d) error = %v, want %v", size, err, ErrInvalidSize)
+		}
+	}
+}
+
+func TestBounded_GetFromEmptyAllocates(t *testing.T) {
+	p, err := NewBounded[session](2)
+	if err != nil {
+		t.Fatalf("NewBounded: %v", err)
+	}
+
+	a, b := p.Get(), p.Get()
+	if a == nil || b == nil || a == b {
+		t.Fatalf("Get on empty pool returned %p and %p, want two distinct objects", a, b)
+	}
+	if p.Len() != 0 {
+		t.Errorf("Len = %d, want 0", p.Len())
+	}
+}
+
+func TestBounded_ReusesPutObjects(t *testing.T) {
+	p, err := NewBounded[session](1)
+	if err != nil {
+		t.Fatalf("NewBounded: %v", err)
+	}
+
+	s := p.Get()
+	p.Put(s)
+	if p.Len() != 1 {
+		t.Fatalf("Len after Put = %d, want 1", p.Len())
+	}
+	if got := p.Get(); got != s {
+		t.Errorf("Get returned %p, want reused %p", got, s)
+	}
+}
+
+func TestBounded_PutDropsWhenFull(t *testing.T) {
+	p, err := NewBounded[session](2)
+	if err != nil {
+		t.Fatalf("NewBounded: %v", err)
+	}
+
+	for range 5 {
+		p.Put(&session{})
+	}
+	if p.Len() != p.Cap() {
+		t.Errorf("Len = %d, want Cap %d", p.Len(), p.Cap())
+	}
+}
+
+func TestBounded_PutNeverBlocks(t *testing.T) {
+	p, err := NewBounded[session](1)
+	if err != nil {
+		t.Fatalf("NewBounded: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 1000 {
+			p.Put(&session{})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Put blocked on a full pool")
+	}
+}
+
+func TestBounded_PutNil(t *testing.T) {
+	p, err := NewBounded[session](1)
+	if err != nil {
+		t.Fatalf("NewBounded: %v", err)
+	}
+
+	p.Put(nil)
+	if p.Len() != 0 {
+		t.Errorf("Len after Put(nil) = %d, want 0", p.Len())
+	}
+}
+
+func TestBounded_Reset(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option[session]
+	}{
+		{name: "reset on put", opt: WithReset(resetSession)},
+		{name: "reset on get", opt: WithResetOnGet(resetSession)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewBounded(1, tt.opt)
+			if err != nil {
+				t.Fatalf("NewBounded: %v", err)
+			}
+
+			s := p.Get()
+			s.ID = 7
+			s.IsActive = true
+			p.Put(s)
+
+			if got := p.Get(); got.ID != 0 || got.IsActive {
+				t.Errorf("Get returned stale state %+v", *got)
+			}
+		})
+	}
+}
+
+func TestBounded_Warm(t *testing.T) {
+	var calls int
+	p, err := NewBounded(4, WithNew(countingNew(&calls)))
+	if err != nil {
+		t.Fatalf("NewBounded: %v", err)
+	}
+
+	p.Warm(10) // capped at capacity
+	if p.Len() != 4 || calls != 4 {
+		t.Fatalf("after Warm(10): Len = %d, New calls = %d, want 4 and 4", p.Len(), calls)
+	}
+
+	for range 4 {
+		p.Get()
+	}
+	if calls != 4 {
+		t.Errorf("Gets after Warm called New %d extra times", calls-4)
+	}
+
+	p.Get()
+	if calls != 5 {
+		t.Errorf("Get on drained pool: New calls = %d, want 5", calls)
+	}
+}
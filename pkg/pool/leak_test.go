@@ -0,0 +1,85 @@
+package pool
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// dropOne checks out an object and drops it. It is a separate function so no reference
+// to the object survives in the caller's frame.
+//
+//go:noinline
+func dropOne(p *LeakDetecting[session]) {
+	s := p.Get()
+	s.ID = 1
+}
+
+// collectUntil runs the GC and waits for finalizers until p has counted want leaks or a
+// few seconds pass, then returns the count.
+func collectUntil(p *LeakDetecting[session], want uint64) uint64 {
+	deadline := time.Now().Add(3 * time.Second)
+	for p.Leaks() < want && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	return p.Leaks()
+}
+
+func TestLeakDetecting_CountsDroppedObject(t *testing.T) {
+	p := NewLeakDetecting[session]()
+
+	dropOne(p)
+	if got := collectUntil(p, 1); got != 1 {
+		t.Fatalf("Leaks = %d after dropping one object, want 1", got)
+	}
+
+	dropOne(p)
+	dropOne(p)
+	if got := collectUntil(p, 3); got != 3 {
+		t.Errorf("Leaks = %d after dropping three objects, want 3", got)
+	}
+}
+
+func TestLeakDetecting_NoLeaksWhenEverythingIsPut(t *testing.T) {
+	p := NewLeakDetecting(WithReset(resetSession))
+
+	for i := range 1000 {
+		s := p.Get()
+		s.ID = int64(i)
+		p.Put(s)
+	}
+	// Several cycles free the pool's own contents, none of which may count as leaks.
+	for range 5 {
+		runtime.GC()
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if got := p.Leaks(); got != 0 {
+		t.Errorf("Leaks = %d with every object put back, want 0", got)
+	}
+}
+
+func TestLeakDetecting_ResetAndNew(t *testing.T) {
+	var calls int
+	p := NewLeakDetecting(WithNew(countingNew(&calls)), WithReset(resetSession))
+
+	s := p.Get()
+	if calls != 1 {
+		t.Fatalf("New called %d times, want 1", calls)
+	}
+
+	s.ID = 7
+	p.Put(s)
+	if s.ID != 0 {
+		t.Errorf("Put did not reset object: %+v", *s)
+	}
+}
+
+func TestLeakDetecting_PutNil(t *testing.T) {
+	p := NewLeakDetecting[session]()
+	p.Put(nil)
+	if got := p.Leaks(); got != 0 {
+		t.Errorf("Leaks = %d after Put(nil), want 0", got)
+	}
+}
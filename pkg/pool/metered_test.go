@@ -0,0 +1,80 @@
+package pool
+
+import (
+	"runtime/debug"
+	"sync"
+	"testing"
+)
+
+func TestMetered_CountsMissThenHit(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops objects at random under the race detector")
+	}
+	defer debug.SetGCPercent(debug.SetGCPercent(-1))
+
+	p := NewMetered[session]()
+
+	s := p.Get()
+	p.Put(s)
+	p.Get()
+
+	want := Stats{Gets: 2, Puts: 1, Hits: 1, News: 1}
+	if got := p.Stats(); got != want {
+		t.Errorf("Stats = %+v, want %+v", got, want)
+	}
+}
+
+func TestMetered_PutNilNotCounted(t *testing.T) {
+	p := NewMetered[session]()
+
+	p.Put(nil)
+
+	if got := p.Stats().Puts; got != 0 {
+		t.Errorf("Puts = %d, want 0", got)
+	}
+}
+
+func TestMetered_Options(t *testing.T) {
+	var calls int
+	p := NewMetered(WithNew(countingNew(&calls)), WithReset(resetSession))
+
+	s := p.Get()
+	if calls != 1 {
+		t.Fatalf("New called %d times, want 1", calls)
+	}
+
+	s.ID = 7
+	p.Put(s)
+	if s.ID != 0 {
+		t.Errorf("Put did not reset object: %+v", *s)
+	}
+}
+
+func TestMetered_ReconcilesUnderConcurrency(t *testing.T) {
+	const (
+		goroutines = 100
+		cycles     = 1000
+	)
+
+	p := NewMetered(WithReset(resetSession))
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Go(func() {
+			for range cycles {
+				s := p.Get()
+				s.IsActive = true
+				p.Put(s)
+			}
+		})
+	}
+	wg.Wait()
+
+	st := p.Stats()
+	if st.Gets != goroutines*cycles || st.Puts != goroutines*cycles {
+		t.Errorf("Gets = %d, Puts = %d, want %d each", st.Gets, st.Puts, goroutines*cycles)
+	}
+	if st.Gets != st.Hits+st.News {
+		t.Errorf("Gets (%d) != Hits (%d) + News (%d)", st.Gets, st.Hits, st.News)
+	}
+}
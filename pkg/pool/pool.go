@@ -0,0 +1,116 @@
+// Package pool provides a type-safe wrapper around sync.Pool.
+package pool
+
+import "sync"
+
+// Pool is a type-safe sync.Pool for *T values.
+// When a reset function is configured, it runs automatically so callers
+// can never observe state left behind by a previous user.
+type Pool[T any] struct {
+	p sync.Pool
+	config[T]
+}
+
+// config holds the settings shared by every pool implementation in this package.
+type config[T any] struct {
+	newFn      func() *T
+	reset      func(*T)
+	resetOnGet bool
+}
+
+// newConfig applies opts on top of the defaults.
+func newConfig[T any](opts []Option[T]) config[T] {
+	var c config[T]
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// alloc creates a fresh object with the configured constructor, or new(T).
+func (c *config[T]) alloc() *T {
+	if c.newFn != nil {
+		return c.newFn()
+	}
+	return new(T)
+}
+
+// onGet applies the reset function if it is configured to run on Get.
+func (c *config[T]) onGet(v *T) {
+	if c.resetOnGet && c.reset != nil {
+		c.reset(v)
+	}
+}
+
+// onPut applies the reset function if it is configured to run on Put.
+func (c *config[T]) onPut(v *T) {
+	if !c.resetOnGet && c.reset != nil {
+		c.reset(v)
+	}
+}
+
+// Option configures a pool.
+type Option[T any] func(*config[T])
+
+// WithNew sets the constructor used when the pool has no object to hand out.
+// It defaults to new(T).
+func WithNew[T any](fn func() *T) Option[T] {
+	return func(c *config[T]) {
+		c.newFn = fn
+	}
+}
+
+// WithReset registers fn to clear an object when it is returned via Put.
+func WithReset[T any](fn func(*T)) Option[T] {
+	return func(c *config[T]) {
+		c.reset = fn
+		c.resetOnGet = false
+	}
+}
+
+// WithResetOnGet registers fn to clear an object right before it is handed out by Get.
+// Use it when objects are cheap to put back but Put is on a latency-sensitive path.
+func WithResetOnGet[T any](fn func(*T)) Option[T] {
+	return func(c *config[T]) {
+		c.reset = fn
+		c.resetOnGet = true
+	}
+}
+
+// New creates a Pool that allocates new objects with new(T) unless WithNew is given.
+func New[T any](opts ...Option[T]) *Pool[T] {
+	p := &Pool[T]{
+		config: newConfig(opts),
+	}
+	p.p.New = func() any {
+		return p.alloc()
+	}
+
+	return p
+}
+
+// Get returns an object from the pool, allocating one if the pool is empty.
+func (p *Pool[T]) Get() *T {
+	v := p.p.Get().(*T)
+	p.onGet(v)
+	return v
+}
+
+// Put returns v to the pool. The caller must not use v afterwards.
+// Putting nil is a no-op.
+func (p *Pool[T]) Put(v *T) {
+	if v == nil {
+		return
+	}
+	p.onPut(v)
+	p.p.Put(v)
+}
+
+// Warm pre-populates the pool with n freshly allocated objects, so a burst of
+// Gets right after startup does not pay the allocation cost.
+// Like everything in a sync.Pool, warmed objects may be dropped by the GC.
+func (p *Pool[T]) Warm(n int) {
+	for range n {
+		p.p.Put(p.alloc())
+	}
+}
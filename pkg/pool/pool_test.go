@@ -0,0 +1,129 @@
+package pool
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+type session struct {
+	ID       int64
+	IsActive bool
+}
+
+func resetSession(s *session) {
+	*s = session{}
+}
+
+func TestPool_GetAllocatesWhenEmpty(t *testing.T) {
+	p := New[session]()
+
+	if s := p.Get(); s == nil {
+		t.Fatal("Get returned nil, want a fresh object")
+	}
+}
+
+func TestPool_PutResetsObject(t *testing.T) {
+	p := New(WithReset(resetSession))
+
+	s := p.Get()
+	s.ID = 7
+	s.IsActive = true
+	p.Put(s)
+
+	// Inspecting s after Put is only done here to prove the reset ran.
+	if s.ID != 0 || s.IsActive {
+		t.Errorf("after Put got %+v, want zero value", *s)
+	}
+}
+
+func TestPool_GetResetsObject(t *testing.T) {
+	p := New(WithResetOnGet(resetSession))
+
+	s := p.Get()
+	s.ID = 7
+	s.IsActive = true
+	p.Put(s)
+
+	// With reset-on-get the object keeps its state until it is handed out again.
+	if !s.IsActive {
+		t.Fatal("reset ran on Put, want it deferred to Get")
+	}
+}
+
+func TestPool_NoStaleStateLeaksThroughGet(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option[session]
+	}{
+		{name: "reset on put", opt: WithReset(resetSession)},
+		{name: "reset on get", opt: WithResetOnGet(resetSession)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(tt.opt)
+
+			for i := range 1000 {
+				s := p.Get()
+				if s.ID != 0 || s.IsActive {
+					t.Fatalf("iteration %d: Get returned stale state %+v", i, *s)
+				}
+				s.ID = int64(i + 1)
+				s.IsActive = true
+				p.Put(s)
+			}
+		})
+	}
+}
+
+func TestPool_PutNil(t *testing.T) {
+	p := New(WithReset(resetSession))
+
+	p.Put(nil)
+
+	if s := p.Get(); s == nil {
+		t.Fatal("Get returned nil after Put(nil)")
+	}
+}
+
+// countingNew returns a constructor that counts its invocations in calls.
+func countingNew(calls *int) func() *session {
+	return func() *session {
+		*calls++
+		return &session{}
+	}
+}
+
+func TestPool_WithNew(t *testing.T) {
+	var calls int
+	p := New(WithNew(countingNew(&calls)))
+
+	p.Get()
+	if calls != 1 {
+		t.Errorf("New called %d times, want 1", calls)
+	}
+}
+
+func TestPool_Warm(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops objects at random under the race detector")
+	}
+	// A GC between Warm and Get would legitimately empty the pool.
+	defer debug.SetGCPercent(debug.SetGCPercent(-1))
+
+	const n = 16
+
+	var calls int
+	p := New(WithNew(countingNew(&calls)))
+	p.Warm(n)
+	if calls != n {
+		t.Fatalf("Warm(%d) called New %d times, want %d", n, calls, n)
+	}
+
+	for range n {
+		p.Get()
+	}
+	if calls != n {
+		t.Errorf("%d Gets after Warm(%d) called New %d extra times", n, n, calls-n)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package pool
+
+// raceEnabled reports whether the race detector is on. The race runtime makes
+// sync.Pool drop objects at random, so reuse cannot be asserted.
+const raceEnabled = true
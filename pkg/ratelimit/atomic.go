@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// AtomicBucket is a lock-free token bucket. Instead of a token count it stores the
+// theoretical arrival time (TAT) of the next token as nanoseconds since the bucket was
+// created, and admits a request by advancing it with compare-and-swap. n tokens are
+// available while TAT + n*interval stays within burst*interval of now.
+// A new bucket starts full.
+type AtomicBucket struct {
+	interval int64 // nanoseconds per token
+	window   int64 // burst * interval
+	start    time.Time
+	config
+
+	tat atomic.Int64
+}
+
+// NewAtomic creates an AtomicBucket refilling rate tokens per second up to burst tokens.
+// The per-token interval is rounded down to a whole nanosecond, so rates above 1e9/s are rejected.
+func NewAtomic(rate float64, burst int, opts ...Option) (*AtomicBucket, error) {
+	interval := int64(float64(time.Second) / rate)
+	if rate <= 0 || burst <= 0 || interval <= 0 {
+		return nil, ErrInvalidConfig
+	}
+
+	b := &AtomicBucket{
+		interval: interval,
+		window:   int64(burst) * interval,
+		config:   newConfig(opts),
+	}
+	b.start = b.now()
+	return b, nil
+}
+
+// Allow reports whether one token is available, consuming it if so.
+func (b *AtomicBucket) Allow() bool {
+	return b.AllowN(1)
+}
+
+// AllowN reports whether n tokens are available, consuming them all if so.
+func (b *AtomicBucket) AllowN(n int) bool {
+	if n <= 0 {
+		return true
+	}
+
+	cost := int64(n) * b.interval
+	now := int64(b.now().Sub(b.start))
+	for {
+		tat := b.tat.Load()
+		next := max(tat, now) + cost
+		if next-now > b.window {
+			return false
+		}
+		if b.tat.CompareAndSwap(tat, next) {
+			return true
+		}
+	}
+}
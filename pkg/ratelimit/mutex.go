@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// MutexBucket is a token bucket whose state is guarded by a mutex.
+// A new bucket starts full.
+type MutexBucket struct {
+	rate  float64 // tokens per second
+	burst float64
+	config
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewMutex creates a MutexBucket refilling rate tokens per second up to burst tokens.
+func NewMutex(rate float64, burst int, opts ...Option) (*MutexBucket, error) {
+	if rate <= 0 || burst <= 0 {
+		return nil, ErrInvalidConfig
+	}
+
+	b := &MutexBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		config: newConfig(opts),
+		tokens: float64(burst),
+	}
+	b.last = b.now()
+	return b, nil
+}
+
+// Allow reports whether one token is available, consuming it if so.
+func (b *MutexBucket) Allow() bool {
+	return b.AllowN(1)
+}
+
+// AllowN reports whether n tokens are available, consuming them all if so.
+func (b *MutexBucket) AllowN(n int) bool {
+	if n <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
@@ -0,0 +1,42 @@
+// Package ratelimit provides token-bucket rate limiters with time-based refill.
+package ratelimit
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidConfig is returned when the rate or burst is not positive.
+var ErrInvalidConfig = errors.New("ratelimit: rate and burst must be positive")
+
+// Limiter is the surface shared by the bucket implementations.
+type Limiter interface {
+	// Allow reports whether one token is available, consuming it if so.
+	Allow() bool
+	// AllowN reports whether n tokens are available, consuming them all if so.
+	// It never partially consumes; n <= 0 is always allowed.
+	AllowN(n int) bool
+}
+
+// config holds the settings shared by every bucket implementation.
+type config struct {
+	now func() time.Time
+}
+
+func newConfig(opts []Option) config {
+	c := config{now: time.Now}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Option configures a bucket.
+type Option func(*config)
+
+// WithClock replaces time.Now as the bucket's time source, typically with a fake clock in tests.
+func WithClock(now func() time.Time) Option {
+	return func(c *config) {
+		c.now = now
+	}
+}
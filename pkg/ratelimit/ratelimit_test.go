@@ -0,0 +1,198 @@
+package ratelimit
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced time source, safe for concurrent reads.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1_700_000_000, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+var buckets = []struct {
+	name string
+	new  func(rate float64, burst int, opts ...Option) (Limiter, error)
+}{
+	{name: "mutex", new: func(rate float64, burst int, opts ...Option) (Limiter, error) {
+		return NewMutex(rate, burst, opts...)
+	}},
+	{name: "atomic", new: func(rate float64, burst int, opts ...Option) (Limiter, error) {
+		return NewAtomic(rate, burst, opts...)
+	}},
+}
+
+func newBucket(t *testing.T, newFn func(float64, int, ...Option) (Limiter, error), rate float64, burst int, clock *fakeClock) Limiter {
+	t.Helper()
+
+	l, err := newFn(rate, burst, WithClock(clock.Now))
+	if err != nil {
+		t.Fatalf("new(%v, %d): %v", rate, burst, err)
+	}
+	return l
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	for _, b := range buckets {
+		for _, cfg := range []struct {
+			rate  float64
+			burst int
+		}{{0, 1}, {-1, 1}, {1, 0}, {1, -1}} {
+			if _, err := b.new(cfg.rate, cfg.burst); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("%s(%v, %d) error = %v, want %v", b.name, cfg.rate, cfg.burst, err, ErrInvalidConfig)
+			}
+		}
+	}
+}
+
+func TestNewAtomic_RejectsSubNanosecondInterval(t *testing.T) {
+	if _, err := NewAtomic(2e9, 1); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("NewAtomic(2e9, 1) error = %v, want %v", err, ErrInvalidConfig)
+	}
+}
+
+func TestBucket_BurstCapacity(t *testing.T) {
+	for _, b := range buckets {
+		t.Run(b.name, func(t *testing.T) {
+			l := newBucket(t, b.new, 10, 5, newFakeClock())
+
+			for i := range 5 {
+				if !l.Allow() {
+					t.Fatalf("Allow %d of burst 5 = false", i+1)
+				}
+			}
+			if l.Allow() {
+				t.Error("Allow after exhausting burst = true")
+			}
+		})
+	}
+}
+
+func TestBucket_Refill(t *testing.T) {
+	for _, b := range buckets {
+		t.Run(b.name, func(t *testing.T) {
+			clock := newFakeClock()
+			l := newBucket(t, b.new, 10, 5, clock) // one token per 100ms
+
+			if !l.AllowN(5) {
+				t.Fatal("AllowN(5) on a full bucket = false")
+			}
+
+			clock.Advance(99 * time.Millisecond)
+			if l.Allow() {
+				t.Error("Allow after 99ms = true, want the first token at 100ms")
+			}
+
+			clock.Advance(time.Millisecond)
+			if !l.Allow() {
+				t.Error("Allow after 100ms = false")
+			}
+			if l.Allow() {
+				t.Error("second Allow after 100ms = true, only one token refilled")
+			}
+
+			clock.Advance(250 * time.Millisecond)
+			if !l.AllowN(2) {
+				t.Error("AllowN(2) after 250ms = false")
+			}
+			if l.Allow() {
+				t.Error("Allow with half a token left = true")
+			}
+		})
+	}
+}
+
+func TestBucket_RefillCapsAtBurst(t *testing.T) {
+	for _, b := range buckets {
+		t.Run(b.name, func(t *testing.T) {
+			clock := newFakeClock()
+			l := newBucket(t, b.new, 10, 5, clock)
+
+			l.AllowN(5)
+			clock.Advance(time.Hour)
+
+			if !l.AllowN(5) {
+				t.Fatal("AllowN(5) after refilling = false")
+			}
+			if l.Allow() {
+				t.Error("Allow beyond burst after a long idle period = true")
+			}
+		})
+	}
+}
+
+func TestBucket_AllowN(t *testing.T) {
+	for _, b := range buckets {
+		t.Run(b.name, func(t *testing.T) {
+			l := newBucket(t, b.new, 10, 5, newFakeClock())
+
+			if l.AllowN(6) {
+				t.Error("AllowN above burst = true")
+			}
+			if !l.AllowN(0) || !l.AllowN(-1) {
+				t.Error("AllowN(n <= 0) = false, want true")
+			}
+			if !l.AllowN(3) {
+				t.Fatal("AllowN(3) = false")
+			}
+			if l.AllowN(3) {
+				t.Error("AllowN(3) with 2 tokens = true")
+			}
+			if !l.AllowN(2) {
+				t.Error("AllowN(2) with 2 tokens = false: a failed AllowN must not consume")
+			}
+		})
+	}
+}
+
+func TestBucket_ConcurrentNeverOverAdmits(t *testing.T) {
+	const (
+		burst      = 100
+		goroutines = 32
+		attempts   = 100
+	)
+
+	for _, b := range buckets {
+		t.Run(b.name, func(t *testing.T) {
+			// The clock never advances, so exactly burst requests may be admitted.
+			l := newBucket(t, b.new, 1, burst, newFakeClock())
+
+			var admitted atomic.Int64
+			var wg sync.WaitGroup
+			for range goroutines {
+				wg.Go(func() {
+					for range attempts {
+						if l.Allow() {
+							admitted.Add(1)
+						}
+					}
+				})
+			}
+			wg.Wait()
+
+			if got := admitted.Load(); got != burst {
+				t.Errorf("admitted %d, want exactly %d", got, burst)
+			}
+		})
+	}
+}
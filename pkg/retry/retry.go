@@ -0,0 +1,97 @@
+// Package retry calls a function until it succeeds, sleeping between attempts with
+// exponential backoff and full jitter.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Policy configures Do. The zero Policy makes a single attempt.
+type Policy struct {
+	// MaxAttempts is the total number of calls, including the first. Values below 1 mean 1.
+	MaxAttempts int
+
+	// BaseDelay is the backoff cap before the first retry, doubled for each one after,
+	// up to MaxDelay. A zero MaxDelay leaves the doubling uncapped.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Retryable reports whether an error is worth retrying. Nil retries every error.
+	Retryable func(error) bool
+
+	// Sleep waits for d or until ctx ends, returning ctx's error in that case. Nil uses a
+	// timer; tests substitute a sleeper that records the delays and returns at once.
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+// Backoff returns the upper bound on the delay before retry number n, counting from 0:
+// BaseDelay doubled n times and capped at MaxDelay.
+func (p Policy) Backoff(n int) time.Duration {
+	d := p.BaseDelay
+	for range n {
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+		if d > 1<<62 {
+			// Doubling again would overflow.
+			break
+		}
+		d *= 2
+	}
+	if p.MaxDelay > 0 {
+		d = min(d, p.MaxDelay)
+	}
+	return d
+}
+
+// jitter picks a delay uniformly in [0, ceil], so clients retrying together spread out.
+func jitter(ceil time.Duration) time.Duration {
+	if ceil <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(ceil) + 1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Do calls fn until it returns nil, returns an error Retryable rejects, or has been called
+// MaxAttempts times. Errors from fn are returned unwrapped, so callers can match them with
+// errors.Is. If ctx ends during a backoff, Do returns an error wrapping both ctx's error
+// and fn's last error.
+//
+// A call that succeeds at once does not allocate.
+func Do(ctx context.Context, p Policy, fn func(ctx context.Context) error) error {
+	sleepFn := p.Sleep
+	if sleepFn == nil {
+		sleepFn = sleep
+	}
+	attempts := max(p.MaxAttempts, 1)
+
+	for n := 0; ; n++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if n == attempts-1 || (p.Retryable != nil && !p.Retryable(err)) {
+			return err
+		}
+		if serr := sleepFn(ctx, jitter(p.Backoff(n))); serr != nil {
+			return fmt.Errorf("retry: %w after %d attempts, last error: %w", serr, n+1, err)
+		}
+	}
+}
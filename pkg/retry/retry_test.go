@@ -0,0 +1,214 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var (
+	errTransient = errors.New("transient")
+	errFatal     = errors.New("fatal")
+)
+
+// sleeper records requested delays and returns immediately, or returns the context's
+// error if it has ended.
+type sleeper struct {
+	delays []time.Duration
+}
+
+func (s *sleeper) sleep(ctx context.Context, d time.Duration) error {
+	s.delays = append(s.delays, d)
+	return ctx.Err()
+}
+
+// failTimes returns a function that fails with err n times and then succeeds, counting
+// its calls.
+func failTimes(n int, err error, calls *int) func(context.Context) error {
+	return func(context.Context) error {
+		*calls++
+		if *calls <= n {
+			return err
+		}
+		return nil
+	}
+}
+
+func TestDo_SucceedsFirstTry(t *testing.T) {
+	var s sleeper
+	calls := 0
+	p := Policy{MaxAttempts: 5, BaseDelay: time.Millisecond, Sleep: s.sleep}
+	if err := Do(context.Background(), p, failTimes(0, errTransient, &calls)); err != nil {
+		t.Fatalf("Do = %v", err)
+	}
+	if calls != 1 || len(s.delays) != 0 {
+		t.Errorf("calls = %d, sleeps = %d; want 1 and 0", calls, len(s.delays))
+	}
+}
+
+func TestDo_CountsAttempts(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		failures    int
+		wantCalls   int
+		wantErr     error
+	}{
+		{name: "zero policy makes one attempt", maxAttempts: 0, failures: 1, wantCalls: 1, wantErr: errTransient},
+		{name: "negative means one", maxAttempts: -3, failures: 1, wantCalls: 1, wantErr: errTransient},
+		{name: "succeeds on the last attempt", maxAttempts: 3, failures: 2, wantCalls: 3},
+		{name: "succeeds early", maxAttempts: 5, failures: 1, wantCalls: 2},
+		{name: "exhausted", maxAttempts: 3, failures: 10, wantCalls: 3, wantErr: errTransient},
+	}
+	for _, tt := range tests {
+		var s sleeper
+		calls := 0
+		p := Policy{MaxAttempts: tt.maxAttempts, BaseDelay: time.Millisecond, Sleep: s.sleep}
+		err := Do(context.Background(), p, failTimes(tt.failures, errTransient, &calls))
+		if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+			t.Errorf("%s: Do = %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if calls != tt.wantCalls {
+			t.Errorf("%s: %d calls, want %d", tt.name, calls, tt.wantCalls)
+		}
+		if len(s.delays) != tt.wantCalls-1 {
+			t.Errorf("%s: %d sleeps, want one between each of %d calls", tt.name, len(s.delays), tt.wantCalls)
+		}
+	}
+}
+
+func TestDo_NonRetryableShortCircuits(t *testing.T) {
+	var s sleeper
+	calls := 0
+	p := Policy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Millisecond,
+		Retryable:   func(err error) bool { return !errors.Is(err, errFatal) },
+		Sleep:       s.sleep,
+	}
+	err := Do(context.Background(), p, failTimes(10, errFatal, &calls))
+	if err != errFatal {
+		t.Errorf("Do = %v, want errFatal unwrapped", err)
+	}
+	if calls != 1 || len(s.delays) != 0 {
+		t.Errorf("calls = %d, sleeps = %d; want 1 and 0", calls, len(s.delays))
+	}
+
+	// A retryable error followed by a fatal one stops at the fatal one.
+	calls = 0
+	err = Do(context.Background(), p, func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return errTransient
+		}
+		return errFatal
+	})
+	if err != errFatal || calls != 2 {
+		t.Errorf("Do = %v after %d calls, want errFatal after 2", err, calls)
+	}
+}
+
+func TestDo_CancelledMidBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	p := Policy{MaxAttempts: 5, BaseDelay: time.Hour}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Do(ctx, p, func(context.Context) error {
+			calls++
+			return errTransient
+		})
+	}()
+	time.Sleep(10 * time.Millisecond) // let Do reach its first backoff
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) || !errors.Is(err, errTransient) {
+			t.Errorf("Do = %v, want it to wrap Canceled and the last error", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Do still sleeping a second after cancel")
+	}
+	if calls != 1 {
+		t.Errorf("%d calls before cancel, want 1", calls)
+	}
+}
+
+func TestDo_ZeroDelayStillSeesCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := Do(ctx, Policy{MaxAttempts: 5}, func(context.Context) error {
+		calls++
+		cancel()
+		return errTransient
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("Do = %v after %d calls, want Canceled after 1", err, calls)
+	}
+}
+
+func TestDo_RealSleep(t *testing.T) {
+	calls := 0
+	p := Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+	if err := Do(context.Background(), p, failTimes(2, errTransient, &calls)); err != nil || calls != 3 {
+		t.Errorf("Do = %v after %d calls, want success after 3", err, calls)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		p    Policy
+		n    int
+		want time.Duration
+	}{
+		{p: Policy{BaseDelay: 10 * time.Millisecond}, n: 0, want: 10 * time.Millisecond},
+		{p: Policy{BaseDelay: 10 * time.Millisecond}, n: 3, want: 80 * time.Millisecond},
+		{p: Policy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}, n: 3, want: 50 * time.Millisecond},
+		{p: Policy{BaseDelay: 10 * time.Millisecond, MaxDelay: 5 * time.Millisecond}, n: 0, want: 5 * time.Millisecond},
+		{p: Policy{BaseDelay: time.Second, MaxDelay: time.Minute}, n: 1000, want: time.Minute},
+		// Uncapped doubling stops at the largest value that fits in a Duration.
+		{p: Policy{BaseDelay: time.Second}, n: 1000, want: time.Second << 33},
+		{p: Policy{}, n: 5, want: 0},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Backoff(tt.n); got != tt.want {
+			t.Errorf("%+v.Backoff(%d) = %v, want %v", tt.p, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestDo_JitterWithinBounds(t *testing.T) {
+	p := Policy{MaxAttempts: 8, BaseDelay: time.Millisecond, MaxDelay: 20 * time.Millisecond}
+	var s sleeper
+	p.Sleep = s.sleep
+
+	var seenLow, seenHigh bool
+	for range 200 {
+		s.delays = s.delays[:0]
+		Do(context.Background(), p, func(context.Context) error { return errTransient })
+		for i, d := range s.delays {
+			bound := p.Backoff(i)
+			if d < 0 || d > bound {
+				t.Fatalf("delay before retry %d = %v, want within [0, %v]", i, d, bound)
+			}
+			seenLow = seenLow || d < bound/4
+			seenHigh = seenHigh || d > bound*3/4
+		}
+	}
+	// Full jitter spreads delays over the whole range rather than clustering at the cap.
+	if !seenLow || !seenHigh {
+		t.Errorf("delays not spread across [0, bound]: saw low %v, high %v", seenLow, seenHigh)
+	}
+}
+
+func TestDo_HappyPathAllocs(t *testing.T) {
+	p := Policy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	ctx := context.Background()
+	fn := func(context.Context) error { return nil }
+	if allocs := testing.AllocsPerRun(100, func() { Do(ctx, p, fn) }); allocs != 0 {
+		t.Errorf("Do of a succeeding call allocated %v times, want 0", allocs)
+	}
+}
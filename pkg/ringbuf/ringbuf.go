@@ -0,0 +1,94 @@
+// Package ringbuf provides a fixed-capacity single-producer single-consumer queue
+// built on atomics instead of a channel.
+package ringbuf
+
+import (
+	"errors"
+	"runtime"
+	"sync/atomic"
+)
+
+// ErrInvalidSize is returned when the capacity is not a positive power of two.
+var ErrInvalidSize = errors.New("ringbuf: capacity must be a positive power of two")
+
+// cacheLineSize keeps the producer and consumer indexes on separate cache lines.
+const cacheLineSize = 64
+
+// Ring is a bounded SPSC queue of *T. Exactly one goroutine may push and exactly one
+// goroutine may pop at a time; it is not safe for multiple producers or consumers.
+type Ring[T any] struct {
+	buf  []*T
+	mask uint64
+
+	// head is the next slot to pop, written only by the consumer.
+	head atomic.Uint64
+	_    [cacheLineSize - 8]byte
+	// tail is the next slot to push, written only by the producer.
+	tail atomic.Uint64
+	_    [cacheLineSize - 8]byte
+}
+
+// New creates a Ring holding up to capacity items. The capacity must be a power of two
+// so slot indexes can be masked rather than divided.
+func New[T any](capacity int) (*Ring[T], error) {
+	if capacity <= 0 || capacity&(capacity-1) != 0 {
+		return nil, ErrInvalidSize
+	}
+
+	return &Ring[T]{
+		buf:  make([]*T, capacity),
+		mask: uint64(capacity - 1),
+	}, nil
+}
+
+// TryPush appends v and reports whether there was room. It never blocks.
+func (r *Ring[T]) TryPush(v *T) bool {
+	tail := r.tail.Load()
+	if tail-r.head.Load() == uint64(len(r.buf)) {
+		return false
+	}
+	r.buf[tail&r.mask] = v
+	r.tail.Store(tail + 1)
+	return true
+}
+
+// TryPop removes the oldest item and reports whether there was one. It never blocks.
+func (r *Ring[T]) TryPop() (*T, bool) {
+	head := r.head.Load()
+	if head == r.tail.Load() {
+		return nil, false
+	}
+	slot := &r.buf[head&r.mask]
+	v := *slot
+	*slot = nil // drop the reference so the GC can reclaim popped items
+	r.head.Store(head + 1)
+	return v, true
+}
+
+// Push appends v, yielding to the scheduler while the ring is full.
+func (r *Ring[T]) Push(v *T) {
+	for !r.TryPush(v) {
+		runtime.Gosched()
+	}
+}
+
+// Pop removes the oldest item, yielding to the scheduler while the ring is empty.
+// It blocks forever if nothing is ever pushed.
+func (r *Ring[T]) Pop() *T {
+	for {
+		if v, ok := r.TryPop(); ok {
+			return v
+		}
+		runtime.Gosched()
+	}
+}
+
+// Len returns the number of queued items. It is only a snapshot while the other side is active.
+func (r *Ring[T]) Len() int {
+	return int(r.tail.Load() - r.head.Load())
+}
+
+// Cap returns the maximum number of queued items.
+func (r *Ring[T]) Cap() int {
+	return len(r.buf)
+}
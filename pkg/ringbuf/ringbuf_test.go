@@ -0,0 +1,109 @@
+package ringbuf
+
+import (
+	"errors"
+	"testing"
+)
+
+type item struct {
+	seq int
+}
+
+func newRing(t *testing.T, capacity int) *Ring[item] {
+	t.Helper()
+
+	r, err := New[item](capacity)
+	if err != nil {
+		t.Fatalf("New(%d): %v", capacity, err)
+	}
+	return r
+}
+
+func TestNew_InvalidSize(t *testing.T) {
+	for _, capacity := range []int{0, -1, 3, 6, 1000} {
+		if _, err := New[item](capacity); !errors.Is(err, ErrInvalidSize) {
+			t.Errorf("New(%d) error = %v, want %v", capacity, err, ErrInvalidSize)
+		}
+	}
+}
+
+func TestNew_PowerOfTwo(t *testing.T) {
+	for _, capacity := range []int{1, 2, 64, 1024} {
+		if got := newRing(t, capacity).Cap(); got != capacity {
+			t.Errorf("New(%d).Cap = %d", capacity, got)
+		}
+	}
+}
+
+func TestRing_Empty(t *testing.T) {
+	r := newRing(t, 4)
+
+	if v, ok := r.TryPop(); ok {
+		t.Fatalf("TryPop on empty ring = %v, true", v)
+	}
+	if r.Len() != 0 {
+		t.Errorf("Len = %d, want 0", r.Len())
+	}
+}
+
+func TestRing_Full(t *testing.T) {
+	r := newRing(t, 4)
+
+	items := make([]item, 5)
+	for i := range 4 {
+		if !r.TryPush(&items[i]) {
+			t.Fatalf("TryPush(%d) on non-full ring = false", i)
+		}
+	}
+	if r.TryPush(&items[4]) {
+		t.Fatal("TryPush on full ring = true")
+	}
+	if r.Len() != 4 {
+		t.Errorf("Len = %d, want 4", r.Len())
+	}
+
+	if v, ok := r.TryPop(); !ok || v != &items[0] {
+		t.Fatalf("TryPop = %p, %v, want %p, true", v, ok, &items[0])
+	}
+	if !r.TryPush(&items[4]) {
+		t.Error("TryPush after freeing a slot = false")
+	}
+}
+
+func TestRing_FIFOAcrossWrap(t *testing.T) {
+	r := newRing(t, 2)
+
+	items := make([]item, 10)
+	for i := range items {
+		items[i].seq = i
+		r.Push(&items[i])
+		if got := r.Pop(); got.seq != i {
+			t.Fatalf("Pop = %d, want %d", got.seq, i)
+		}
+	}
+}
+
+func TestRing_SPSCMillion(t *testing.T) {
+	const n = 1_000_000
+
+	r := newRing(t, 1024)
+	items := make([]item, n)
+	for i := range items {
+		items[i].seq = i
+	}
+
+	go func() {
+		for i := range items {
+			r.Push(&items[i])
+		}
+	}()
+
+	for want := range n {
+		if got := r.Pop(); got.seq != want {
+			t.Fatalf("Pop = %d, want %d (reordered or lost)", got.seq, want)
+		}
+	}
+	if v, ok := r.TryPop(); ok {
+		t.Errorf("TryPop after draining = %d, true", v.seq)
+	}
+}
@@ -0,0 +1,77 @@
+package sem
+
+import "context"
+
+// Chan is a weighted semaphore built on a buffered channel holding one token per unit.
+// Blocked senders are queued in order, so waiters are served roughly first come, first
+// served. Weights above 1 are taken one token at a time while holding a separate
+// one-slot channel, so two heavy acquirers can never each hold part of what they need
+// and deadlock.
+type Chan struct {
+	tokens chan struct{}
+	// multi serializes acquisitions of more than one unit.
+	multi chan struct{}
+}
+
+// NewChan creates a Chan with the given capacity, all of it available.
+func NewChan(capacity int64) (*Chan, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidSize
+	}
+	return &Chan{
+		tokens: make(chan struct{}, capacity),
+		multi:  make(chan struct{}, 1),
+	}, nil
+}
+
+// Acquire blocks until n units are available and takes them, or returns ctx.Err()
+// without holding any if ctx is done first.
+func (s *Chan) Acquire(ctx context.Context, n int64) error {
+	if n <= 0 || n > int64(cap(s.tokens)) {
+		return ErrInvalidWeight
+	}
+	if n == 1 {
+		select {
+		case s.tokens <- struct{}{}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case s.multi <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.multi }()
+
+	for i := range n {
+		select {
+		case s.tokens <- struct{}{}:
+		case <-ctx.Done():
+			s.release(i)
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Release returns n units. It panics if that is more than are currently held.
+func (s *Chan) Release(n int64) {
+	if n < 0 || !s.release(n) {
+		panic("sem: released more than held")
+	}
+}
+
+// release removes n tokens, reporting false if the channel ran out first.
+func (s *Chan) release(n int64) bool {
+	for range n {
+		select {
+		case <-s.tokens:
+		default:
+			return false
+		}
+	}
+	return true
+}
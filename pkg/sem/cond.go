@@ -0,0 +1,87 @@
+package sem
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// Cond is a weighted semaphore built on a mutex and sync.Cond. Waiters queue in arrival
+// order and only the head of the queue may take units, so a heavy acquirer is never
+// starved by a stream of light ones. Release wakes every waiter to re-check; that
+// broadcast is the price of using sync.Cond.
+type Cond struct {
+	size int64
+
+	mu      sync.Mutex
+	cond    sync.Cond
+	cur     int64
+	waiters list.List
+}
+
+// NewCond creates a Cond with the given capacity, all of it available.
+func NewCond(capacity int64) (*Cond, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidSize
+	}
+	s := &Cond{size: capacity}
+	s.cond.L = &s.mu
+	return s, nil
+}
+
+// Acquire blocks until n units are available and takes them, or returns ctx.Err()
+// without holding any if ctx is done first.
+func (s *Cond) Acquire(ctx context.Context, n int64) error {
+	if n <= 0 || n > s.size {
+		return ErrInvalidWeight
+	}
+
+	s.mu.Lock()
+	if s.waiters.Len() == 0 && s.cur+n <= s.size {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+
+	// sync.Cond cannot select on ctx, so cancellation wakes every waiter to notice it.
+	stop := context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		s.cond.Broadcast()
+		s.mu.Unlock()
+	})
+	defer stop()
+
+	elem := s.waiters.PushBack(n)
+	for s.waiters.Front() != elem || s.cur+n > s.size {
+		if err := ctx.Err(); err != nil {
+			s.waiters.Remove(elem)
+			// The new head may fit now that this waiter no longer blocks it.
+			s.cond.Broadcast()
+			s.mu.Unlock()
+			return err
+		}
+		s.cond.Wait()
+	}
+	s.waiters.Remove(elem)
+	s.cur += n
+	// The next waiter may fit in what is left.
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	return nil
+}
+
+// Release returns n units. It panics if that is more than are currently held.
+func (s *Cond) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n < 0 || n > s.cur {
+		panic("sem: released more than held")
+	}
+	s.cur -= n
+	s.cond.Broadcast()
+}
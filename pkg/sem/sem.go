@@ -0,0 +1,25 @@
+// Package sem provides weighted semaphores that bound how much of a resource concurrent
+// callers may hold at once.
+package sem
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrInvalidSize is returned when the semaphore capacity is not positive.
+	ErrInvalidSize = errors.New("sem: capacity must be positive")
+	// ErrInvalidWeight is returned by Acquire for a weight that is not positive or exceeds
+	// the capacity, which could never be granted.
+	ErrInvalidWeight = errors.New("sem: weight must be positive and not exceed capacity")
+)
+
+// Weighted is the surface shared by the semaphore implementations.
+type Weighted interface {
+	// Acquire blocks until n units are available and takes them, or returns ctx.Err()
+	// without holding any if ctx is done first.
+	Acquire(ctx context.Context, n int64) error
+	// Release returns n units. It panics if that is more than are currently held.
+	Release(n int64)
+}
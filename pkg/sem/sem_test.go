@@ -0,0 +1,217 @@
+package sem
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+var semaphores = []struct {
+	name string
+	new  func(capacity int64) (Weighted, error)
+}{
+	{name: "chan", new: func(capacity int64) (Weighted, error) { return NewChan(capacity) }},
+	{name: "cond", new: func(capacity int64) (Weighted, error) { return NewCond(capacity) }},
+}
+
+func newSem(t *testing.T, newFn func(int64) (Weighted, error), capacity int64) Weighted {
+	t.Helper()
+
+	s, err := newFn(capacity)
+	if err != nil {
+		t.Fatalf("new(%d): %v", capacity, err)
+	}
+	return s
+}
+
+// acquireWithin acquires n units, failing the test if that takes longer than d.
+func acquireWithin(t *testing.T, s Weighted, n int64, d time.Duration) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	if err := s.Acquire(ctx, n); err != nil {
+		t.Fatalf("Acquire(%d): %v", n, err)
+	}
+}
+
+// mustPanic fails the test unless fn panics.
+func mustPanic(t *testing.T, what string, fn func()) {
+	t.Helper()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s did not panic", what)
+		}
+	}()
+	fn()
+}
+
+func TestNew_InvalidSize(t *testing.T) {
+	for _, sm := range semaphores {
+		for _, capacity := range []int64{0, -1} {
+			if _, err := sm.new(capacity); !errors.Is(err, ErrInvalidSize) {
+				t.Errorf("%s: new(%d) error = %v, want ErrInvalidSize", sm.name, capacity, err)
+			}
+		}
+	}
+}
+
+func TestAcquire_InvalidWeight(t *testing.T) {
+	for _, sm := range semaphores {
+		s := newSem(t, sm.new, 4)
+		for _, n := range []int64{0, -1, 5} {
+			if err := s.Acquire(context.Background(), n); !errors.Is(err, ErrInvalidWeight) {
+				t.Errorf("%s: Acquire(%d) error = %v, want ErrInvalidWeight", sm.name, n, err)
+			}
+		}
+	}
+}
+
+func TestAcquire_BlocksAtCapacity(t *testing.T) {
+	for _, sm := range semaphores {
+		t.Run(sm.name, func(t *testing.T) {
+			s := newSem(t, sm.new, 4)
+			acquireWithin(t, s, 3, time.Second)
+			acquireWithin(t, s, 1, time.Second)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			if err := s.Acquire(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Acquire on a full semaphore = %v, want DeadlineExceeded", err)
+			}
+
+			s.Release(2)
+			acquireWithin(t, s, 2, time.Second)
+		})
+	}
+}
+
+func TestAcquire_CancelWhileBlocked(t *testing.T) {
+	for _, sm := range semaphores {
+		t.Run(sm.name, func(t *testing.T) {
+			s := newSem(t, sm.new, 4)
+			acquireWithin(t, s, 2, time.Second)
+
+			// Weight 4 blocks while 2 units are held; the chan implementation holds the
+			// other 2 tokens while it waits and must give them back on cancellation.
+			ctx, cancel := context.WithCancel(context.Background())
+			errc := make(chan error, 1)
+			go func() { errc <- s.Acquire(ctx, 4) }()
+
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+			select {
+			case err := <-errc:
+				if !errors.Is(err, context.Canceled) {
+					t.Fatalf("blocked Acquire = %v, want Canceled", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("blocked Acquire did not return after cancel")
+			}
+
+			s.Release(2)
+			acquireWithin(t, s, 4, time.Second)
+		})
+	}
+}
+
+func TestAcquire_CanceledContextWhenFull(t *testing.T) {
+	for _, sm := range semaphores {
+		s := newSem(t, sm.new, 1)
+		acquireWithin(t, s, 1, time.Second)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := s.Acquire(ctx, 1); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: Acquire with a canceled context = %v, want Canceled", sm.name, err)
+		}
+	}
+}
+
+func TestRelease_MoreThanHeldPanics(t *testing.T) {
+	for _, sm := range semaphores {
+		s := newSem(t, sm.new, 4)
+		mustPanic(t, sm.name+": Release on an idle semaphore", func() { s.Release(1) })
+
+		acquireWithin(t, s, 2, time.Second)
+		mustPanic(t, sm.name+": Release(3) after Acquire(2)", func() { s.Release(3) })
+		mustPanic(t, sm.name+": Release(-1)", func() { s.Release(-1) })
+	}
+}
+
+func TestAcquire_HeavyWaiterNotStarved(t *testing.T) {
+	const capacity = 8
+	for _, sm := range semaphores {
+		t.Run(sm.name, func(t *testing.T) {
+			s := newSem(t, sm.new, capacity)
+
+			// Light acquirers keep the semaphore busy the whole time.
+			ctx, cancel := context.WithCancel(context.Background())
+			var wg sync.WaitGroup
+			for range capacity - 1 {
+				wg.Go(func() {
+					for ctx.Err() == nil {
+						if err := s.Acquire(ctx, 1); err != nil {
+							return
+						}
+						time.Sleep(100 * time.Microsecond)
+						s.Release(1)
+					}
+				})
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			// The full-capacity acquirer still gets its turn.
+			acquireWithin(t, s, capacity, 5*time.Second)
+			s.Release(capacity)
+			cancel()
+			wg.Wait()
+		})
+	}
+}
+
+func TestAcquire_AllWaitersProgress(t *testing.T) {
+	const (
+		capacity = 8
+		workers  = 16
+		rounds   = 20
+	)
+	for _, sm := range semaphores {
+		t.Run(sm.name, func(t *testing.T) {
+			s := newSem(t, sm.new, capacity)
+			// Generous: the run takes milliseconds unless some worker is starved.
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			// Every worker holds its units briefly, so they contend for the whole run.
+			var acquired [workers]int
+			var wg sync.WaitGroup
+			for w := range workers {
+				n := int64(1)
+				if w%4 == 0 {
+					n = 4
+				}
+				wg.Go(func() {
+					for range rounds {
+						if err := s.Acquire(ctx, n); err != nil {
+							return
+						}
+						acquired[w]++
+						time.Sleep(50 * time.Microsecond)
+						s.Release(n)
+					}
+				})
+			}
+			wg.Wait()
+
+			for w, got := range acquired {
+				if got != rounds {
+					t.Errorf("worker %d acquired %d of %d times", w, got, rounds)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Package shutdown orchestrates a graceful shutdown: request handlers check a cheap
+// Accepting flag, and registered cleanup hooks run in priority order, each bounded by a
+// timeout, once shutdown is triggered.
+package shutdown
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrShuttingDown is returned by Register once shutdown has started.
+var ErrShuttingDown = errors.New("shutdown: already shutting down")
+
+// DefaultHookTimeout bounds each hook when New is given a non-positive timeout.
+const DefaultHookTimeout = 5 * time.Second
+
+// Hook is a cleanup function. Its context is cancelled when the hook's timeout passes or
+// the context given to Shutdown ends.
+type Hook func(ctx context.Context) error
+
+type hook struct {
+	name     string
+	priority int
+	fn       Hook
+}
+
+// Manager runs registered hooks when shutdown is triggered, by Shutdown or by a context
+// passed to Watch ending.
+type Manager struct {
+	hookTimeout time.Duration
+
+	// accepting is read on every request, so it is an atomic rather than guarded by mu.
+	accepting atomic.Bool
+
+	mu      sync.Mutex
+	hooks   []hook
+	started bool
+
+	done chan struct{}
+	err  error // written before done is closed
+}
+
+// New creates a Manager that is accepting work and bounds each hook by hookTimeout.
+// A non-positive timeout uses DefaultHookTimeout.
+func New(hookTimeout time.Duration) *Manager {
+	if hookTimeout <= 0 {
+		hookTimeout = DefaultHookTimeout
+	}
+	m := &Manager{hookTimeout: hookTimeout, done: make(chan struct{})}
+	m.accepting.Store(true)
+	return m
+}
+
+// Accepting reports whether shutdown has not started yet. Handlers call it to reject new
+// work while hooks drain; it is a single atomic load.
+func (m *Manager) Accepting() bool {
+	return m.accepting.Load()
+}
+
+// Register adds a hook. Hooks run in ascending priority order, and hooks of equal
+// priority in the order they were registered. Register returns ErrShuttingDown once
+// shutdown has started, since the hook would never run.
+func (m *Manager) Register(name string, priority int, fn Hook) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.started {
+		return ErrShuttingDown
+	}
+	m.hooks = append(m.hooks, hook{name: name, priority: priority, fn: fn})
+	return nil
+}
+
+// Watch triggers shutdown when ctx ends, typically a context from signal.NotifyContext.
+// The watching goroutine exits once shutdown has finished, whatever triggered it.
+func (m *Manager) Watch(ctx context.Context) {
+	go func() {
+		select {
+		case <-ctx.Done():
+			m.Shutdown(context.Background())
+		case <-m.done:
+		}
+	}()
+}
+
+// Done is closed once every hook has returned or timed out.
+func (m *Manager) Done() <-chan struct{} {
+	return m.done
+}
+
+// Shutdown stops accepting work and runs the hooks one at a time, giving each up to the
+// hook timeout. A hook still running at its timeout is abandoned, not waited for, and the
+// next one starts. Shutdown returns the hooks' errors joined, with timeouts wrapping
+// context.DeadlineExceeded.
+//
+// Only the first call runs the hooks; later and concurrent calls wait for it to finish and
+// return the same error, or return ctx's error if their own ctx ends first. Their ctx does
+// not affect the hooks, which stay bounded by the first caller's.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	if m.started {
+		m.mu.Unlock()
+		select {
+		case <-m.done: // finished already: report it even if ctx has ended too
+			return m.err
+		default:
+		}
+		select {
+		case <-m.done:
+			return m.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	m.started = true
+	m.accepting.Store(false)
+	hooks := m.hooks
+	m.hooks = nil
+	m.mu.Unlock()
+
+	slices.SortStableFunc(hooks, func(a, b hook) int { return cmp.Compare(a.priority, b.priority) })
+	var errs []error
+	for _, h := range hooks {
+		if err := m.run(ctx, h); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown: hook %q: %w", h.name, err))
+		}
+	}
+	m.err = errors.Join(errs...)
+	close(m.done)
+	return m.err
+}
+
+// run calls h on its own goroutine so a hook that ignores its context cannot hold up the
+// rest of the shutdown.
+func (m *Manager) run(ctx context.Context, h hook) error {
+	ctx, cancel := context.WithTimeout(ctx, m.hookTimeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() { result <- h.fn(ctx) }()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,261 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is a hook factory that records the order hooks ran in.
+type recorder struct {
+	mu  sync.Mutex
+	ran []string
+}
+
+func (r *recorder) hook(name string) Hook {
+	return func(context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.ran = append(r.ran, name)
+		return nil
+	}
+}
+
+func (r *recorder) order() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.ran)
+}
+
+func mustRegister(t *testing.T, m *Manager, name string, priority int, fn Hook) {
+	t.Helper()
+	if err := m.Register(name, priority, fn); err != nil {
+		t.Fatalf("Register(%q) = %v", name, err)
+	}
+}
+
+func TestShutdown_RunsHooksInPriorityOrder(t *testing.T) {
+	m := New(time.Second)
+	var r recorder
+	mustRegister(t, m, "db", 30, r.hook("db"))
+	mustRegister(t, m, "listener", 10, r.hook("listener"))
+	mustRegister(t, m, "flush-a", 20, r.hook("flush-a"))
+	mustRegister(t, m, "flush-b", 20, r.hook("flush-b"))
+
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown = %v", err)
+	}
+	want := []string{"listener", "flush-a", "flush-b", "db"}
+	if got := r.order(); !slices.Equal(got, want) {
+		t.Errorf("hooks ran in order %v, want %v", got, want)
+	}
+}
+
+func TestShutdown_StopsAccepting(t *testing.T) {
+	m := New(time.Second)
+	if !m.Accepting() {
+		t.Fatal("new Manager is not accepting")
+	}
+
+	var during bool
+	mustRegister(t, m, "check", 0, func(context.Context) error {
+		during = m.Accepting()
+		return nil
+	})
+	m.Shutdown(context.Background())
+	if during {
+		t.Error("Accepting was true while hooks ran")
+	}
+	if m.Accepting() {
+		t.Error("Accepting is true after Shutdown")
+	}
+}
+
+func TestShutdown_StuckHookTimesOut(t *testing.T) {
+	const timeout = 20 * time.Millisecond
+	m := New(timeout)
+	var r recorder
+
+	release := make(chan struct{})
+	defer close(release)
+	mustRegister(t, m, "stuck", 0, func(context.Context) error {
+		<-release // ignores its context
+		return nil
+	})
+	mustRegister(t, m, "after", 1, r.hook("after"))
+
+	start := time.Now()
+	err := m.Shutdown(context.Background())
+	if elapsed := time.Since(start); elapsed < timeout || elapsed > time.Second {
+		t.Errorf("Shutdown took %v, want about the %v hook timeout", elapsed, timeout)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown = %v, want DeadlineExceeded", err)
+	}
+	if got := r.order(); !slices.Equal(got, []string{"after"}) {
+		t.Errorf("hooks after the stuck one ran %v, want [after]", got)
+	}
+}
+
+func TestShutdown_HookSeesTimeout(t *testing.T) {
+	m := New(10 * time.Millisecond)
+	hasDeadline := make(chan bool, 1)
+	mustRegister(t, m, "waits", 0, func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		hasDeadline <- ok
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err := m.Shutdown(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown = %v, want DeadlineExceeded", err)
+	}
+	if !<-hasDeadline {
+		t.Error("hook context has no deadline")
+	}
+}
+
+func TestShutdown_JoinsHookErrors(t *testing.T) {
+	m := New(time.Second)
+	errA, errB := errors.New("a failed"), errors.New("b failed")
+	mustRegister(t, m, "a", 0, func(context.Context) error { return errA })
+	mustRegister(t, m, "ok", 1, func(context.Context) error { return nil })
+	mustRegister(t, m, "b", 2, func(context.Context) error { return errB })
+
+	err := m.Shutdown(context.Background())
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("Shutdown = %v, want both hook errors", err)
+	}
+}
+
+func TestShutdown_Idempotent(t *testing.T) {
+	m := New(time.Second)
+	errHook := errors.New("hook failed")
+	runs := 0
+	mustRegister(t, m, "once", 0, func(context.Context) error {
+		runs++
+		time.Sleep(5 * time.Millisecond) // let the other callers arrive mid-shutdown
+		return errHook
+	})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Go(func() { errs[i] = m.Shutdown(context.Background()) })
+	}
+	wg.Wait()
+	if err := m.Shutdown(context.Background()); !errors.Is(err, errHook) {
+		t.Errorf("Shutdown after completion = %v, want the hook error", err)
+	}
+
+	if runs != 1 {
+		t.Errorf("hook ran %d times, want 1", runs)
+	}
+	for i, err := range errs {
+		if !errors.Is(err, errHook) {
+			t.Errorf("caller %d: Shutdown = %v, want the hook error", i, err)
+		}
+	}
+}
+
+func TestShutdown_LaterCallerHonorsOwnDeadline(t *testing.T) {
+	m := New(time.Second)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mustRegister(t, m, "slow", 0, func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	first := make(chan error, 1)
+	go func() { first <- m.Shutdown(context.Background()) }()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	begin := time.Now()
+	if err := m.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second Shutdown = %v, want its own context.DeadlineExceeded", err)
+	}
+	if waited := time.Since(begin); waited > 500*time.Millisecond {
+		t.Errorf("second Shutdown returned after %v, well past its 10ms deadline", waited)
+	}
+
+	// The first caller's shutdown is unaffected and still completes.
+	close(release)
+	if err := <-first; err != nil {
+		t.Errorf("first Shutdown = %v, want nil", err)
+	}
+	if err := m.Shutdown(ctx); err != nil {
+		t.Errorf("Shutdown after completion with an expired ctx = %v, want the stored nil", err)
+	}
+}
+
+func TestRegister_AfterShutdownStarted(t *testing.T) {
+	m := New(time.Second)
+	registered := make(chan error, 1)
+	mustRegister(t, m, "registers", 0, func(context.Context) error {
+		registered <- m.Register("late", 1, func(context.Context) error {
+			t.Error("hook registered during shutdown ran")
+			return nil
+		})
+		return nil
+	})
+
+	m.Shutdown(context.Background())
+	if err := <-registered; !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("Register during shutdown = %v, want ErrShuttingDown", err)
+	}
+	if err := m.Register("later", 0, func(context.Context) error { return nil }); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("Register after shutdown = %v, want ErrShuttingDown", err)
+	}
+}
+
+func TestWatch_TriggersOnCancel(t *testing.T) {
+	m := New(time.Second)
+	var r recorder
+	mustRegister(t, m, "hook", 0, r.hook("hook"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.Watch(ctx)
+	if !m.Accepting() {
+		t.Fatal("Watch shut down before its context ended")
+	}
+	cancel()
+
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatal("shutdown did not finish within a second of the context ending")
+	}
+	if got := r.order(); !slices.Equal(got, []string{"hook"}) {
+		t.Errorf("hooks ran %v, want [hook]", got)
+	}
+}
+
+func TestWatch_ExitsAfterExplicitShutdown(t *testing.T) {
+	before := runtime.NumGoroutine()
+	m := New(time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Watch(ctx)
+
+	m.Shutdown(context.Background())
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines running after Shutdown, want %d", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNew_DefaultTimeout(t *testing.T) {
+	if m := New(0); m.hookTimeout != DefaultHookTimeout {
+		t.Errorf("New(0) hook timeout = %v, want %v", m.hookTimeout, DefaultHookTimeout)
+	}
+}
@@ -0,0 +1,74 @@
+// Package slab provides an arena that hands out pointers into large chunks of values, so a
+// burst of thousands of short-lived objects costs a handful of allocations instead of one
+// each, and is freed all at once.
+package slab
+
+import "errors"
+
+// ErrInvalidSize is returned when the chunk size is not positive.
+var ErrInvalidSize = errors.New("slab: chunk size must be positive")
+
+// DefaultChunkSize is a chunk size suited to request-scoped bursts of small structs.
+const DefaultChunkSize = 1024
+
+// Arena allocates values of type T from chunks of chunkSize elements. Pointers it returns
+// stay valid until Release; chunks are never moved or resized, only appended to.
+//
+// Each chunk is one heap object, so the GC keeps a whole chunk alive while any pointer into
+// it is reachable. An Arena is not safe for concurrent use.
+type Arena[T any] struct {
+	chunkSize int
+	chunks    [][]T // in use; the last one is being filled
+	free      [][]T // released chunks, zeroed, ready for reuse
+	n         int
+}
+
+// NewArena creates an Arena allocating chunkSize values at a time.
+func NewArena[T any](chunkSize int) (*Arena[T], error) {
+	if chunkSize <= 0 {
+		return nil, ErrInvalidSize
+	}
+	return &Arena[T]{chunkSize: chunkSize}, nil
+}
+
+// New returns a pointer to a zero T, taking a chunk from the free list, or allocating
+// one, when the current chunk is full.
+func (a *Arena[T]) New() *T {
+	last := len(a.chunks) - 1
+	if last < 0 || len(a.chunks[last]) == a.chunkSize {
+		a.chunks = append(a.chunks, a.chunk())
+		last++
+	}
+	c := a.chunks[last]
+	c = c[:len(c)+1]
+	a.chunks[last] = c
+	a.n++
+	return &c[len(c)-1]
+}
+
+func (a *Arena[T]) chunk() []T {
+	if n := len(a.free); n > 0 {
+		c := a.free[n-1]
+		a.free[n-1] = nil
+		a.free = a.free[:n-1]
+		return c
+	}
+	return make([]T, 0, a.chunkSize)
+}
+
+// Len returns the number of values handed out since the last Release.
+func (a *Arena[T]) Len() int {
+	return a.n
+}
+
+// Release frees every value at once: the chunks are zeroed, so they pin nothing T points
+// to, and kept for the next burst. Pointers returned by New must not be used afterwards.
+func (a *Arena[T]) Release() {
+	for i, c := range a.chunks {
+		clear(c)
+		a.free = append(a.free, c[:0])
+		a.chunks[i] = nil
+	}
+	a.chunks = a.chunks[:0]
+	a.n = 0
+}
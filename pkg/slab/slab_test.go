@@ -0,0 +1,142 @@
+package slab
+
+import (
+	"errors"
+	"testing"
+)
+
+type item struct {
+	ID   int64
+	Name *string
+}
+
+func TestNewArena_RejectsInvalidSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		if _, err := NewArena[item](size); !errors.Is(err, ErrInvalidSize) {
+			t.Errorf("NewArena(%d) error = %v, want ErrInvalidSize", size, err)
+		}
+	}
+}
+
+func newArena(t *testing.T, chunkSize int) *Arena[item] {
+	t.Helper()
+	a, err := NewArena[item](chunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestArena_ChunkBoundary(t *testing.T) {
+	const chunk = 4
+	a := newArena(t, chunk)
+
+	ptrs := make([]*item, 2*chunk+1)
+	for i := range ptrs {
+		ptrs[i] = a.New()
+	}
+	if got := a.Len(); got != len(ptrs) {
+		t.Errorf("Len = %d, want %d", got, len(ptrs))
+	}
+	if got := len(a.chunks); got != 3 {
+		t.Fatalf("%d values used %d chunks, want 3", len(ptrs), got)
+	}
+	// Value i is element i%chunk of chunk i/chunk: a chunk fills completely before the
+	// next one is started.
+	for i, p := range ptrs {
+		c := a.chunks[i/chunk]
+		if p != &c[:cap(c)][i%chunk] {
+			t.Errorf("value %d is not element %d of chunk %d", i, i%chunk, i/chunk)
+		}
+	}
+}
+
+func TestArena_AllocatesOncePerChunk(t *testing.T) {
+	const chunk = 64
+	var a *Arena[item]
+	allocs := testing.AllocsPerRun(10, func() {
+		a, _ = NewArena[item](chunk)
+		a.chunks = make([][]item, 0, 8) // keep the chunk list's growth out of the count
+		for range 4 * chunk {
+			a.New()
+		}
+	})
+	// The Arena, its chunk list, and one allocation per chunk.
+	if allocs != 2+4 {
+		t.Errorf("a fresh burst of 4 chunks allocated %v times, want 6", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(10, func() {
+		a.Release()
+		for range 4 * chunk {
+			a.New()
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("a burst after Release allocated %v times, want 0", allocs)
+	}
+}
+
+func TestArena_PointersStableUntilRelease(t *testing.T) {
+	const n = 10_000
+	a := newArena(t, 128)
+
+	ptrs := make([]*item, n)
+	for i := range ptrs {
+		p := a.New()
+		p.ID = int64(i)
+		ptrs[i] = p
+	}
+	// Filling later chunks must not move or overwrite earlier values.
+	for i, p := range ptrs {
+		if p.ID != int64(i) {
+			t.Fatalf("value %d has ID %d after %d more allocations", i, p.ID, n-i-1)
+		}
+	}
+}
+
+func TestArena_ReuseAfterRelease(t *testing.T) {
+	const chunk = 8
+	a := newArena(t, chunk)
+
+	name := "order"
+	first := make(map[*item]bool)
+	for range 3 * chunk {
+		p := a.New()
+		p.ID, p.Name = 7, &name
+		first[p] = true
+	}
+	a.Release()
+	if got := a.Len(); got != 0 {
+		t.Errorf("Len after Release = %d, want 0", got)
+	}
+	if got := len(a.free); got != 3 {
+		t.Errorf("Release kept %d chunks, want 3", got)
+	}
+	for _, c := range a.free {
+		for _, v := range c[:cap(c)] {
+			if v != (item{}) {
+				t.Fatalf("released chunk holds %+v, want it zeroed", v)
+			}
+		}
+	}
+
+	for range 3 * chunk {
+		p := a.New()
+		if !first[p] {
+			t.Fatal("New after Release returned memory outside the released chunks")
+		}
+		if *p != (item{}) {
+			t.Fatalf("New after Release returned %+v, want a zero value", *p)
+		}
+	}
+	if got := len(a.free); got != 0 {
+		t.Errorf("%d released chunks left unused, want 0", got)
+	}
+
+	// Growing past the reused chunks allocates a new one.
+	a.New()
+	if got := len(a.chunks); got != 4 {
+		t.Errorf("chunks after growing = %d, want 4", got)
+	}
+}
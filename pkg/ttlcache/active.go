@@ -0,0 +1,75 @@
+package ttlcache
+
+import (
+	"context"
+	"time"
+)
+
+// Active is a TTL cache with a background goroutine that removes expired entries every
+// sweep interval, so memory is reclaimed whether or not entries are read again. Get only
+// reads; an expired entry found between sweeps is reported as missing and left for the sweeper.
+type Active[K comparable, V any] struct {
+	store[K, V]
+	done chan struct{}
+}
+
+// NewActive creates an Active cache whose entries expire ttl after being set, and starts
+// its sweeper. The sweeper stops when ctx is done; Done reports when it has exited.
+//
+// The sweeper ticks on wall time even when WithClock is given; tests drive Sweep directly.
+func NewActive[K comparable, V any](ctx context.Context, ttl, interval time.Duration, opts ...Option) (*Active[K, V], error) {
+	if ttl <= 0 || interval <= 0 {
+		return nil, ErrInvalidConfig
+	}
+
+	c := &Active[K, V]{
+		store: newStore[K, V](ttl, opts),
+		done:  make(chan struct{}),
+	}
+	go c.sweepEvery(ctx, interval)
+	return c, nil
+}
+
+func (c *Active[K, V]) sweepEvery(ctx context.Context, interval time.Duration) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Sweep()
+		}
+	}
+}
+
+// Get returns the value for key if it is stored and not expired.
+func (c *Active[K, V]) Get(key K) (*V, bool) {
+	value, ok, _ := c.lookup(key, c.now())
+	return value, ok
+}
+
+// Sweep removes every expired entry and returns how many it removed.
+// It holds the write lock for one pass over the whole map.
+func (c *Active[K, V]) Sweep() int {
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key, e := range c.items {
+		if e.expired(now) {
+			delete(c.items, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Done returns a channel that is closed once the sweeper goroutine has exited.
+func (c *Active[K, V]) Done() <-chan struct{} {
+	return c.done
+}
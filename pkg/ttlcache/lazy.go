@@ -0,0 +1,36 @@
+package ttlcache
+
+import "time"
+
+// Lazy is a TTL cache that removes an expired entry only when Get finds it.
+// It runs no goroutines, but an entry that is set and never read again stays in
+// memory indefinitely.
+type Lazy[K comparable, V any] struct {
+	store[K, V]
+}
+
+// NewLazy creates a Lazy cache whose entries expire ttl after being set.
+func NewLazy[K comparable, V any](ttl time.Duration, opts ...Option) (*Lazy[K, V], error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidConfig
+	}
+	return &Lazy[K, V]{store: newStore[K, V](ttl, opts)}, nil
+}
+
+// Get returns the value for key if it is stored and not expired.
+// Finding an expired entry deletes it.
+func (c *Lazy[K, V]) Get(key K) (*V, bool) {
+	now := c.now()
+	value, ok, expired := c.lookup(key, now)
+	if !expired {
+		return value, ok
+	}
+
+	c.mu.Lock()
+	// Another Set may have replaced the entry since the read lock was dropped.
+	if e, found := c.items[key]; found && e.expired(now) {
+		delete(c.items, key)
+	}
+	c.mu.Unlock()
+	return nil, false
+}
@@ -0,0 +1,94 @@
+// Package ttlcache provides caches whose entries expire a fixed duration after being set.
+package ttlcache
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInvalidConfig is returned when the TTL or sweep interval is not positive.
+var ErrInvalidConfig = errors.New("ttlcache: ttl and interval must be positive")
+
+// config holds the settings shared by every cache implementation.
+type config struct {
+	now func() time.Time
+}
+
+func newConfig(opts []Option) config {
+	c := config{now: time.Now}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Option configures a cache.
+type Option func(*config)
+
+// WithClock replaces time.Now as the cache's time source, typically with a fake clock in tests.
+func WithClock(now func() time.Time) Option {
+	return func(c *config) {
+		c.now = now
+	}
+}
+
+type entry[V any] struct {
+	value   *V
+	expires time.Time
+}
+
+// expired reports whether e has expired at now. An entry set at t with TTL d
+// is live strictly before t+d and expired from t+d on.
+func (e entry[V]) expired(now time.Time) bool {
+	return !now.Before(e.expires)
+}
+
+// store is the map and lock shared by the implementations; they differ only in when
+// expired entries are removed.
+type store[K comparable, V any] struct {
+	ttl time.Duration
+	config
+
+	mu    sync.RWMutex
+	items map[K]entry[V]
+}
+
+func newStore[K comparable, V any](ttl time.Duration, opts []Option) store[K, V] {
+	return store[K, V]{
+		ttl:    ttl,
+		config: newConfig(opts),
+		items:  make(map[K]entry[V]),
+	}
+}
+
+// Set stores value under key, expiring ttl from now and replacing any existing entry.
+func (s *store[K, V]) Set(key K, value *V) {
+	expires := s.now().Add(s.ttl)
+
+	s.mu.Lock()
+	s.items[key] = entry[V]{value: value, expires: expires}
+	s.mu.Unlock()
+}
+
+// Len returns the number of stored entries, including expired ones not yet removed.
+func (s *store[K, V]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.items)
+}
+
+// lookup returns the live entry for key, and whether an expired one is stored instead.
+func (s *store[K, V]) lookup(key K, now time.Time) (value *V, ok, expired bool) {
+	s.mu.RLock()
+	e, found := s.items[key]
+	s.mu.RUnlock()
+
+	if !found {
+		return nil, false, false
+	}
+	if e.expired(now) {
+		return nil, false, true
+	}
+	return e.value, true, false
+}
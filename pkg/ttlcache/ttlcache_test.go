@@ -0,0 +1,232 @@
+package ttlcache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced time source, safe for concurrent reads.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1_700_000_000, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+type item struct {
+	n int
+}
+
+// cache is the surface shared by Lazy and Active.
+type cache interface {
+	Get(key string) (*item, bool)
+	Set(key string, value *item)
+	Len() int
+}
+
+// hourly keeps the sweeper from running on its own during tests that expect expired
+// entries to still be stored.
+const hourly = time.Hour
+
+// caches builds each implementation. Lazy has no sweeper and ignores interval.
+var caches = []struct {
+	name string
+	new  func(t *testing.T, ttl, interval time.Duration, clock *fakeClock) cache
+}{
+	{name: "lazy", new: func(t *testing.T, ttl, _ time.Duration, clock *fakeClock) cache {
+		return newLazy(t, ttl, clock)
+	}},
+	{name: "active", new: func(t *testing.T, ttl, interval time.Duration, clock *fakeClock) cache {
+		return newActive(t, t.Context(), ttl, interval, clock)
+	}},
+}
+
+func newLazy(t *testing.T, ttl time.Duration, clock *fakeClock) *Lazy[string, item] {
+	t.Helper()
+
+	c, err := NewLazy[string, item](ttl, WithClock(clock.Now))
+	if err != nil {
+		t.Fatalf("NewLazy(%v): %v", ttl, err)
+	}
+	return c
+}
+
+func newActive(t *testing.T, ctx context.Context, ttl, interval time.Duration, clock *fakeClock) *Active[string, item] {
+	t.Helper()
+
+	c, err := NewActive[string, item](ctx, ttl, interval, WithClock(clock.Now))
+	if err != nil {
+		t.Fatalf("NewActive(%v, %v): %v", ttl, interval, err)
+	}
+	return c
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		if _, err := NewLazy[string, item](d); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("NewLazy(%v) error = %v, want %v", d, err, ErrInvalidConfig)
+		}
+		if _, err := NewActive[string, item](t.Context(), d, time.Second); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("NewActive(ttl %v) error = %v, want %v", d, err, ErrInvalidConfig)
+		}
+		if _, err := NewActive[string, item](t.Context(), time.Second, d); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("NewActive(interval %v) error = %v, want %v", d, err, ErrInvalidConfig)
+		}
+	}
+}
+
+func TestCache_ExpiryBoundary(t *testing.T) {
+	for _, cc := range caches {
+		t.Run(cc.name, func(t *testing.T) {
+			clock := newFakeClock()
+			c := cc.new(t, time.Minute, hourly, clock)
+			v := &item{n: 1}
+			c.Set("a", v)
+
+			clock.Advance(time.Minute - time.Nanosecond)
+			if got, ok := c.Get("a"); !ok || got != v {
+				t.Fatalf("Get 1ns before expiry = %p, %v, want %p, true", got, ok, v)
+			}
+
+			clock.Advance(time.Nanosecond)
+			if got, ok := c.Get("a"); ok || got != nil {
+				t.Errorf("Get at expiry = %p, %v, want nil, false", got, ok)
+			}
+		})
+	}
+}
+
+func TestCache_SetRefreshesExpiry(t *testing.T) {
+	for _, cc := range caches {
+		t.Run(cc.name, func(t *testing.T) {
+			clock := newFakeClock()
+			c := cc.new(t, time.Minute, hourly, clock)
+			c.Set("a", &item{n: 1})
+
+			clock.Advance(30 * time.Second)
+			v := &item{n: 2}
+			c.Set("a", v)
+
+			clock.Advance(45 * time.Second)
+			if got, ok := c.Get("a"); !ok || got != v {
+				t.Errorf("Get 45s after re-Set = %p, %v, want %p, true", got, ok, v)
+			}
+		})
+	}
+}
+
+func TestLazy_RemovesOnlyOnGet(t *testing.T) {
+	clock := newFakeClock()
+	c := newLazy(t, time.Minute, clock)
+	c.Set("read", &item{})
+	c.Set("unread", &item{})
+
+	clock.Advance(time.Minute)
+	if c.Len() != 2 {
+		t.Fatalf("Len after expiry = %d, want 2: nothing has read the entries yet", c.Len())
+	}
+
+	c.Get("read")
+	if c.Len() != 1 {
+		t.Errorf("Len after reading one expired entry = %d, want 1", c.Len())
+	}
+}
+
+func TestActive_Sweep(t *testing.T) {
+	clock := newFakeClock()
+	c := newActive(t, t.Context(), time.Minute, hourly, clock)
+	c.Set("old", &item{})
+	clock.Advance(30 * time.Second)
+	c.Set("new", &item{})
+
+	clock.Advance(30 * time.Second)
+	if n := c.Sweep(); n != 1 {
+		t.Errorf("Sweep removed %d entries, want 1", n)
+	}
+	if _, ok := c.Get("new"); !ok || c.Len() != 1 {
+		t.Errorf("after Sweep: Len = %d, Get(new) ok = %v, want 1, true", c.Len(), ok)
+	}
+}
+
+func TestActive_SweeperRunsInBackground(t *testing.T) {
+	clock := newFakeClock()
+	c := newActive(t, t.Context(), time.Minute, time.Millisecond, clock)
+	for i := range 10 {
+		c.Set(strconv.Itoa(i), &item{n: i})
+	}
+	clock.Advance(time.Minute)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for c.Len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Len = %d after 5s, want the sweeper to have removed every entry", c.Len())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestActive_SweeperStopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	c := newActive(t, ctx, time.Minute, time.Millisecond, newFakeClock())
+
+	select {
+	case <-c.Done():
+		t.Fatal("sweeper exited before cancel")
+	default:
+	}
+
+	cancel()
+	select {
+	case <-c.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("sweeper still running 5s after cancel")
+	}
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	const goroutines = 8
+
+	for _, cc := range caches {
+		t.Run(cc.name, func(t *testing.T) {
+			clock := newFakeClock()
+			c := cc.new(t, 10*time.Millisecond, time.Millisecond, clock)
+
+			var wg sync.WaitGroup
+			for g := range goroutines {
+				wg.Go(func() {
+					for i := range 2_000 {
+						key := strconv.Itoa(i % 64)
+						if (g+i)%3 == 0 {
+							c.Set(key, &item{n: i})
+						} else if v, ok := c.Get(key); ok && v == nil {
+							t.Error("Get returned ok with a nil value")
+							return
+						}
+						if i%100 == 0 {
+							clock.Advance(time.Millisecond)
+						}
+					}
+				})
+			}
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Package unsafeconv converts between string and []byte without copying.
+//
+// Both conversions alias the same memory, so they are only safe when the caller
+// upholds the contract documented on each function. Prefer the ordinary copying
+// conversions unless a profile shows the copy matters.
+package unsafeconv
+
+import "unsafe"
+
+// B2S returns a string that shares b's memory.
+//
+// The caller must not modify b for as long as the returned string, or anything
+// derived from it such as a map key, is in use: Go strings are assumed immutable,
+// and a later write to b changes the string in place.
+func B2S(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// S2B returns a []byte that shares s's memory.
+//
+// The caller must never write to the returned slice. String data may live in
+// read-only memory, where a write crashes the program, and other strings may
+// share the same bytes.
+func S2B(s string) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
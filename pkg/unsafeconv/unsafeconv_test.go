@@ -0,0 +1,84 @@
+package unsafeconv
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestB2S(t *testing.T) {
+	b := []byte("ORD-12345")
+	s := B2S(b)
+
+	if s != "ORD-12345" {
+		t.Fatalf("B2S = %q, want %q", s, "ORD-12345")
+	}
+	if unsafe.StringData(s) != &b[0] {
+		t.Error("B2S copied the bytes, want shared memory")
+	}
+}
+
+func TestS2B(t *testing.T) {
+	s := strings.Repeat("x", 16)
+	b := S2B(s)
+
+	if string(b) != s {
+		t.Fatalf("S2B = %q, want %q", b, s)
+	}
+	if &b[0] != unsafe.StringData(s) {
+		t.Error("S2B copied the string, want shared memory")
+	}
+	if len(b) != cap(b) {
+		t.Errorf("len %d != cap %d: appending could write past the string", len(b), cap(b))
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	if got := B2S(nil); got != "" {
+		t.Errorf("B2S(nil) = %q, want empty", got)
+	}
+	if got := B2S([]byte{}); got != "" {
+		t.Errorf("B2S([]byte{}) = %q, want empty", got)
+	}
+	if got := S2B(""); got != nil {
+		t.Errorf("S2B(\"\") = %v, want nil", got)
+	}
+}
+
+// TestB2S_AliasingHazard shows what breaks when the B2S contract is violated:
+// the "immutable" string changes underneath its users.
+func TestB2S_AliasingHazard(t *testing.T) {
+	b := []byte("ORD-1")
+	s := B2S(b)
+
+	b[4] = '9'
+	if s != "ORD-9" {
+		t.Fatalf("s = %q after mutating b, want %q: the string aliases b", s, "ORD-9")
+	}
+
+	// A map key stored via B2S is silently corrupted the same way: the entry sits in the
+	// bucket for its original hash but now compares equal to a different string.
+	buf := []byte("ORD-1")
+	m := map[string]int{B2S(buf): 1}
+	buf[4] = '2'
+
+	if _, ok := m["ORD-1"]; ok {
+		t.Error(`m["ORD-1"] still found after the key bytes changed`)
+	}
+	for k := range m {
+		if k != "ORD-2" {
+			t.Errorf("stored key = %q, want it rewritten to %q", k, "ORD-2")
+		}
+	}
+}
+
+// TestB2S_CopyIsIndependent is the safe counterpart of the hazard test.
+func TestB2S_CopyIsIndependent(t *testing.T) {
+	b := []byte("ORD-1")
+	s := string(b)
+
+	b[4] = '9'
+	if s != "ORD-1" {
+		t.Errorf("s = %q after mutating b, want %q", s, "ORD-1")
+	}
+}
@@ -0,0 +1,96 @@
+// Package workerpool runs submitted tasks on a fixed set of long-lived goroutines.
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by Submit once Shutdown has been called.
+var ErrClosed = errors.New("workerpool: pool is shut down")
+
+// Pool is a fixed-size worker pool backed by a channel of tasks.
+type Pool struct {
+	tasks chan func()
+	done  chan struct{}
+
+	// quit is closed by Shutdown to release Submits blocked on a full queue.
+	quit chan struct{}
+
+	// mu guards closed and registration in submitters. tasks is only closed once
+	// every registered Submit has returned, so no send can race the close.
+	mu         sync.Mutex
+	closed     bool
+	submitters sync.WaitGroup
+}
+
+// New starts a pool with the given number of workers. Values below 1 start a single worker.
+func New(workers int) *Pool {
+	workers = max(workers, 1)
+
+	p := &Pool{
+		tasks: make(chan func(), workers),
+		done:  make(chan struct{}),
+		quit:  make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for task := range p.tasks {
+				task()
+			}
+		})
+	}
+
+	go func() {
+		wg.Wait()
+		close(p.done)
+	}()
+
+	return p
+}
+
+// Submit queues task for execution, blocking while all workers are busy and the queue is full.
+// It returns ErrClosed if the pool has been shut down, including while Submit is blocked.
+func (p *Pool) Submit(task func()) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrClosed
+	}
+	p.submitters.Add(1)
+	p.mu.Unlock()
+	defer p.submitters.Done()
+
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-p.quit:
+		return ErrClosed
+	}
+}
+
+// Shutdown stops accepting tasks and waits for every queued task to finish.
+// If ctx ends first, Shutdown returns ctx.Err() while the workers keep draining in the background.
+// It is safe to call more than once.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.quit)
+		go func() {
+			p.submitters.Wait()
+			close(p.tasks)
+		}()
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
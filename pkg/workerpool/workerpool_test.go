@@ -0,0 +1,196 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPool_RunsEveryTaskExactlyOnce(t *testing.T) {
+	const tasks = 10_000
+
+	p := New(8)
+	runs := make([]atomic.Int32, tasks)
+	for i := range tasks {
+		if err := p.Submit(func() { runs[i].Add(1) }); err != nil {
+			t.Fatalf("Submit(%d): %v", i, err)
+		}
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	for i := range runs {
+		if n := runs[i].Load(); n != 1 {
+			t.Fatalf("task %d ran %d times, want 1", i, n)
+		}
+	}
+}
+
+func TestPool_ShutdownDrainsQueuedTasks(t *testing.T) {
+	p := New(1)
+
+	release := make(chan struct{})
+	var finished atomic.Int32
+	if err := p.Submit(func() {
+		<-release
+		finished.Add(1)
+	}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	// This task sits in the queue behind the blocked one.
+	if err := p.Submit(func() { finished.Add(1) }); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- p.Shutdown(context.Background())
+	}()
+
+	select {
+	case err := <-shutdownErr:
+		t.Fatalf("Shutdown returned %v before tasks finished", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-shutdownErr; err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if n := finished.Load(); n != 2 {
+		t.Errorf("finished %d tasks, want 2", n)
+	}
+}
+
+func TestPool_SubmitAfterShutdown(t *testing.T) {
+	p := New(2)
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	ran := false
+	if err := p.Submit(func() { ran = true }); !errors.Is(err, ErrClosed) {
+		t.Errorf("Submit after Shutdown error = %v, want %v", err, ErrClosed)
+	}
+	if ran {
+		t.Error("task submitted after Shutdown ran")
+	}
+}
+
+func TestPool_ShutdownContextExpires(t *testing.T) {
+	p := New(1)
+
+	release := make(chan struct{})
+	if err := p.Submit(func() { <-release }); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(release)
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Errorf("second Shutdown: %v", err)
+	}
+}
+
+func TestPool_ShutdownDeadlineWithBlockedSubmit(t *testing.T) {
+	p := New(1)
+
+	release := make(chan struct{})
+	var finished atomic.Int32
+	if err := p.Submit(func() {
+		<-release
+		finished.Add(1)
+	}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	// Fills the single queue slot, so the next Submit blocks.
+	if err := p.Submit(func() { finished.Add(1) }); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	submitErr := make(chan error, 1)
+	go func() {
+		submitErr <- p.Submit(func() { finished.Add(1) })
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := p.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown took %v, want it to return at the 20ms deadline", elapsed)
+	}
+
+	select {
+	case err := <-submitErr:
+		if !errors.Is(err, ErrClosed) {
+			t.Errorf("blocked Submit error = %v, want %v", err, ErrClosed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked Submit was not released by Shutdown")
+	}
+
+	close(release)
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("second Shutdown: %v", err)
+	}
+	if n := finished.Load(); n != 2 {
+		t.Errorf("finished %d tasks, want 2", n)
+	}
+}
+
+func TestPool_ConcurrentSubmitAndShutdown(t *testing.T) {
+	p := New(4)
+
+	var ran, accepted atomic.Int64
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 1000 {
+				if err := p.Submit(func() { ran.Add(1) }); err == nil {
+					accepted.Add(1)
+				}
+			}
+		})
+	}
+
+	time.Sleep(time.Millisecond)
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	wg.Wait()
+
+	if ran.Load() != accepted.Load() {
+		t.Errorf("ran %d tasks, accepted %d", ran.Load(), accepted.Load())
+	}
+}
+
+func TestNew_ClampsWorkers(t *testing.T) {
+	p := New(0)
+
+	done := make(chan struct{})
+	if err := p.Submit(func() { close(done) }); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("task never ran on a pool created with 0 workers")
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+}